@@ -0,0 +1,196 @@
+package cmd
+
+import (
+	"flag"
+	"fmt"
+	"io"
+
+	"github.com/gwyn/gh-subissue/internal/api"
+	"github.com/gwyn/gh-subissue/internal/debug"
+)
+
+// AddOptions contains the parsed command line options for the add command.
+type AddOptions struct {
+	Parent int
+	Issues []string // issue numbers or URLs to link
+	Repo   string
+}
+
+// ParseAddFlags parses command line flags for the add command.
+func ParseAddFlags(args []string) (*AddOptions, error) {
+	debug.Log("ParseAddFlags", "args", args)
+
+	opts := &AddOptions{}
+	fs := flag.NewFlagSet("add", flag.ContinueOnError)
+
+	fs.IntVar(&opts.Parent, "parent", 0, "Parent issue number")
+	fs.IntVar(&opts.Parent, "p", 0, "Parent issue number")
+
+	fs.StringVar(&opts.Repo, "repo", "", "Repository (owner/repo)")
+	fs.StringVar(&opts.Repo, "R", "", "Repository (owner/repo)")
+
+	positional, flagArgs := splitArgs(args, "-p", "--parent", "-parent", "-R", "--repo", "-repo")
+	if err := fs.Parse(flagArgs); err != nil {
+		debug.Error("ParseAddFlags", err, "stage", "fs.Parse")
+		return nil, err
+	}
+	opts.Issues = positional
+
+	debug.Log("ParseAddFlags", "parsed", fmt.Sprintf("%+v", opts))
+	return opts, nil
+}
+
+// AddAPIClient defines the interface for add operations.
+type AddAPIClient interface {
+	GetIssue(owner, repo string, number int) (*api.Issue, error)
+	ListIssues(opts api.ListIssuesOptions) ([]api.Issue, error)
+	LinkSubIssue(opts api.LinkSubIssueOptions) error
+}
+
+// AddRunner executes the add subcommand.
+type AddRunner struct {
+	Client   AddAPIClient
+	Owner    string
+	Repo     string
+	Out      io.Writer
+	Prompter Prompter // nil means non-interactive mode
+}
+
+// Run executes the add command.
+func (r *AddRunner) Run(opts AddOptions) error {
+	debug.Log("AddRunner.Run", "parent", opts.Parent, "issues", opts.Issues, "has_prompter", r.Prompter != nil)
+
+	parent := opts.Parent
+
+	// If no parent specified, prompt for selection
+	if parent == 0 {
+		if r.Prompter == nil {
+			return fmt.Errorf("--parent flag is required when not running interactively\nTo find parent issues: gh issue list -R %s/%s", r.Owner, r.Repo)
+		}
+
+		issues, err := r.listOpenIssues()
+		if err != nil {
+			return err
+		}
+
+		selected, err := SelectParentIssue(r.Prompter, issues)
+		if err != nil {
+			debug.Error("AddRunner.Run", err, "stage", "select_parent")
+			return err
+		}
+		parent = selected
+	}
+
+	// Resolve the issues to link, either from arguments or interactively
+	var targets []addTarget
+	var failed int
+	total := len(opts.Issues)
+	if len(opts.Issues) == 0 {
+		if r.Prompter == nil {
+			return fmt.Errorf("at least one issue is required when not running interactively\nExample: gh subissue add 43 44 --parent %d", parent)
+		}
+
+		issues, err := r.listOpenIssues()
+		if err != nil {
+			return err
+		}
+
+		var candidates []api.Issue
+		for _, issue := range issues {
+			if issue.Number != parent {
+				candidates = append(candidates, issue)
+			}
+		}
+
+		selected, err := SelectIssues(r.Prompter, fmt.Sprintf("Select issues to add to #%d", parent), candidates)
+		if err != nil {
+			debug.Error("AddRunner.Run", err, "stage", "select_issues")
+			return err
+		}
+		for _, issue := range selected {
+			targets = append(targets, addTarget{label: fmt.Sprintf("#%d", issue.Number), id: issue.ID})
+		}
+		total = len(targets)
+	} else {
+		for _, arg := range opts.Issues {
+			target, err := r.resolve(arg)
+			if err != nil {
+				debug.Error("AddRunner.Run", err, "stage", "resolve_issue", "arg", arg)
+				fmt.Fprintf(r.Out, "Failed to add %s: %v\n", arg, err)
+				failed++
+				continue
+			}
+			targets = append(targets, target)
+		}
+	}
+
+	// Link each issue, reporting each result on its own line
+	for _, target := range targets {
+		debug.Log("AddRunner.Run", "action", "linking_sub_issue", "parent", parent, "sub_issue", target.label, "sub_issue_id", target.id)
+		err := r.Client.LinkSubIssue(api.LinkSubIssueOptions{
+			Owner:       r.Owner,
+			Repo:        r.Repo,
+			ParentIssue: parent,
+			SubIssueID:  target.id,
+		})
+		if err != nil {
+			debug.Error("AddRunner.Run", err, "stage", "link_sub_issue", "sub_issue", target.label)
+			fmt.Fprintf(r.Out, "Failed to add %s: %v\n", target.label, err)
+			failed++
+			continue
+		}
+		fmt.Fprintf(r.Out, "Added %s as a sub-issue of #%d\n", target.label, parent)
+	}
+
+	if failed > 0 {
+		err := fmt.Errorf("failed to add %d of %d issues", failed, total)
+		debug.Error("AddRunner.Run", err)
+		return err
+	}
+
+	debug.Log("AddRunner.Run", "result", "success", "linked", len(targets))
+	return nil
+}
+
+// addTarget is an issue resolved for linking.
+type addTarget struct {
+	label string // how the issue is shown in output, e.g. "#43" or "owner/repo#43"
+	id    int64  // issue ID (not number) required by the sub-issues API
+}
+
+// resolve looks up an issue number or URL to get the issue ID needed for linking.
+func (r *AddRunner) resolve(arg string) (addTarget, error) {
+	ref, err := ParseIssueRef(arg)
+	if err != nil {
+		return addTarget{}, err
+	}
+
+	owner, repo := r.Owner, r.Repo
+	if ref.Owner != "" && (ref.Owner != r.Owner || ref.Repo != r.Repo) {
+		owner, repo = ref.Owner, ref.Repo
+	} else {
+		ref = IssueRef{Number: ref.Number}
+	}
+
+	issue, err := r.Client.GetIssue(owner, repo, ref.Number)
+	if err != nil {
+		return addTarget{}, err
+	}
+
+	return addTarget{label: ref.String(), id: issue.ID}, nil
+}
+
+// listOpenIssues lists open issues in the repository for interactive selection.
+func (r *AddRunner) listOpenIssues() ([]api.Issue, error) {
+	issues, err := r.Client.ListIssues(api.ListIssuesOptions{
+		Owner: r.Owner,
+		Repo:  r.Repo,
+		State: "open",
+		Limit: pickerIssueLimit,
+	})
+	if err != nil {
+		debug.Error("AddRunner.listOpenIssues", err, "stage", "list_issues")
+		return nil, fmt.Errorf("failed to list issues: %w", err)
+	}
+	return issues, nil
+}
@@ -0,0 +1,275 @@
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/gwyn/gh-subissue/internal/api"
+)
+
+func TestParseAddFlags(t *testing.T) {
+	tests := []struct {
+		name       string
+		args       []string
+		wantParent int
+		wantIssues []string
+		wantRepo   string
+		wantErr    bool
+	}{
+		{
+			name:       "issues before flags",
+			args:       []string{"43", "44", "--parent", "42"},
+			wantParent: 42,
+			wantIssues: []string{"43", "44"},
+		},
+		{
+			name:       "flags before issues",
+			args:       []string{"-p", "42", "-R", "owner/repo", "43"},
+			wantParent: 42,
+			wantIssues: []string{"43"},
+			wantRepo:   "owner/repo",
+		},
+		{
+			name:       "issue URL",
+			args:       []string{"https://github.com/owner/repo/issues/43", "-p", "42"},
+			wantParent: 42,
+			wantIssues: []string{"https://github.com/owner/repo/issues/43"},
+		},
+		{
+			name:       "no arguments",
+			args:       []string{},
+			wantParent: 0,
+		},
+		{
+			name:    "invalid parent",
+			args:    []string{"43", "--parent", "abc"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts, err := ParseAddFlags(tt.args)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ParseAddFlags() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			if opts.Parent != tt.wantParent {
+				t.Errorf("Parent = %d, want %d", opts.Parent, tt.wantParent)
+			}
+			if strings.Join(opts.Issues, ",") != strings.Join(tt.wantIssues, ",") {
+				t.Errorf("Issues = %v, want %v", opts.Issues, tt.wantIssues)
+			}
+			if opts.Repo != tt.wantRepo {
+				t.Errorf("Repo = %q, want %q", opts.Repo, tt.wantRepo)
+			}
+		})
+	}
+}
+
+// mockAddAPIClient implements the AddAPIClient interface for testing.
+type mockAddAPIClient struct {
+	getIssueFunc     func(owner, repo string, number int) (*api.Issue, error)
+	listIssuesFunc   func(opts api.ListIssuesOptions) ([]api.Issue, error)
+	linkSubIssueFunc func(opts api.LinkSubIssueOptions) error
+}
+
+func (m *mockAddAPIClient) GetIssue(owner, repo string, number int) (*api.Issue, error) {
+	if m.getIssueFunc != nil {
+		return m.getIssueFunc(owner, repo, number)
+	}
+	return &api.Issue{ID: int64(number * 1000), Number: number}, nil
+}
+
+func (m *mockAddAPIClient) ListIssues(opts api.ListIssuesOptions) ([]api.Issue, error) {
+	if m.listIssuesFunc != nil {
+		return m.listIssuesFunc(opts)
+	}
+	return []api.Issue{}, nil
+}
+
+func (m *mockAddAPIClient) LinkSubIssue(opts api.LinkSubIssueOptions) error {
+	if m.linkSubIssueFunc != nil {
+		return m.linkSubIssueFunc(opts)
+	}
+	return nil
+}
+
+// Compile-time check
+var _ AddAPIClient = (*mockAddAPIClient)(nil)
+
+func TestAddRunnerLinksIssues(t *testing.T) {
+	var linked []int64
+	client := &mockAddAPIClient{
+		linkSubIssueFunc: func(opts api.LinkSubIssueOptions) error {
+			if opts.ParentIssue != 42 {
+				t.Errorf("expected parent 42, got %d", opts.ParentIssue)
+			}
+			linked = append(linked, opts.SubIssueID)
+			return nil
+		},
+	}
+
+	var output bytes.Buffer
+	runner := &AddRunner{
+		Client: client,
+		Owner:  "owner",
+		Repo:   "repo",
+		Out:    &output,
+	}
+
+	err := runner.Run(AddOptions{Parent: 42, Issues: []string{"43", "#44"}})
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+
+	if len(linked) != 2 || linked[0] != 43000 || linked[1] != 44000 {
+		t.Errorf("linked IDs = %v, want [43000 44000]", linked)
+	}
+	want := "Added #43 as a sub-issue of #42\nAdded #44 as a sub-issue of #42\n"
+	if output.String() != want {
+		t.Errorf("output = %q, want %q", output.String(), want)
+	}
+}
+
+func TestAddRunnerIssueURLFromOtherRepo(t *testing.T) {
+	client := &mockAddAPIClient{
+		getIssueFunc: func(owner, repo string, number int) (*api.Issue, error) {
+			if owner != "other" || repo != "service" || number != 7 {
+				t.Errorf("GetIssue(%s, %s, %d), want (other, service, 7)", owner, repo, number)
+			}
+			return &api.Issue{ID: 700, Number: 7}, nil
+		},
+	}
+
+	var output bytes.Buffer
+	runner := &AddRunner{
+		Client: client,
+		Owner:  "owner",
+		Repo:   "repo",
+		Out:    &output,
+	}
+
+	err := runner.Run(AddOptions{Parent: 42, Issues: []string{"https://github.com/other/service/issues/7"}})
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	if !strings.Contains(output.String(), "Added other/service#7 as a sub-issue of #42") {
+		t.Errorf("unexpected output: %q", output.String())
+	}
+}
+
+func TestAddRunnerPartialFailure(t *testing.T) {
+	client := &mockAddAPIClient{
+		getIssueFunc: func(owner, repo string, number int) (*api.Issue, error) {
+			if number == 99 {
+				return nil, errors.New("not found")
+			}
+			return &api.Issue{ID: int64(number), Number: number}, nil
+		},
+		linkSubIssueFunc: func(opts api.LinkSubIssueOptions) error {
+			if opts.SubIssueID == 44 {
+				return errors.New("already a sub-issue")
+			}
+			return nil
+		},
+	}
+
+	var output bytes.Buffer
+	runner := &AddRunner{
+		Client: client,
+		Owner:  "owner",
+		Repo:   "repo",
+		Out:    &output,
+	}
+
+	err := runner.Run(AddOptions{Parent: 42, Issues: []string{"43", "44", "99", "abc"}})
+	if err == nil {
+		t.Fatal("expected error when some links fail")
+	}
+	if !strings.Contains(err.Error(), "3 of 4") {
+		t.Errorf("error = %q, want it to mention 3 of 4", err.Error())
+	}
+
+	out := output.String()
+	for _, want := range []string{
+		"Added #43 as a sub-issue of #42",
+		"Failed to add #44: already a sub-issue",
+		"Failed to add 99: not found",
+		"Failed to add abc:",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q, got %q", want, out)
+		}
+	}
+}
+
+func TestAddRunnerInteractive(t *testing.T) {
+	var linked []int64
+	client := &mockAddAPIClient{
+		listIssuesFunc: func(opts api.ListIssuesOptions) ([]api.Issue, error) {
+			return []api.Issue{
+				{ID: 100, Number: 10, Title: "Parent"},
+				{ID: 200, Number: 20, Title: "Task A"},
+				{ID: 300, Number: 30, Title: "Task B"},
+			}, nil
+		},
+		linkSubIssueFunc: func(opts api.LinkSubIssueOptions) error {
+			linked = append(linked, opts.SubIssueID)
+			return nil
+		},
+	}
+
+	var multiOptions []string
+	prompter := &mockPrompter{
+		selectFunc: func(prompt, defaultValue string, options []string) (int, error) {
+			return 0, nil // parent #10
+		},
+		multiSelectFunc: func(prompt string, defaultValues, options []string) ([]int, error) {
+			multiOptions = options
+			return []int{0, 1}, nil
+		},
+	}
+
+	var output bytes.Buffer
+	runner := &AddRunner{
+		Client:   client,
+		Owner:    "owner",
+		Repo:     "repo",
+		Out:      &output,
+		Prompter: prompter,
+	}
+
+	if err := runner.Run(AddOptions{}); err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+
+	if len(multiOptions) != 2 {
+		t.Errorf("expected parent to be excluded from options, got %v", multiOptions)
+	}
+	if len(linked) != 2 || linked[0] != 200 || linked[1] != 300 {
+		t.Errorf("linked IDs = %v, want [200 300]", linked)
+	}
+}
+
+func TestAddRunnerNoPrompterRequiresIssues(t *testing.T) {
+	var output bytes.Buffer
+	runner := &AddRunner{
+		Client: &mockAddAPIClient{},
+		Owner:  "owner",
+		Repo:   "repo",
+		Out:    &output,
+	}
+
+	if err := runner.Run(AddOptions{Parent: 42}); err == nil {
+		t.Error("expected error when no issues given without prompter")
+	}
+	if err := runner.Run(AddOptions{Issues: []string{"43"}}); err == nil {
+		t.Error("expected error when no parent given without prompter")
+	}
+}
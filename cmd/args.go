@@ -0,0 +1,169 @@
+package cmd
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/gwyn/gh-subissue/internal/api"
+	"github.com/gwyn/gh-subissue/internal/debug"
+)
+
+// IssueRef identifies an issue, optionally in a specific repository.
+// Owner and Repo are empty when the reference did not name a repository.
+type IssueRef struct {
+	Owner  string
+	Repo   string
+	Number int
+}
+
+// String formats the reference as "#123" or "owner/repo#123".
+func (r IssueRef) String() string {
+	if r.Owner == "" || r.Repo == "" {
+		return fmt.Sprintf("#%d", r.Number)
+	}
+	return fmt.Sprintf("%s/%s#%d", r.Owner, r.Repo, r.Number)
+}
+
+// RelativeTo formats the reference as "#123" when it is in owner/repo, and
+// as "owner/repo#123" otherwise.
+func (r IssueRef) RelativeTo(owner, repo string) string {
+	if r.Owner == "" || (strings.EqualFold(r.Owner, owner) && strings.EqualFold(r.Repo, repo)) {
+		return fmt.Sprintf("#%d", r.Number)
+	}
+	return r.String()
+}
+
+// issueRefOf returns a reference to an issue returned by the API, assuming
+// owner/repo when the response does not name a repository.
+func issueRefOf(issue api.Issue, owner, repo string) IssueRef {
+	ref := IssueRef{Owner: owner, Repo: repo, Number: issue.Number}
+	if o, n := issue.Repository(); o != "" {
+		ref.Owner, ref.Repo = o, n
+	}
+	return ref
+}
+
+// sameIssue reports whether two references name the same issue. Owner and
+// repository names are compared without regard to case, as GitHub does.
+func sameIssue(a, b IssueRef) bool {
+	return a.Number == b.Number && strings.EqualFold(a.Owner, b.Owner) && strings.EqualFold(a.Repo, b.Repo)
+}
+
+// findIssue returns the index of the issue in issues that ref names, or -1
+// when there is none. Issues and a ref without a repository are taken to be
+// in owner/repo. A bare number prefers an issue in owner/repo; when it
+// matches issues in several other repositories, it is ambiguous and an error
+// asks for the repository.
+func findIssue(issues []api.Issue, ref IssueRef, owner, repo string) (int, error) {
+	bare := ref.Owner == ""
+	if bare {
+		ref.Owner, ref.Repo = owner, repo
+	}
+
+	var candidates []int
+	for i, issue := range issues {
+		issueRef := issueRefOf(issue, owner, repo)
+		if sameIssue(issueRef, ref) {
+			return i, nil
+		}
+		if bare && issueRef.Number == ref.Number {
+			candidates = append(candidates, i)
+		}
+	}
+
+	switch len(candidates) {
+	case 0:
+		return -1, nil
+	case 1:
+		return candidates[0], nil
+	}
+	names := make([]string, len(candidates))
+	for i, idx := range candidates {
+		names[i] = issueRefOf(issues[idx], owner, repo).String()
+	}
+	return -1, fmt.Errorf("#%d matches sub-issues in several repositories (%s); use owner/repo#%d to pick one",
+		ref.Number, strings.Join(names, ", "), ref.Number)
+}
+
+// ParseIssueRef parses an issue number ("123" or "#123"), a qualified
+// reference ("owner/repo#123") or an issue URL ("https://github.com/owner/repo/issues/123").
+func ParseIssueRef(s string) (IssueRef, error) {
+	debug.Log("ParseIssueRef", "input", s)
+
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return IssueRef{}, fmt.Errorf("issue reference cannot be empty")
+	}
+
+	if strings.HasPrefix(s, "https://") || strings.HasPrefix(s, "http://") {
+		u, err := url.Parse(s)
+		if err != nil {
+			return IssueRef{}, fmt.Errorf("invalid issue URL %q: %w", s, err)
+		}
+		parts := strings.Split(strings.Trim(u.Path, "/"), "/")
+		if len(parts) != 4 || parts[2] != "issues" {
+			return IssueRef{}, fmt.Errorf("invalid issue URL %q (expected https://HOST/OWNER/REPO/issues/NUMBER)", s)
+		}
+		number, err := strconv.Atoi(parts[3])
+		if err != nil || number <= 0 {
+			return IssueRef{}, fmt.Errorf("invalid issue number in URL %q", s)
+		}
+		ref := IssueRef{Owner: parts[0], Repo: parts[1], Number: number}
+		debug.Log("ParseIssueRef", "owner", ref.Owner, "repo", ref.Repo, "number", ref.Number)
+		return ref, nil
+	}
+
+	if repoPart, numPart, ok := strings.Cut(s, "#"); ok && repoPart != "" {
+		owner, repo, found := strings.Cut(repoPart, "/")
+		if !found || owner == "" || repo == "" || strings.Contains(repo, "/") {
+			return IssueRef{}, fmt.Errorf("invalid issue reference %q (expected OWNER/REPO#NUMBER)", s)
+		}
+		number, err := strconv.Atoi(numPart)
+		if err != nil || number <= 0 {
+			return IssueRef{}, fmt.Errorf("invalid issue number in %q", s)
+		}
+		ref := IssueRef{Owner: owner, Repo: repo, Number: number}
+		debug.Log("ParseIssueRef", "owner", ref.Owner, "repo", ref.Repo, "number", ref.Number)
+		return ref, nil
+	}
+
+	number, err := strconv.Atoi(strings.TrimPrefix(s, "#"))
+	if err != nil || number <= 0 {
+		return IssueRef{}, fmt.Errorf("invalid issue number: %s", s)
+	}
+
+	debug.Log("ParseIssueRef", "number", number)
+	return IssueRef{Number: number}, nil
+}
+
+// splitArgs separates positional arguments from flags so that flags may
+// appear after positional arguments. Go's flag package stops at the first
+// non-flag, so the flags are returned separately for fs.Parse.
+// valueFlags lists the flags (with dashes) that consume the following argument.
+func splitArgs(args []string, valueFlags ...string) (positional, flagArgs []string) {
+	takesValue := make(map[string]bool, len(valueFlags))
+	for _, f := range valueFlags {
+		takesValue[f] = true
+	}
+
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		if arg == "--" {
+			positional = append(positional, args[i+1:]...)
+			break
+		}
+		if len(arg) > 1 && arg[0] == '-' {
+			flagArgs = append(flagArgs, arg)
+			if takesValue[arg] && i+1 < len(args) {
+				i++
+				flagArgs = append(flagArgs, args[i])
+			}
+			continue
+		}
+		positional = append(positional, arg)
+	}
+
+	return positional, flagArgs
+}
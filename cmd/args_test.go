@@ -0,0 +1,75 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseIssueRef(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    IssueRef
+		wantErr bool
+	}{
+		{name: "plain number", input: "43", want: IssueRef{Number: 43}},
+		{name: "hash number", input: "#43", want: IssueRef{Number: 43}},
+		{
+			name:  "issue URL",
+			input: "https://github.com/owner/repo/issues/43",
+			want:  IssueRef{Owner: "owner", Repo: "repo", Number: 43},
+		},
+		{
+			name:  "enterprise issue URL",
+			input: "https://ghe.example.com/owner/repo/issues/7/",
+			want:  IssueRef{Owner: "owner", Repo: "repo", Number: 7},
+		},
+		{
+			name:  "qualified reference",
+			input: "owner/planning#42",
+			want:  IssueRef{Owner: "owner", Repo: "planning", Number: 42},
+		},
+		{name: "qualified reference without repo", input: "owner#42", wantErr: true},
+		{name: "qualified reference without number", input: "owner/repo#", wantErr: true},
+		{name: "pull request URL", input: "https://github.com/owner/repo/pull/43", wantErr: true},
+		{name: "not a number", input: "abc", wantErr: true},
+		{name: "zero", input: "0", wantErr: true},
+		{name: "empty", input: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseIssueRef(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ParseIssueRef() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("ParseIssueRef() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIssueRefString(t *testing.T) {
+	if got := (IssueRef{Number: 5}).String(); got != "#5" {
+		t.Errorf("String() = %q, want #5", got)
+	}
+	if got := (IssueRef{Owner: "o", Repo: "r", Number: 5}).String(); got != "o/r#5" {
+		t.Errorf("String() = %q, want o/r#5", got)
+	}
+}
+
+func TestSplitArgs(t *testing.T) {
+	positional, flagArgs := splitArgs(
+		[]string{"43", "-p", "42", "44", "--yes", "--repo", "o/r", "--", "-45"},
+		"-p", "--repo",
+	)
+
+	if got := strings.Join(positional, " "); got != "43 44 -45" {
+		t.Errorf("positional = %q, want %q", got, "43 44 -45")
+	}
+	if got := strings.Join(flagArgs, " "); got != "-p 42 --yes --repo o/r" {
+		t.Errorf("flagArgs = %q, want %q", got, "-p 42 --yes --repo o/r")
+	}
+}
@@ -0,0 +1,370 @@
+package cmd
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/gwyn/gh-subissue/internal/api"
+	"github.com/gwyn/gh-subissue/internal/debug"
+)
+
+// CloseOptions contains the parsed command line options for the close command.
+type CloseOptions struct {
+	Issue   string // issue number or URL (interactive if empty)
+	Repo    string
+	Reason  string // "completed" or "not_planned"
+	Cascade bool   // close open descendants first
+	Force   bool   // close the issue even with open descendants
+}
+
+// ParseCloseFlags parses command line flags for the close command.
+func ParseCloseFlags(args []string) (*CloseOptions, error) {
+	debug.Log("ParseCloseFlags", "args", args)
+
+	opts := &CloseOptions{}
+	fs := flag.NewFlagSet("close", flag.ContinueOnError)
+
+	fs.StringVar(&opts.Repo, "repo", "", "Repository (owner/repo)")
+	fs.StringVar(&opts.Repo, "R", "", "Repository (owner/repo)")
+
+	fs.StringVar(&opts.Reason, "reason", "completed", "Reason for closing: {completed|not_planned}")
+	fs.StringVar(&opts.Reason, "r", "completed", "Reason for closing: {completed|not_planned}")
+
+	fs.BoolVar(&opts.Cascade, "cascade", false, "Close all open sub-issues, bottom-up, before the issue")
+	fs.BoolVar(&opts.Force, "force", false, "Close only the issue, leaving its sub-issues open")
+
+	positional, flagArgs := splitArgs(args, "-R", "--repo", "-repo", "-r", "--reason", "-reason")
+	if err := fs.Parse(flagArgs); err != nil {
+		debug.Error("ParseCloseFlags", err, "stage", "fs.Parse")
+		return nil, err
+	}
+
+	if len(positional) > 1 {
+		return nil, fmt.Errorf("only one issue can be closed at a time, got %d", len(positional))
+	}
+	if len(positional) == 1 {
+		opts.Issue = positional[0]
+	}
+
+	switch opts.Reason {
+	case "completed", "not_planned":
+	default:
+		return nil, fmt.Errorf("invalid reason %q (expected completed or not_planned)", opts.Reason)
+	}
+	if opts.Cascade && opts.Force {
+		return nil, errors.New("specify only one of --cascade or --force")
+	}
+
+	debug.Log("ParseCloseFlags", "parsed", fmt.Sprintf("%+v", opts))
+	return opts, nil
+}
+
+// ReopenOptions contains the parsed command line options for the reopen command.
+type ReopenOptions struct {
+	Issue   string // issue number or URL (interactive if empty)
+	Repo    string
+	Cascade bool // reopen closed descendants too
+}
+
+// ParseReopenFlags parses command line flags for the reopen command.
+func ParseReopenFlags(args []string) (*ReopenOptions, error) {
+	debug.Log("ParseReopenFlags", "args", args)
+
+	opts := &ReopenOptions{}
+	fs := flag.NewFlagSet("reopen", flag.ContinueOnError)
+
+	fs.StringVar(&opts.Repo, "repo", "", "Repository (owner/repo)")
+	fs.StringVar(&opts.Repo, "R", "", "Repository (owner/repo)")
+
+	fs.BoolVar(&opts.Cascade, "cascade", false, "Reopen all closed sub-issues, top-down, after the issue")
+
+	positional, flagArgs := splitArgs(args, "-R", "--repo", "-repo")
+	if err := fs.Parse(flagArgs); err != nil {
+		debug.Error("ParseReopenFlags", err, "stage", "fs.Parse")
+		return nil, err
+	}
+
+	if len(positional) > 1 {
+		return nil, fmt.Errorf("only one issue can be reopened at a time, got %d", len(positional))
+	}
+	if len(positional) == 1 {
+		opts.Issue = positional[0]
+	}
+
+	debug.Log("ParseReopenFlags", "parsed", fmt.Sprintf("%+v", opts))
+	return opts, nil
+}
+
+// CloseAPIClient defines the interface for close and reopen operations.
+type CloseAPIClient interface {
+	GetIssue(owner, repo string, number int) (*api.Issue, error)
+	ListIssues(opts api.ListIssuesOptions) ([]api.Issue, error)
+	ListSubIssues(opts api.ListSubIssuesOptions) ([]api.Issue, error)
+	UpdateIssue(opts api.UpdateIssueOptions) (*api.Issue, error)
+}
+
+// CloseRunner executes the close subcommand.
+type CloseRunner struct {
+	Client   CloseAPIClient
+	Owner    string
+	Repo     string
+	Out      io.Writer
+	Prompter Prompter // nil means non-interactive mode
+}
+
+// descendant is an issue below the one being closed or reopened.
+type descendant struct {
+	issue api.Issue
+	ref   IssueRef
+	depth int // 1 for direct sub-issues
+}
+
+// Run executes the close command.
+func (r *CloseRunner) Run(opts CloseOptions) error {
+	debug.Log("CloseRunner.Run", "issue", opts.Issue, "reason", opts.Reason, "cascade", opts.Cascade, "force", opts.Force)
+
+	if opts.Reason == "" {
+		opts.Reason = "completed"
+	}
+
+	ref, err := resolveIssueArg(r.Client, r.Prompter, r.Owner, r.Repo, opts.Issue, "open", "Select issue to close", "close")
+	if err != nil {
+		return err
+	}
+
+	issue, err := r.Client.GetIssue(ref.Owner, ref.Repo, ref.Number)
+	if err != nil {
+		debug.Error("CloseRunner.Run", err, "stage", "get_issue")
+		return err
+	}
+
+	if issue.State == "closed" && !opts.Cascade {
+		fmt.Fprintf(r.Out, "Issue #%d is already closed\n", issue.Number)
+		debug.Log("CloseRunner.Run", "result", "already_closed")
+		return nil
+	}
+
+	var open []descendant
+	if !opts.Force {
+		all, err := listDescendants(r.Client, ref)
+		if err != nil {
+			return err
+		}
+		for _, d := range all {
+			if d.issue.State == "open" {
+				open = append(open, d)
+			}
+		}
+	}
+
+	if len(open) > 0 && !opts.Cascade {
+		var b strings.Builder
+		fmt.Fprintf(&b, "issue #%d has %d open sub-issues:\n", issue.Number, len(open))
+		for _, d := range open {
+			fmt.Fprintf(&b, "%s%s %s\n", strings.Repeat("  ", d.depth), d.ref.RelativeTo(ref.Owner, ref.Repo), d.issue.Title)
+		}
+		fmt.Fprintf(&b, "Use --cascade to close them too, or --force to close only #%d", issue.Number)
+		debug.Log("CloseRunner.Run", "result", "refused", "open_descendants", len(open))
+		return errors.New(b.String())
+	}
+
+	reason := strings.ReplaceAll(opts.Reason, "_", " ")
+
+	// Pre-order reversed closes every sub-issue before its parent
+	for i := len(open) - 1; i >= 0; i-- {
+		d := open[i]
+		if err := r.close(d.ref, opts.Reason); err != nil {
+			return fmt.Errorf("failed to close %s: %w\n#%d was left open", d.ref.RelativeTo(ref.Owner, ref.Repo), err, issue.Number)
+		}
+		fmt.Fprintf(r.Out, "Closed %s %s (%s)\n", d.ref.RelativeTo(ref.Owner, ref.Repo), d.issue.Title, reason)
+	}
+
+	if issue.State == "closed" {
+		fmt.Fprintf(r.Out, "Issue #%d is already closed\n", issue.Number)
+		debug.Log("CloseRunner.Run", "result", "already_closed", "closed_descendants", len(open))
+		return nil
+	}
+
+	if err := r.close(ref, opts.Reason); err != nil {
+		return fmt.Errorf("failed to close #%d: %w", issue.Number, err)
+	}
+	fmt.Fprintf(r.Out, "Closed #%d %s (%s)\n", issue.Number, issue.Title, reason)
+
+	debug.Log("CloseRunner.Run", "result", "success", "closed_descendants", len(open))
+	return nil
+}
+
+// close closes one issue with the given state reason.
+func (r *CloseRunner) close(ref IssueRef, reason string) error {
+	state := "closed"
+	_, err := r.Client.UpdateIssue(api.UpdateIssueOptions{
+		Owner:       ref.Owner,
+		Repo:        ref.Repo,
+		Number:      ref.Number,
+		State:       &state,
+		StateReason: &reason,
+	})
+	if err != nil {
+		debug.Error("CloseRunner.close", err, "issue", ref.String())
+	}
+	return err
+}
+
+// ReopenRunner executes the reopen subcommand.
+type ReopenRunner struct {
+	Client   CloseAPIClient
+	Owner    string
+	Repo     string
+	Out      io.Writer
+	Prompter Prompter // nil means non-interactive mode
+}
+
+// Run executes the reopen command.
+func (r *ReopenRunner) Run(opts ReopenOptions) error {
+	debug.Log("ReopenRunner.Run", "issue", opts.Issue, "cascade", opts.Cascade)
+
+	ref, err := resolveIssueArg(r.Client, r.Prompter, r.Owner, r.Repo, opts.Issue, "closed", "Select issue to reopen", "reopen")
+	if err != nil {
+		return err
+	}
+
+	issue, err := r.Client.GetIssue(ref.Owner, ref.Repo, ref.Number)
+	if err != nil {
+		debug.Error("ReopenRunner.Run", err, "stage", "get_issue")
+		return err
+	}
+
+	if issue.State == "open" {
+		fmt.Fprintf(r.Out, "Issue #%d is already open\n", issue.Number)
+	} else {
+		if err := r.reopen(ref); err != nil {
+			return fmt.Errorf("failed to reopen #%d: %w", issue.Number, err)
+		}
+		fmt.Fprintf(r.Out, "Reopened #%d %s\n", issue.Number, issue.Title)
+	}
+
+	if !opts.Cascade {
+		debug.Log("ReopenRunner.Run", "result", "success")
+		return nil
+	}
+
+	descendants, err := listDescendants(r.Client, ref)
+	if err != nil {
+		return err
+	}
+
+	// Pre-order reopens every parent before its sub-issues
+	reopened := 0
+	for _, d := range descendants {
+		if d.issue.State != "closed" {
+			continue
+		}
+		if err := r.reopen(d.ref); err != nil {
+			return fmt.Errorf("failed to reopen %s: %w", d.ref.RelativeTo(ref.Owner, ref.Repo), err)
+		}
+		fmt.Fprintf(r.Out, "Reopened %s %s\n", d.ref.RelativeTo(ref.Owner, ref.Repo), d.issue.Title)
+		reopened++
+	}
+
+	debug.Log("ReopenRunner.Run", "result", "success", "reopened_descendants", reopened)
+	return nil
+}
+
+// reopen reopens one issue.
+func (r *ReopenRunner) reopen(ref IssueRef) error {
+	state := "open"
+	_, err := r.Client.UpdateIssue(api.UpdateIssueOptions{
+		Owner:  ref.Owner,
+		Repo:   ref.Repo,
+		Number: ref.Number,
+		State:  &state,
+	})
+	if err != nil {
+		debug.Error("ReopenRunner.reopen", err, "issue", ref.String())
+	}
+	return err
+}
+
+// resolveIssueArg parses an issue argument, or prompts for an issue in the
+// given state when the argument is empty.
+func resolveIssueArg(client CloseAPIClient, p Prompter, owner, repo, arg, state, prompt, command string) (IssueRef, error) {
+	if arg != "" {
+		ref, err := ParseIssueRef(arg)
+		if err != nil {
+			return IssueRef{}, err
+		}
+		if ref.Owner == "" {
+			ref.Owner, ref.Repo = owner, repo
+		}
+		return ref, nil
+	}
+
+	if p == nil {
+		return IssueRef{}, fmt.Errorf("issue number is required when not running interactively\nExample: gh subissue %s 42 -R %s/%s", command, owner, repo)
+	}
+
+	issues, err := client.ListIssues(api.ListIssuesOptions{
+		Owner: owner,
+		Repo:  repo,
+		State: state,
+		Limit: pickerIssueLimit,
+	})
+	if err != nil {
+		debug.Error("resolveIssueArg", err, "stage", "list_issues")
+		return IssueRef{}, fmt.Errorf("failed to list issues: %w", err)
+	}
+	if len(issues) == 0 {
+		return IssueRef{}, fmt.Errorf("no %s issues found in %s/%s", state, owner, repo)
+	}
+
+	idx, err := p.Select(prompt, "", issueOptions(issues))
+	if err != nil {
+		debug.Error("resolveIssueArg", err, "stage", "select_issue")
+		return IssueRef{}, err
+	}
+	return IssueRef{Owner: owner, Repo: repo, Number: issues[idx].Number}, nil
+}
+
+// listDescendants walks the sub-issues below root in pre-order. Sub-issues
+// may live in other repositories; visited guards against cycles.
+func listDescendants(client CloseAPIClient, root IssueRef) ([]descendant, error) {
+	var result []descendant
+	visited := map[string]bool{strings.ToLower(root.String()): true}
+
+	var walk func(parent IssueRef, depth int) error
+	walk = func(parent IssueRef, depth int) error {
+		children, err := client.ListSubIssues(api.ListSubIssuesOptions{
+			Owner:       parent.Owner,
+			Repo:        parent.Repo,
+			ParentIssue: parent.Number,
+		})
+		if err != nil {
+			debug.Error("listDescendants", err, "stage", "list_sub_issues", "issue", parent.String())
+			return fmt.Errorf("failed to list sub-issues of %s: %w", parent.RelativeTo(root.Owner, root.Repo), err)
+		}
+
+		for _, child := range children {
+			ref := issueRefOf(child, parent.Owner, parent.Repo)
+			key := strings.ToLower(ref.String())
+			if visited[key] {
+				continue
+			}
+			visited[key] = true
+
+			result = append(result, descendant{issue: child, ref: ref, depth: depth})
+			if err := walk(ref, depth+1); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	if err := walk(root, 1); err != nil {
+		return nil, err
+	}
+	debug.Log("listDescendants", "root", root.String(), "count", len(result))
+	return result, nil
+}
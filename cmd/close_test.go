@@ -0,0 +1,357 @@
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/gwyn/gh-subissue/internal/api"
+)
+
+// mockCloseAPIClient implements the CloseAPIClient interface for testing.
+type mockCloseAPIClient struct {
+	getIssueFunc      func(owner, repo string, number int) (*api.Issue, error)
+	listIssuesFunc    func(opts api.ListIssuesOptions) ([]api.Issue, error)
+	listSubIssuesFunc func(opts api.ListSubIssuesOptions) ([]api.Issue, error)
+	updateIssueFunc   func(opts api.UpdateIssueOptions) (*api.Issue, error)
+}
+
+func (m *mockCloseAPIClient) GetIssue(owner, repo string, number int) (*api.Issue, error) {
+	if m.getIssueFunc != nil {
+		return m.getIssueFunc(owner, repo, number)
+	}
+	return &api.Issue{Number: number, Title: "Issue", State: "open"}, nil
+}
+
+func (m *mockCloseAPIClient) ListIssues(opts api.ListIssuesOptions) ([]api.Issue, error) {
+	if m.listIssuesFunc != nil {
+		return m.listIssuesFunc(opts)
+	}
+	return []api.Issue{}, nil
+}
+
+func (m *mockCloseAPIClient) ListSubIssues(opts api.ListSubIssuesOptions) ([]api.Issue, error) {
+	if m.listSubIssuesFunc != nil {
+		return m.listSubIssuesFunc(opts)
+	}
+	return []api.Issue{}, nil
+}
+
+func (m *mockCloseAPIClient) UpdateIssue(opts api.UpdateIssueOptions) (*api.Issue, error) {
+	if m.updateIssueFunc != nil {
+		return m.updateIssueFunc(opts)
+	}
+	return &api.Issue{Number: opts.Number}, nil
+}
+
+// Compile-time check
+var _ CloseAPIClient = (*mockCloseAPIClient)(nil)
+
+// hierarchyClient serves a hierarchy of issues in owner/repo and records
+// every state change in order as "#N state/reason".
+func hierarchyClient(issues map[int]api.Issue, children map[int][]int, updates *[]string) *mockCloseAPIClient {
+	return &mockCloseAPIClient{
+		getIssueFunc: func(owner, repo string, number int) (*api.Issue, error) {
+			issue, ok := issues[number]
+			if !ok {
+				return nil, errors.New("not found")
+			}
+			return &issue, nil
+		},
+		listSubIssuesFunc: func(opts api.ListSubIssuesOptions) ([]api.Issue, error) {
+			var result []api.Issue
+			for _, n := range children[opts.ParentIssue] {
+				result = append(result, issues[n])
+			}
+			return result, nil
+		},
+		updateIssueFunc: func(opts api.UpdateIssueOptions) (*api.Issue, error) {
+			change := "#" + strconv.Itoa(opts.Number) + " " + *opts.State
+			if opts.StateReason != nil {
+				change += "/" + *opts.StateReason
+			}
+			*updates = append(*updates, change)
+			return &api.Issue{Number: opts.Number, State: *opts.State}, nil
+		},
+	}
+}
+
+// epic is #42 with open #43 (which has open #45 and closed #46) and closed #44.
+func epic() (map[int]api.Issue, map[int][]int) {
+	issues := map[int]api.Issue{
+		42: {ID: 1, Number: 42, Title: "Epic", State: "open"},
+		43: {ID: 2, Number: 43, Title: "Backend", State: "open"},
+		44: {ID: 3, Number: 44, Title: "Design", State: "closed"},
+		45: {ID: 4, Number: 45, Title: "API", State: "open"},
+		46: {ID: 5, Number: 46, Title: "Schema", State: "closed"},
+	}
+	children := map[int][]int{42: {43, 44}, 43: {45, 46}}
+	return issues, children
+}
+
+func TestParseCloseFlags(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		want    CloseOptions
+		wantErr bool
+	}{
+		{
+			name: "defaults",
+			args: []string{"42"},
+			want: CloseOptions{Issue: "42", Reason: "completed"},
+		},
+		{
+			name: "cascade with reason",
+			args: []string{"42", "--cascade", "--reason", "not_planned", "-R", "owner/repo"},
+			want: CloseOptions{Issue: "42", Reason: "not_planned", Cascade: true, Repo: "owner/repo"},
+		},
+		{
+			name: "force",
+			args: []string{"--force", "42"},
+			want: CloseOptions{Issue: "42", Reason: "completed", Force: true},
+		},
+		{
+			name:    "cascade and force",
+			args:    []string{"42", "--cascade", "--force"},
+			wantErr: true,
+		},
+		{
+			name:    "invalid reason",
+			args:    []string{"42", "--reason", "duplicate"},
+			wantErr: true,
+		},
+		{
+			name:    "two issues",
+			args:    []string{"42", "43"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts, err := ParseCloseFlags(tt.args)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseCloseFlags() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if *opts != tt.want {
+				t.Errorf("ParseCloseFlags() = %+v, want %+v", *opts, tt.want)
+			}
+		})
+	}
+}
+
+func TestCloseRunnerRefusesWithOpenDescendants(t *testing.T) {
+	issues, children := epic()
+	var updates []string
+	var output bytes.Buffer
+	runner := &CloseRunner{
+		Client: hierarchyClient(issues, children, &updates),
+		Owner:  "owner",
+		Repo:   "repo",
+		Out:    &output,
+	}
+
+	err := runner.Run(CloseOptions{Issue: "42", Reason: "completed"})
+	if err == nil {
+		t.Fatal("expected error")
+	}
+	want := `issue #42 has 2 open sub-issues:
+  #43 Backend
+    #45 API
+Use --cascade to close them too, or --force to close only #42`
+	if err.Error() != want {
+		t.Errorf("error =\n%s\nwant\n%s", err, want)
+	}
+	if len(updates) != 0 {
+		t.Errorf("updates = %v, want none", updates)
+	}
+}
+
+func TestCloseRunnerCascade(t *testing.T) {
+	issues, children := epic()
+	var updates []string
+	var output bytes.Buffer
+	runner := &CloseRunner{
+		Client: hierarchyClient(issues, children, &updates),
+		Owner:  "owner",
+		Repo:   "repo",
+		Out:    &output,
+	}
+
+	if err := runner.Run(CloseOptions{Issue: "42", Reason: "not_planned", Cascade: true}); err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+
+	wantUpdates := []string{"#45 closed/not_planned", "#43 closed/not_planned", "#42 closed/not_planned"}
+	if !reflect.DeepEqual(updates, wantUpdates) {
+		t.Errorf("updates = %v, want %v", updates, wantUpdates)
+	}
+	wantOut := `Closed #45 API (not planned)
+Closed #43 Backend (not planned)
+Closed #42 Epic (not planned)
+`
+	if output.String() != wantOut {
+		t.Errorf("output =\n%s\nwant\n%s", output.String(), wantOut)
+	}
+}
+
+func TestCloseRunnerCascadeStopsOnFailure(t *testing.T) {
+	issues, children := epic()
+	var updates []string
+	client := hierarchyClient(issues, children, &updates)
+	record := client.updateIssueFunc
+	client.updateIssueFunc = func(opts api.UpdateIssueOptions) (*api.Issue, error) {
+		if opts.Number == 43 {
+			return nil, errors.New("forbidden")
+		}
+		return record(opts)
+	}
+
+	runner := &CloseRunner{Client: client, Owner: "owner", Repo: "repo", Out: &bytes.Buffer{}}
+	err := runner.Run(CloseOptions{Issue: "42", Reason: "completed", Cascade: true})
+	if err == nil || !strings.Contains(err.Error(), "failed to close #43") || !strings.Contains(err.Error(), "#42 was left open") {
+		t.Fatalf("error = %v", err)
+	}
+	if !reflect.DeepEqual(updates, []string{"#45 closed/completed"}) {
+		t.Errorf("updates = %v", updates)
+	}
+}
+
+func TestCloseRunnerForce(t *testing.T) {
+	issues, children := epic()
+	var updates []string
+	client := hierarchyClient(issues, children, &updates)
+	client.listSubIssuesFunc = func(opts api.ListSubIssuesOptions) ([]api.Issue, error) {
+		t.Error("sub-issues should not be listed with --force")
+		return nil, nil
+	}
+
+	var output bytes.Buffer
+	runner := &CloseRunner{Client: client, Owner: "owner", Repo: "repo", Out: &output}
+	if err := runner.Run(CloseOptions{Issue: "42", Reason: "completed", Force: true}); err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	if !reflect.DeepEqual(updates, []string{"#42 closed/completed"}) {
+		t.Errorf("updates = %v", updates)
+	}
+	if output.String() != "Closed #42 Epic (completed)\n" {
+		t.Errorf("output = %q", output.String())
+	}
+}
+
+func TestCloseRunnerAlreadyClosed(t *testing.T) {
+	issues, children := epic()
+	var updates []string
+	var output bytes.Buffer
+	runner := &CloseRunner{
+		Client: hierarchyClient(issues, children, &updates),
+		Owner:  "owner",
+		Repo:   "repo",
+		Out:    &output,
+	}
+
+	if err := runner.Run(CloseOptions{Issue: "44", Reason: "completed"}); err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	if len(updates) != 0 {
+		t.Errorf("updates = %v, want none", updates)
+	}
+	if output.String() != "Issue #44 is already closed\n" {
+		t.Errorf("output = %q", output.String())
+	}
+}
+
+func TestCloseRunnerNonInteractiveRequiresIssue(t *testing.T) {
+	runner := &CloseRunner{Client: &mockCloseAPIClient{}, Owner: "owner", Repo: "repo", Out: &bytes.Buffer{}}
+	err := runner.Run(CloseOptions{Reason: "completed"})
+	if err == nil || !strings.Contains(err.Error(), "issue number is required") {
+		t.Errorf("error = %v", err)
+	}
+}
+
+func TestReopenRunnerCascade(t *testing.T) {
+	issues, children := epic()
+	for n, issue := range issues {
+		issue.State = "closed"
+		issues[n] = issue
+	}
+	issues[44] = api.Issue{ID: 3, Number: 44, Title: "Design", State: "open"}
+
+	var updates []string
+	var output bytes.Buffer
+	runner := &ReopenRunner{
+		Client: hierarchyClient(issues, children, &updates),
+		Owner:  "owner",
+		Repo:   "repo",
+		Out:    &output,
+	}
+
+	if err := runner.Run(ReopenOptions{Issue: "42", Cascade: true}); err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+
+	wantUpdates := []string{"#42 open", "#43 open", "#45 open", "#46 open"}
+	if !reflect.DeepEqual(updates, wantUpdates) {
+		t.Errorf("updates = %v, want %v", updates, wantUpdates)
+	}
+	if !strings.HasPrefix(output.String(), "Reopened #42 Epic\nReopened #43 Backend\n") {
+		t.Errorf("output = %q", output.String())
+	}
+}
+
+func TestReopenRunnerWithoutCascade(t *testing.T) {
+	issues, children := epic()
+	issues[42] = api.Issue{ID: 1, Number: 42, Title: "Epic", State: "closed"}
+
+	var updates []string
+	client := hierarchyClient(issues, children, &updates)
+	client.listSubIssuesFunc = func(opts api.ListSubIssuesOptions) ([]api.Issue, error) {
+		t.Error("sub-issues should not be listed without --cascade")
+		return nil, nil
+	}
+
+	runner := &ReopenRunner{Client: client, Owner: "owner", Repo: "repo", Out: &bytes.Buffer{}}
+	if err := runner.Run(ReopenOptions{Issue: "42"}); err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	if !reflect.DeepEqual(updates, []string{"#42 open"}) {
+		t.Errorf("updates = %v", updates)
+	}
+}
+
+func TestReopenRunnerInteractive(t *testing.T) {
+	var listed api.ListIssuesOptions
+	client := &mockCloseAPIClient{
+		listIssuesFunc: func(opts api.ListIssuesOptions) ([]api.Issue, error) {
+			listed = opts
+			return []api.Issue{{Number: 7, Title: "Old epic", State: "closed"}}, nil
+		},
+		getIssueFunc: func(owner, repo string, number int) (*api.Issue, error) {
+			return &api.Issue{Number: number, Title: "Old epic", State: "closed"}, nil
+		},
+	}
+	prompter := &mockPrompter{
+		selectFunc: func(prompt, defaultValue string, options []string) (int, error) {
+			return 0, nil
+		},
+	}
+
+	var output bytes.Buffer
+	runner := &ReopenRunner{Client: client, Owner: "owner", Repo: "repo", Out: &output, Prompter: prompter}
+	if err := runner.Run(ReopenOptions{}); err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	if listed.State != "closed" {
+		t.Errorf("listed state = %q, want closed", listed.State)
+	}
+	if output.String() != "Reopened #7 Old epic\n" {
+		t.Errorf("output = %q", output.String())
+	}
+}
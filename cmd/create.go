@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"strconv"
 	"strings"
 
 	"github.com/gwyn/gh-subissue/internal/api"
@@ -29,18 +30,95 @@ func (o *OptionalString) Set(value string) error {
 	return nil
 }
 
+// parentValue is a flag.Value for --parent that accepts an issue number,
+// owner/repo#number or an issue URL.
+type parentValue struct {
+	opts *Options
+}
+
+func (p *parentValue) String() string {
+	if p.opts == nil || p.opts.Parent == 0 {
+		return ""
+	}
+	return p.opts.parentRef().String()
+}
+
+func (p *parentValue) Set(value string) error {
+	ref, err := ParseIssueRef(value)
+	if err != nil {
+		return err
+	}
+	p.opts.Parent = ref.Number
+	p.opts.ParentRepo = ""
+	if ref.Owner != "" {
+		p.opts.ParentRepo = ref.Owner + "/" + ref.Repo
+	}
+	return nil
+}
+
+// milestoneValue is a flag.Value for --milestone that accepts a milestone
+// number or title.
+type milestoneValue struct {
+	opts *Options
+}
+
+func (m *milestoneValue) String() string {
+	if m.opts == nil {
+		return ""
+	}
+	if m.opts.MilestoneTitle != "" {
+		return m.opts.MilestoneTitle
+	}
+	if m.opts.Milestone != 0 {
+		return strconv.Itoa(m.opts.Milestone)
+	}
+	return ""
+}
+
+func (m *milestoneValue) Set(value string) error {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return errors.New("milestone cannot be empty")
+	}
+	m.opts.Milestone, m.opts.MilestoneTitle = 0, ""
+	if n, err := strconv.Atoi(value); err == nil && n > 0 {
+		m.opts.Milestone = n
+	} else {
+		m.opts.MilestoneTitle = value
+	}
+	return nil
+}
+
 // Options contains the parsed command line options.
 type Options struct {
-	Parent    int
-	Title     string
-	Body      string
-	BodyFile  string
-	Repo      string
-	Assignees []string
-	Labels    []string
-	Milestone int
-	Web       bool
-	Project   OptionalString
+	Parent              int
+	ParentRepo          string // owner/repo of the parent when it is not in --repo
+	Title               string
+	Body                string
+	BodyFile            string
+	Repo                string
+	Assignees           []string
+	Labels              []string
+	Milestone           int
+	MilestoneTitle      string // resolved to Milestone before the issue is created
+	CreateMissingLabels labelCreation
+	Type                string // issue type name; organizations only
+	Atomic              bool   // roll the issue back when linking or the project add fails
+	Web                 bool
+	Project             OptionalString
+	Fields              []FieldAssignment // project fields to set, requires Project
+	Export              ExportOptions
+	IssueTemplate       string // template name or file name from .github/ISSUE_TEMPLATE
+}
+
+// parentRef returns the parent issue as "#42" or "owner/repo#42" when it
+// lives in another repository.
+func (o Options) parentRef() IssueRef {
+	ref := IssueRef{Number: o.Parent}
+	if owner, repo, ok := strings.Cut(o.ParentRepo, "/"); ok {
+		ref.Owner, ref.Repo = owner, repo
+	}
+	return ref
 }
 
 // stringSlice is a flag.Value that collects multiple string values.
@@ -63,9 +141,11 @@ func ParseFlags(args []string) (*Options, error) {
 
 	opts := &Options{}
 	var assignees, labels stringSlice
+	var fields fieldAssignments
 
-	fs.IntVar(&opts.Parent, "parent", 0, "Parent issue number (required)")
-	fs.IntVar(&opts.Parent, "p", 0, "Parent issue number (required)")
+	parent := &parentValue{opts: opts}
+	fs.Var(parent, "parent", "Parent issue number, OWNER/REPO#NUMBER or URL (required)")
+	fs.Var(parent, "p", "Parent issue number, OWNER/REPO#NUMBER or URL (required)")
 
 	fs.StringVar(&opts.Title, "title", "", "Issue title")
 	fs.StringVar(&opts.Title, "t", "", "Issue title")
@@ -78,30 +158,56 @@ func ParseFlags(args []string) (*Options, error) {
 	fs.StringVar(&opts.Repo, "repo", "", "Repository in owner/repo format")
 	fs.StringVar(&opts.Repo, "R", "", "Repository in owner/repo format")
 
-	fs.Var(&assignees, "assignee", "Assign users (can be repeated)")
-	fs.Var(&assignees, "a", "Assign users (can be repeated)")
+	fs.Var(&assignees, "assignee", "Assign users; @me is you, @parent copies the parent's assignees (can be repeated)")
+	fs.Var(&assignees, "a", "Assign users; @me is you, @parent copies the parent's assignees (can be repeated)")
 
 	fs.Var(&labels, "label", "Add labels (can be repeated)")
 	fs.Var(&labels, "l", "Add labels (can be repeated)")
 
-	fs.IntVar(&opts.Milestone, "milestone", 0, "Milestone number")
-	fs.IntVar(&opts.Milestone, "m", 0, "Milestone number")
+	milestone := &milestoneValue{opts: opts}
+	fs.Var(milestone, "milestone", "Milestone number or title")
+	fs.Var(milestone, "m", "Milestone number or title")
+
+	fs.Var(&opts.CreateMissingLabels, "create-missing-labels", "Create labels that do not exist, optionally with a hex color")
+
+	fs.StringVar(&opts.Type, "type", "", "Issue type, such as Bug or Task (interactive if omitted)")
+
+	fs.BoolVar(&opts.Atomic, "atomic", false, "Delete or close the new issue if linking or adding to the project fails")
 
 	fs.BoolVar(&opts.Web, "web", false, "Open in browser after creation")
 	fs.BoolVar(&opts.Web, "w", false, "Open in browser after creation")
 
-	fs.Var(&opts.Project, "project", "Add to project (interactive if empty)")
-	fs.Var(&opts.Project, "P", "Add to project (interactive if empty)")
+	fs.Var(&opts.Project, "project", "Add to project by title, number or OWNER/NUMBER (interactive if empty)")
+	fs.Var(&opts.Project, "P", "Add to project by title, number or OWNER/NUMBER (interactive if empty)")
+
+	fs.Var(&fields, "field", "Set a project field as NAME=VALUE (can be repeated)")
+
+	fs.StringVar(&opts.IssueTemplate, "issue-template", "", "Start from an issue template or issue form")
+	fs.StringVar(&opts.IssueTemplate, "T", "", "Start from an issue template or issue form")
+
+	registerExportFlags(fs, &opts.Export)
 
 	if err := fs.Parse(args); err != nil {
 		debug.Error("ParseFlags", err, "stage", "fs.Parse")
 		return nil, err
 	}
 
+	if err := opts.Export.validate(api.IssueResultFields); err != nil {
+		debug.Error("ParseFlags", err, "stage", "validate_export")
+		return nil, err
+	}
+
 	opts.Assignees = assignees
 	opts.Labels = labels
+	opts.Fields = fields
 
-	debug.Log("ParseFlags", "parsed_parent", opts.Parent, "title", opts.Title, "repo", opts.Repo)
+	if len(opts.Fields) > 0 && !opts.Project.WasSet {
+		err := errors.New("--field requires --project")
+		debug.Error("ParseFlags", err, "stage", "validate_fields")
+		return nil, err
+	}
+
+	debug.Log("ParseFlags", "parsed_parent", opts.Parent, "parent_repo", opts.ParentRepo, "title", opts.Title, "repo", opts.Repo)
 	return opts, nil
 }
 
@@ -170,8 +276,22 @@ type APIClient interface {
 	GetIssue(owner, repo string, number int) (*api.Issue, error)
 	ListIssues(opts api.ListIssuesOptions) ([]api.Issue, error)
 	ListProjects(owner, repo string) ([]api.Project, error)
+	GetProject(owner string, number int) (*api.Project, error)
 	GetIssueNodeID(owner, repo string, number int) (string, error)
-	AddIssueToProject(projectID, issueNodeID string) error
+	AddIssueToProject(projectID, issueNodeID string) (string, error)
+	ListProjectFields(projectID string) ([]api.ProjectField, error)
+	SetProjectFieldValue(opts api.SetProjectFieldValueOptions) error
+	ListIssueTemplates(owner, repo string) ([]api.IssueTemplateFile, error)
+	ListLabels(owner, repo string) ([]api.Label, error)
+	CreateLabel(owner, repo, name, color string) (*api.Label, error)
+	ListMilestones(owner, repo string) ([]api.Milestone, error)
+	ListAssignees(owner, repo string) ([]api.User, error)
+	GetAuthenticatedUser() (*api.User, error)
+	ListIssueTypes(owner string) ([]api.IssueType, error)
+	DeleteIssue(issueNodeID string) error
+	UpdateIssue(opts api.UpdateIssueOptions) (*api.Issue, error)
+	CreateComment(owner, repo string, number int, body string) error
+	RemoveSubIssue(opts api.RemoveSubIssueOptions) error
 }
 
 // Runner executes the create subcommand.
@@ -180,16 +300,31 @@ type Runner struct {
 	Owner          string
 	Repo           string
 	Out            io.Writer
+	ErrOut         io.Writer // warnings and notes; nil means Out
 	Stdin          io.Reader
 	ValidateParent bool
 	OpenBrowser    func(url string) error
 	Prompter       Prompter // nil means non-interactive mode
 }
 
+// errOut returns the writer for warnings, which stay off Out so that
+// --json, --jq and --template output can be parsed.
+func (r *Runner) errOut() io.Writer {
+	if r.ErrOut != nil {
+		return r.ErrOut
+	}
+	return r.Out
+}
+
 // Run executes the create command with the given options.
 func (r *Runner) Run(opts Options) error {
 	debug.Log("Runner.Run", "owner", r.Owner, "repo", r.Repo, "parent", opts.Parent, "title", opts.Title, "has_prompter", r.Prompter != nil)
 
+	// Offer the metadata pickers only when no metadata was given on the
+	// command line
+	pickMetadata := r.Prompter != nil && len(opts.Labels) == 0 && len(opts.Assignees) == 0 &&
+		opts.Milestone == 0 && opts.MilestoneTitle == ""
+
 	// If no parent specified, prompt interactively
 	if opts.Parent == 0 {
 		debug.Log("Runner.Run", "action", "need_parent_selection")
@@ -201,10 +336,10 @@ func (r *Runner) Run(opts Options) error {
 
 		debug.Log("Runner.Run", "action", "listing_issues_for_selection")
 		issues, err := r.Client.ListIssues(api.ListIssuesOptions{
-			Owner:   r.Owner,
-			Repo:    r.Repo,
-			State:   "open",
-			PerPage: 30,
+			Owner: r.Owner,
+			Repo:  r.Repo,
+			State: "open",
+			Limit: pickerIssueLimit,
 		})
 		if err != nil {
 			debug.Error("Runner.Run", err, "stage", "list_issues")
@@ -221,6 +356,64 @@ func (r *Runner) Run(opts Options) error {
 		debug.Log("Runner.Run", "selected_parent", parent)
 	}
 
+	// Start from an issue template when one is named, or offer the picker
+	// when running interactively without a body
+	hasBody := opts.Body != "" || opts.BodyFile != ""
+	var tmpl *issueTemplate
+	if opts.IssueTemplate != "" || (r.Prompter != nil && !hasBody) {
+		var err error
+		tmpl, err = r.chooseIssueTemplate(opts.IssueTemplate)
+		if err != nil {
+			return err
+		}
+	}
+	if tmpl != nil {
+		debug.Log("Runner.Run", "issue_template", tmpl.Name, "is_form", tmpl.IsForm())
+		opts.Labels = appendMissing(opts.Labels, tmpl.Labels...)
+		opts.Assignees = appendMissing(opts.Assignees, tmpl.Assignees...)
+		if opts.Title == "" && r.Prompter == nil {
+			opts.Title = strings.TrimSpace(tmpl.Title)
+		}
+	}
+
+	// Check labels and the milestone before prompting, so a typo does not
+	// throw away a title and body that were already written
+	if len(opts.Labels) > 0 {
+		out := r.Out
+		if opts.Export.Enabled() {
+			out = r.errOut()
+		}
+		if err := checkLabels(r.Client, out, r.Owner, r.Repo, opts.Labels, opts.CreateMissingLabels); err != nil {
+			return err
+		}
+	}
+	if opts.MilestoneTitle != "" {
+		number, err := resolveMilestone(r.Client, r.Owner, r.Repo, opts.MilestoneTitle)
+		if err != nil {
+			return err
+		}
+		opts.Milestone = number
+	}
+
+	if opts.Type != "" {
+		name, err := resolveIssueType(r.Client, r.Owner, opts.Type)
+		if err != nil {
+			return err
+		}
+		opts.Type = name
+	}
+	if len(opts.Assignees) > 0 {
+		parent := opts.parentRef()
+		if parent.Owner == "" {
+			parent.Owner, parent.Repo = r.Owner, r.Repo
+		}
+		assignees, err := resolveAssignees(r.Client, parent, opts.Assignees)
+		if err != nil {
+			return err
+		}
+		opts.Assignees = assignees
+	}
+
 	// If no title specified, prompt interactively
 	if opts.Title == "" {
 		debug.Log("Runner.Run", "action", "need_title_input")
@@ -231,7 +424,11 @@ func (r *Runner) Run(opts Options) error {
 		}
 
 		debug.Log("Runner.Run", "action", "prompting_for_title")
-		title, err := r.Prompter.Input("Title", "")
+		defaultTitle := ""
+		if tmpl != nil {
+			defaultTitle = tmpl.Title
+		}
+		title, err := r.Prompter.Input("Title", defaultTitle)
 		if err != nil {
 			debug.Error("Runner.Run", err, "stage", "input_title")
 			return fmt.Errorf("failed to get title: %w", err)
@@ -260,12 +457,85 @@ func (r *Runner) Run(opts Options) error {
 		}
 	}
 
-	// Validate parent exists if requested
-	if r.ValidateParent {
-		debug.Log("Runner.Run", "action", "validating_parent", "parent", opts.Parent)
-		_, err := r.Client.GetIssue(r.Owner, r.Repo, opts.Parent)
+	// Fill the body from the template unless one was given
+	if tmpl != nil && !hasBody {
+		if tmpl.IsForm() {
+			if r.Prompter == nil {
+				err := fmt.Errorf("--body is required when using the issue form %q while not running interactively", tmpl.Name)
+				debug.Error("Runner.Run", err, "reason", "no_prompter")
+				return err
+			}
+			var err error
+			body, err = promptIssueForm(r.Prompter, tmpl)
+			if err != nil {
+				debug.Error("Runner.Run", err, "stage", "issue_form")
+				return err
+			}
+		} else {
+			body = tmpl.Body
+		}
+	}
+
+	// Write the body in the editor when running interactively without one.
+	// Issue forms already prompted for each field.
+	if r.Prompter != nil && !hasBody && (tmpl == nil || !tmpl.IsForm()) {
+		debug.Log("Runner.Run", "action", "opening_editor")
+		edited, err := r.Prompter.Editor("Body", body)
+		if err != nil {
+			debug.Error("Runner.Run", err, "stage", "editor")
+			return fmt.Errorf("failed to get body: %w", err)
+		}
+
+		body = strings.TrimSpace(stripComments(edited))
+		if body == "" {
+			err := errors.New("issue creation cancelled: the body is empty")
+			debug.Error("Runner.Run", err, "reason", "empty_body")
+			return err
+		}
+		debug.Log("Runner.Run", "body_length", len(body))
+	}
+
+	if pickMetadata {
+		debug.Log("Runner.Run", "action", "prompting_for_metadata")
+		metadata, err := promptMetadata(r.Client, r.Prompter, r.Owner, r.Repo, issueMetadata{
+			Labels:    opts.Labels,
+			Assignees: opts.Assignees,
+		})
+		if err != nil {
+			return err
+		}
+		opts.Labels, opts.Assignees, opts.Milestone = metadata.Labels, metadata.Assignees, metadata.Milestone
+	}
+
+	if r.Prompter != nil && opts.Type == "" {
+		debug.Log("Runner.Run", "action", "prompting_for_issue_type")
+		name, err := selectIssueType(r.Client, r.Prompter, r.Owner, "")
+		if err != nil {
+			return err
+		}
+		opts.Type = name
+	}
+
+	// The parent may live in another repository than the new issue
+	parentOwner, parentRepo := r.Owner, r.Repo
+	if owner, repo, ok := strings.Cut(opts.ParentRepo, "/"); ok && (owner != r.Owner || repo != r.Repo) {
+		parentOwner, parentRepo = owner, repo
+	} else {
+		opts.ParentRepo = ""
+	}
+	parentLabel := opts.parentRef().String()
+
+	// Validate parent exists if requested. A parent in another repository is
+	// always checked so a typo does not leave an unlinked issue behind.
+	if r.ValidateParent || opts.ParentRepo != "" {
+		debug.Log("Runner.Run", "action", "validating_parent", "parent", parentLabel)
+		_, err := r.Client.GetIssue(parentOwner, parentRepo, opts.Parent)
 		if err != nil {
 			debug.Error("Runner.Run", err, "stage", "validate_parent")
+			if opts.ParentRepo != "" {
+				return fmt.Errorf("parent issue #%d not found in %s/%s (the new issue would be created in %s/%s): %w",
+					opts.Parent, parentOwner, parentRepo, r.Owner, r.Repo, err)
+			}
 			return fmt.Errorf("parent issue #%d not found: %w", opts.Parent, err)
 		}
 		debug.Log("Runner.Run", "parent_validation", "success")
@@ -281,6 +551,7 @@ func (r *Runner) Run(opts Options) error {
 		Labels:    opts.Labels,
 		Assignees: opts.Assignees,
 		Milestone: opts.Milestone,
+		Type:      opts.Type,
 	})
 	if err != nil {
 		debug.Error("Runner.Run", err, "stage", "create_issue")
@@ -288,55 +559,193 @@ func (r *Runner) Run(opts Options) error {
 	}
 	debug.Log("Runner.Run", "issue_created", result.Number, "issue_id", result.ID, "url", result.URL)
 
-	// Link as sub-issue
-	debug.Log("Runner.Run", "action", "linking_sub_issue", "parent", opts.Parent, "sub_issue_id", result.ID)
+	// Link as sub-issue; the link is made on the parent's repository
+	debug.Log("Runner.Run", "action", "linking_sub_issue", "parent", parentLabel, "sub_issue_id", result.ID)
 	linkErr := r.Client.LinkSubIssue(api.LinkSubIssueOptions{
-		Owner:       r.Owner,
-		Repo:        r.Repo,
+		Owner:       parentOwner,
+		Repo:        parentRepo,
 		ParentIssue: opts.Parent,
 		SubIssueID:  result.ID,
 	})
 
 	if linkErr != nil {
-		// Issue was created but linking failed - warn the user
 		debug.Error("Runner.Run", linkErr, "stage", "link_sub_issue", "issue_url", result.URL)
-		fmt.Fprintf(r.Out, "Warning: Issue created but failed to link as sub-issue: %v\n", linkErr)
-		fmt.Fprintf(r.Out, "Issue URL: %s\n", result.URL)
-		fmt.Fprintf(r.Out, "To manually link, run:\n")
-		fmt.Fprintf(r.Out, "  gh api repos/%s/%s/issues/%d/sub_issues -f sub_issue_id=%d\n",
-			r.Owner, r.Repo, opts.Parent, result.ID)
+		if opts.Atomic {
+			cause := fmt.Errorf("failed to link issue #%d as a sub-issue of %s: %w", result.Number, parentLabel, linkErr)
+			return r.rollback(result, nil, cause)
+		}
+
+		// Issue was created but linking failed - warn the user
+		errOut := r.errOut()
+		if opts.ParentRepo != "" {
+			fmt.Fprintf(errOut, "Warning: Issue created in %s/%s but failed to link as sub-issue of %s: %v\n",
+				r.Owner, r.Repo, parentLabel, linkErr)
+		} else {
+			fmt.Fprintf(errOut, "Warning: Issue created but failed to link as sub-issue: %v\n", linkErr)
+		}
+		fmt.Fprintf(errOut, "Issue URL: %s\n", result.URL)
+		fmt.Fprintf(errOut, "To manually link, run:\n")
+		fmt.Fprintf(errOut, "  gh api repos/%s/%s/issues/%d/sub_issues -F sub_issue_id=%d\n",
+			parentOwner, parentRepo, opts.Parent, result.ID)
+
+		// Scripts reading the exported issue would otherwise take it for a
+		// linked sub-issue
+		if opts.Export.Enabled() {
+			if err := opts.Export.Write(r.Out, result.ExportData(opts.Export.Fields)); err != nil {
+				return err
+			}
+			return fmt.Errorf("issue #%d was created but not linked as a sub-issue of %s", result.Number, parentLabel)
+		}
 		return nil
 	}
 
 	// Add to project if requested
 	if opts.Project.WasSet {
 		debug.Log("Runner.Run", "action", "adding_to_project", "project_value", opts.Project.Value)
-		r.addToProject(opts, result)
+		if err := r.addToProject(opts, result); err != nil {
+			if opts.Atomic {
+				link := &api.RemoveSubIssueOptions{Owner: parentOwner, Repo: parentRepo, ParentIssue: opts.Parent, SubIssueID: result.ID}
+				return r.rollback(result, link, fmt.Errorf("failed to add issue #%d to the project: %w", result.Number, err))
+			}
+			fmt.Fprintf(r.errOut(), "Warning: %v\n", err)
+		}
 	}
 
 	debug.Log("Runner.Run", "result", "success", "url", result.URL)
-	fmt.Fprintln(r.Out, result.URL)
+	if opts.Export.Enabled() {
+		if err := opts.Export.Write(r.Out, result.ExportData(opts.Export.Fields)); err != nil {
+			return err
+		}
+	} else {
+		fmt.Fprintln(r.Out, result.URL)
+	}
 
 	// Open in browser if requested
 	if opts.Web && r.OpenBrowser != nil {
 		debug.Log("Runner.Run", "action", "opening_browser", "url", result.URL)
 		if err := r.OpenBrowser(result.URL); err != nil {
 			debug.Error("Runner.Run", err, "stage", "open_browser")
-			fmt.Fprintf(r.Out, "Warning: failed to open browser: %v\n", err)
+			fmt.Fprintf(r.errOut(), "Warning: failed to open browser: %v\n", err)
 		}
 	}
 
 	return nil
 }
 
-// addToProject handles adding the created issue to a project.
-func (r *Runner) addToProject(opts Options, result *api.IssueResult) {
+// rollback undoes a created issue after a later step failed with cause. The
+// issue is deleted when the token allows it; otherwise it is unlinked from
+// its parent when link is set, closed as not planned and commented on. The
+// returned error describes both cause and the outcome of the rollback.
+func (r *Runner) rollback(result *api.IssueResult, link *api.RemoveSubIssueOptions, cause error) error {
+	debug.Log("Runner.rollback", "issue", result.Number, "cause", cause.Error())
+
+	nodeID, err := r.Client.GetIssueNodeID(r.Owner, r.Repo, result.Number)
+	if err == nil {
+		err = r.Client.DeleteIssue(nodeID)
+	}
+	if err == nil {
+		debug.Log("Runner.rollback", "result", "deleted")
+		return fmt.Errorf("%w\nRolled back: deleted issue #%d", cause, result.Number)
+	}
+	deleteErr := err
+	debug.Error("Runner.rollback", deleteErr, "stage", "delete_issue")
+
+	if link != nil {
+		if err := r.Client.RemoveSubIssue(*link); err != nil {
+			debug.Error("Runner.rollback", err, "stage", "remove_sub_issue")
+		}
+	}
+
+	state, reason := "closed", "not_planned"
+	if _, err := r.Client.UpdateIssue(api.UpdateIssueOptions{
+		Owner:       r.Owner,
+		Repo:        r.Repo,
+		Number:      result.Number,
+		State:       &state,
+		StateReason: &reason,
+	}); err != nil {
+		debug.Error("Runner.rollback", err, "stage", "close_issue")
+		return fmt.Errorf("%w\nRollback failed: could not delete issue #%d (%v) or close it (%v); remove it by hand: %s",
+			cause, result.Number, deleteErr, err, result.URL)
+	}
+
+	comment := fmt.Sprintf("Closed automatically by `gh subissue create --atomic` because creating it did not complete:\n\n> %s\n",
+		strings.ReplaceAll(cause.Error(), "\n", "\n> "))
+	if err := r.Client.CreateComment(r.Owner, r.Repo, result.Number, comment); err != nil {
+		debug.Error("Runner.rollback", err, "stage", "create_comment")
+		return fmt.Errorf("%w\nRolled back: could not delete issue #%d (%v), so it was closed as not planned without a comment (%v): %s",
+			cause, result.Number, deleteErr, err, result.URL)
+	}
+
+	debug.Log("Runner.rollback", "result", "closed")
+	return fmt.Errorf("%w\nRolled back: could not delete issue #%d (%v), so it was closed as not planned: %s",
+		cause, result.Number, deleteErr, result.URL)
+}
+
+// chooseIssueTemplate loads the repository's issue templates and returns the
+// named one, or lets the user pick one when name is empty. A nil template
+// means a blank issue.
+func (r *Runner) chooseIssueTemplate(name string) (*issueTemplate, error) {
+	debug.Log("chooseIssueTemplate", "name", name)
+
+	files, err := r.Client.ListIssueTemplates(r.Owner, r.Repo)
+	if err != nil {
+		debug.Error("chooseIssueTemplate", err, "stage", "list_templates")
+		if name == "" {
+			fmt.Fprintf(r.errOut(), "Warning: failed to load issue templates: %v\n", err)
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to load issue templates: %w", err)
+	}
+
+	var templates []*issueTemplate
+	for _, file := range files {
+		tmpl, err := parseIssueTemplate(file)
+		if err != nil {
+			if name != "" {
+				return nil, err
+			}
+			fmt.Fprintf(r.errOut(), "Warning: skipping issue template: %v\n", err)
+			continue
+		}
+		templates = append(templates, tmpl)
+	}
+
+	if name != "" {
+		return findIssueTemplate(templates, name)
+	}
+	if len(templates) == 0 {
+		debug.Log("chooseIssueTemplate", "result", "no_templates")
+		return nil, nil
+	}
+	return SelectIssueTemplate(r.Prompter, templates)
+}
+
+// appendMissing appends the values not already in list, ignoring case.
+func appendMissing(list []string, values ...string) []string {
+	for _, v := range values {
+		found := false
+		for _, existing := range list {
+			if strings.EqualFold(existing, v) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			list = append(list, v)
+		}
+	}
+	return list
+}
+
+// addToProject handles adding the created issue to a project. The returned
+// error is shown as a warning, or rolls the issue back with --atomic.
+func (r *Runner) addToProject(opts Options, result *api.IssueResult) error {
 	// List projects
 	projects, err := r.Client.ListProjects(r.Owner, r.Repo)
 	if err != nil {
 		debug.Error("addToProject", err, "stage", "list_projects")
-		fmt.Fprintf(r.Out, "Warning: failed to list projects: %v\n", err)
-		return
+		return fmt.Errorf("failed to list projects: %w", err)
 	}
 
 	var selectedProject *api.Project
@@ -346,52 +755,38 @@ func (r *Runner) addToProject(opts Options, result *api.IssueResult) {
 		if r.Prompter == nil {
 			debug.Log("addToProject", "action", "skip_interactive", "reason", "no_prompter")
 			if len(projects) == 0 {
-				fmt.Fprintf(r.Out, "Warning: no projects found for this repository\n")
-				fmt.Fprintf(r.Out, "Create a project at: https://github.com/%s/%s/projects\n", r.Owner, r.Repo)
-			} else {
-				fmt.Fprintf(r.Out, "Warning: --project requires a project name when not running interactively\n")
-				fmt.Fprintf(r.Out, "Available projects:")
-				for _, p := range projects {
-					fmt.Fprintf(r.Out, " %q", p.Title)
-				}
-				fmt.Fprintf(r.Out, "\nTo add to project later: gh subissue edit %d --project %q\n", result.Number, projects[0].Title)
+				return fmt.Errorf("no projects found for this repository\nCreate a project at: https://github.com/%s/%s/projects", r.Owner, r.Repo)
 			}
-			return
+			return fmt.Errorf("--project requires a project name when not running interactively\nAvailable projects:%s\nTo add to project later: gh subissue edit %d --project %q",
+				projectTitles(projects), result.Number, projects[0].Title)
 		}
 
 		if len(projects) == 0 {
 			debug.Log("addToProject", "action", "no_projects_found")
-			fmt.Fprintf(r.Out, "Warning: no projects found for this repository\n")
-			fmt.Fprintf(r.Out, "Create a project at: https://github.com/%s/%s/projects\n", r.Owner, r.Repo)
-			return
+			return fmt.Errorf("no projects found for this repository\nCreate a project at: https://github.com/%s/%s/projects", r.Owner, r.Repo)
 		}
 
 		project, err := SelectProject(r.Prompter, projects)
 		if err != nil {
 			debug.Error("addToProject", err, "stage", "select_project")
-			fmt.Fprintf(r.Out, "Warning: failed to select project: %v\n", err)
-			return
+			return fmt.Errorf("failed to select project: %w", err)
 		}
 		selectedProject = project
 	} else {
-		// Find project by name
-		for i := range projects {
-			if projects[i].Title == opts.Project.Value {
-				selectedProject = &projects[i]
-				break
-			}
+		// Find project by title, number or OWNER/NUMBER
+		project, err := findProject(r.Client, projects, opts.Project.Value, r.Owner)
+		if err != nil {
+			debug.Error("addToProject", err, "stage", "find_project")
+			return err
 		}
+		selectedProject = project
 		if selectedProject == nil {
 			debug.Log("addToProject", "action", "project_not_found", "project_name", opts.Project.Value)
-			fmt.Fprintf(r.Out, "Warning: project %q not found\n", opts.Project.Value)
+			err := fmt.Errorf("project %q not found", opts.Project.Value)
 			if len(projects) > 0 {
-				fmt.Fprintf(r.Out, "Available projects:")
-				for _, p := range projects {
-					fmt.Fprintf(r.Out, " %q", p.Title)
-				}
-				fmt.Fprintf(r.Out, "\n")
+				err = fmt.Errorf("%w\nAvailable projects:%s", err, projectTitles(projects))
 			}
-			return
+			return err
 		}
 	}
 
@@ -399,17 +794,33 @@ func (r *Runner) addToProject(opts Options, result *api.IssueResult) {
 	nodeID, err := r.Client.GetIssueNodeID(r.Owner, r.Repo, result.Number)
 	if err != nil {
 		debug.Error("addToProject", err, "stage", "get_issue_node_id")
-		fmt.Fprintf(r.Out, "Warning: failed to get issue node ID: %v\n", err)
-		return
+		return fmt.Errorf("failed to get issue node ID: %w", err)
 	}
 
 	// Add issue to project
-	err = r.Client.AddIssueToProject(selectedProject.ID, nodeID)
+	itemID, err := r.Client.AddIssueToProject(selectedProject.ID, nodeID)
 	if err != nil {
 		debug.Error("addToProject", err, "stage", "add_issue_to_project")
-		fmt.Fprintf(r.Out, "Warning: failed to add issue to project: %v\n", err)
-		return
+		return fmt.Errorf("failed to add issue to project: %w", err)
+	}
+
+	// Set project fields such as Status or Iteration on the new item
+	if len(opts.Fields) > 0 {
+		if _, err := setProjectFields(r.Client, r.Prompter, selectedProject, itemID, opts.Fields); err != nil {
+			debug.Error("addToProject", err, "stage", "set_project_fields")
+			return err
+		}
 	}
 
 	debug.Log("addToProject", "result", "success", "project", selectedProject.Title)
+	return nil
+}
+
+// projectTitles formats project titles as ` "Roadmap" "Sprint 3"`.
+func projectTitles(projects []api.Project) string {
+	var b strings.Builder
+	for _, p := range projects {
+		fmt.Fprintf(&b, " %q", p.Title)
+	}
+	return b.String()
 }
@@ -3,7 +3,9 @@ package cmd
 import (
 	"bytes"
 	"errors"
+	"fmt"
 	"io"
+	"reflect"
 	"strings"
 	"testing"
 
@@ -12,13 +14,27 @@ import (
 
 // mockAPIClient implements the API interface for testing.
 type mockAPIClient struct {
-	createIssueFunc      func(opts api.CreateIssueOptions) (*api.IssueResult, error)
-	linkSubIssueFunc     func(opts api.LinkSubIssueOptions) error
-	getIssueFunc         func(owner, repo string, number int) (*api.Issue, error)
-	listIssuesFunc       func(opts api.ListIssuesOptions) ([]api.Issue, error)
-	listProjectsFunc     func(owner, repo string) ([]api.Project, error)
-	getIssueNodeIDFunc   func(owner, repo string, number int) (string, error)
-	addIssueToProjectFunc func(projectID, issueNodeID string) error
+	createIssueFunc          func(opts api.CreateIssueOptions) (*api.IssueResult, error)
+	linkSubIssueFunc         func(opts api.LinkSubIssueOptions) error
+	getIssueFunc             func(owner, repo string, number int) (*api.Issue, error)
+	listIssuesFunc           func(opts api.ListIssuesOptions) ([]api.Issue, error)
+	listProjectsFunc         func(owner, repo string) ([]api.Project, error)
+	getProjectFunc           func(owner string, number int) (*api.Project, error)
+	getIssueNodeIDFunc       func(owner, repo string, number int) (string, error)
+	addIssueToProjectFunc    func(projectID, issueNodeID string) (string, error)
+	listProjectFieldsFunc    func(projectID string) ([]api.ProjectField, error)
+	setProjectFieldValueFunc func(opts api.SetProjectFieldValueOptions) error
+	listIssueTemplatesFunc   func(owner, repo string) ([]api.IssueTemplateFile, error)
+	listLabelsFunc           func(owner, repo string) ([]api.Label, error)
+	createLabelFunc          func(owner, repo, name, color string) (*api.Label, error)
+	listMilestonesFunc       func(owner, repo string) ([]api.Milestone, error)
+	listAssigneesFunc        func(owner, repo string) ([]api.User, error)
+	getAuthenticatedUserFunc func() (*api.User, error)
+	listIssueTypesFunc       func(owner string) ([]api.IssueType, error)
+	deleteIssueFunc          func(issueNodeID string) error
+	updateIssueFunc          func(opts api.UpdateIssueOptions) (*api.Issue, error)
+	createCommentFunc        func(owner, repo string, number int, body string) error
+	removeSubIssueFunc       func(opts api.RemoveSubIssueOptions) error
 }
 
 func (m *mockAPIClient) CreateIssue(opts api.CreateIssueOptions) (*api.IssueResult, error) {
@@ -56,6 +72,13 @@ func (m *mockAPIClient) ListProjects(owner, repo string) ([]api.Project, error)
 	return []api.Project{}, nil
 }
 
+func (m *mockAPIClient) GetProject(owner string, number int) (*api.Project, error) {
+	if m.getProjectFunc != nil {
+		return m.getProjectFunc(owner, number)
+	}
+	return nil, errors.New("not found")
+}
+
 func (m *mockAPIClient) GetIssueNodeID(owner, repo string, number int) (string, error) {
 	if m.getIssueNodeIDFunc != nil {
 		return m.getIssueNodeIDFunc(owner, repo, number)
@@ -63,10 +86,104 @@ func (m *mockAPIClient) GetIssueNodeID(owner, repo string, number int) (string,
 	return "I_mock_node_id", nil
 }
 
-func (m *mockAPIClient) AddIssueToProject(projectID, issueNodeID string) error {
+func (m *mockAPIClient) AddIssueToProject(projectID, issueNodeID string) (string, error) {
 	if m.addIssueToProjectFunc != nil {
 		return m.addIssueToProjectFunc(projectID, issueNodeID)
 	}
+	return "PVTI_mock", nil
+}
+
+func (m *mockAPIClient) ListProjectFields(projectID string) ([]api.ProjectField, error) {
+	if m.listProjectFieldsFunc != nil {
+		return m.listProjectFieldsFunc(projectID)
+	}
+	return nil, nil
+}
+
+func (m *mockAPIClient) SetProjectFieldValue(opts api.SetProjectFieldValueOptions) error {
+	if m.setProjectFieldValueFunc != nil {
+		return m.setProjectFieldValueFunc(opts)
+	}
+	return nil
+}
+
+func (m *mockAPIClient) ListIssueTemplates(owner, repo string) ([]api.IssueTemplateFile, error) {
+	if m.listIssueTemplatesFunc != nil {
+		return m.listIssueTemplatesFunc(owner, repo)
+	}
+	return nil, nil
+}
+
+// repoLabels are the labels the mock repository has by default.
+var repoLabels = []api.Label{{Name: "bug"}, {Name: "enhancement"}, {Name: "triage"}, {Name: "ui"}}
+
+func (m *mockAPIClient) ListLabels(owner, repo string) ([]api.Label, error) {
+	if m.listLabelsFunc != nil {
+		return m.listLabelsFunc(owner, repo)
+	}
+	return repoLabels, nil
+}
+
+func (m *mockAPIClient) CreateLabel(owner, repo, name, color string) (*api.Label, error) {
+	if m.createLabelFunc != nil {
+		return m.createLabelFunc(owner, repo, name, color)
+	}
+	return &api.Label{Name: name, Color: color}, nil
+}
+
+func (m *mockAPIClient) ListMilestones(owner, repo string) ([]api.Milestone, error) {
+	if m.listMilestonesFunc != nil {
+		return m.listMilestonesFunc(owner, repo)
+	}
+	return nil, nil
+}
+
+func (m *mockAPIClient) ListAssignees(owner, repo string) ([]api.User, error) {
+	if m.listAssigneesFunc != nil {
+		return m.listAssigneesFunc(owner, repo)
+	}
+	return nil, nil
+}
+
+func (m *mockAPIClient) GetAuthenticatedUser() (*api.User, error) {
+	if m.getAuthenticatedUserFunc != nil {
+		return m.getAuthenticatedUserFunc()
+	}
+	return &api.User{Login: "testuser"}, nil
+}
+
+func (m *mockAPIClient) ListIssueTypes(owner string) ([]api.IssueType, error) {
+	if m.listIssueTypesFunc != nil {
+		return m.listIssueTypesFunc(owner)
+	}
+	return nil, nil
+}
+
+func (m *mockAPIClient) DeleteIssue(issueNodeID string) error {
+	if m.deleteIssueFunc != nil {
+		return m.deleteIssueFunc(issueNodeID)
+	}
+	return nil
+}
+
+func (m *mockAPIClient) UpdateIssue(opts api.UpdateIssueOptions) (*api.Issue, error) {
+	if m.updateIssueFunc != nil {
+		return m.updateIssueFunc(opts)
+	}
+	return &api.Issue{Number: opts.Number}, nil
+}
+
+func (m *mockAPIClient) CreateComment(owner, repo string, number int, body string) error {
+	if m.createCommentFunc != nil {
+		return m.createCommentFunc(owner, repo, number, body)
+	}
+	return nil
+}
+
+func (m *mockAPIClient) RemoveSubIssue(opts api.RemoveSubIssueOptions) error {
+	if m.removeSubIssueFunc != nil {
+		return m.removeSubIssueFunc(opts)
+	}
 	return nil
 }
 
@@ -537,8 +654,11 @@ var _ io.Reader = (*strings.Reader)(nil)
 
 // mockPrompterInCreate implements Prompter for testing.
 type mockPrompterInCreate struct {
-	selectFunc func(prompt string, defaultValue string, options []string) (int, error)
-	inputFunc  func(prompt, defaultValue string) (string, error)
+	selectFunc      func(prompt string, defaultValue string, options []string) (int, error)
+	multiSelectFunc func(prompt string, defaultValues, options []string) ([]int, error)
+	inputFunc       func(prompt, defaultValue string) (string, error)
+	confirmFunc     func(prompt string, defaultValue bool) (bool, error)
+	editorFunc      func(prompt, defaultValue string) (string, error)
 }
 
 func (m *mockPrompterInCreate) Select(prompt, defaultValue string, options []string) (int, error) {
@@ -548,6 +668,13 @@ func (m *mockPrompterInCreate) Select(prompt, defaultValue string, options []str
 	return 0, nil
 }
 
+func (m *mockPrompterInCreate) MultiSelect(prompt string, defaultValues, options []string) ([]int, error) {
+	if m.multiSelectFunc != nil {
+		return m.multiSelectFunc(prompt, defaultValues, options)
+	}
+	return nil, nil
+}
+
 func (m *mockPrompterInCreate) Input(prompt, defaultValue string) (string, error) {
 	if m.inputFunc != nil {
 		return m.inputFunc(prompt, defaultValue)
@@ -555,6 +682,23 @@ func (m *mockPrompterInCreate) Input(prompt, defaultValue string) (string, error
 	return "", nil
 }
 
+func (m *mockPrompterInCreate) Confirm(prompt string, defaultValue bool) (bool, error) {
+	if m.confirmFunc != nil {
+		return m.confirmFunc(prompt, defaultValue)
+	}
+	return defaultValue, nil
+}
+
+func (m *mockPrompterInCreate) Editor(prompt, defaultValue string) (string, error) {
+	if m.editorFunc != nil {
+		return m.editorFunc(prompt, defaultValue)
+	}
+	if defaultValue != "" {
+		return defaultValue, nil
+	}
+	return "Body from editor", nil
+}
+
 var _ Prompter = (*mockPrompterInCreate)(nil)
 
 func TestRunInteractiveSelection(t *testing.T) {
@@ -805,14 +949,14 @@ func TestRunWithProjectByName(t *testing.T) {
 			}
 			return "I_abc123", nil
 		},
-		addIssueToProjectFunc: func(projectID, issueNodeID string) error {
+		addIssueToProjectFunc: func(projectID, issueNodeID string) (string, error) {
 			if projectID != "PVT_2" {
 				t.Errorf("expected project ID PVT_2, got %s", projectID)
 			}
 			if issueNodeID != "I_abc123" {
 				t.Errorf("expected issue node ID I_abc123, got %s", issueNodeID)
 			}
-			return nil
+			return "PVTI_1", nil
 		},
 	}
 
@@ -860,11 +1004,11 @@ func TestRunWithProjectInteractive(t *testing.T) {
 		getIssueNodeIDFunc: func(owner, repo string, number int) (string, error) {
 			return "I_abc123", nil
 		},
-		addIssueToProjectFunc: func(projectID, issueNodeID string) error {
+		addIssueToProjectFunc: func(projectID, issueNodeID string) (string, error) {
 			if projectID != "PVT_1" {
 				t.Errorf("expected project ID PVT_1 (first selected), got %s", projectID)
 			}
-			return nil
+			return "PVTI_1", nil
 		},
 	}
 
@@ -958,9 +1102,9 @@ func TestRunNoProjectFlag(t *testing.T) {
 		linkSubIssueFunc: func(opts api.LinkSubIssueOptions) error {
 			return nil
 		},
-		addIssueToProjectFunc: func(projectID, issueNodeID string) error {
+		addIssueToProjectFunc: func(projectID, issueNodeID string) (string, error) {
 			addProjectCalled = true
-			return nil
+			return "PVTI_1", nil
 		},
 	}
 
@@ -986,3 +1130,929 @@ func TestRunNoProjectFlag(t *testing.T) {
 		t.Error("AddIssueToProject should not be called when --project flag not provided")
 	}
 }
+
+func TestRunWithJSONOutput(t *testing.T) {
+	client := &mockAPIClient{
+		createIssueFunc: func(opts api.CreateIssueOptions) (*api.IssueResult, error) {
+			return &api.IssueResult{ID: 12345, Number: 43, URL: "https://github.com/owner/repo/issues/43"}, nil
+		},
+	}
+
+	var output bytes.Buffer
+	runner := &Runner{
+		Client: client,
+		Owner:  "owner",
+		Repo:   "repo",
+		Out:    &output,
+	}
+
+	err := runner.Run(Options{
+		Parent: 42,
+		Title:  "Sub Issue",
+		Export: ExportOptions{Fields: []string{"number", "url"}, Template: "{{.number}} {{.url}}"},
+	})
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+
+	want := "43 https://github.com/owner/repo/issues/43"
+	if output.String() != want {
+		t.Errorf("output = %q, want %q", output.String(), want)
+	}
+}
+
+func TestRunExportKeepsWarningsOffOut(t *testing.T) {
+	newClient := func(linkErr error) *mockAPIClient {
+		return &mockAPIClient{
+			createIssueFunc: func(opts api.CreateIssueOptions) (*api.IssueResult, error) {
+				return &api.IssueResult{ID: 12345, Number: 43, URL: "https://github.com/owner/repo/issues/43"}, nil
+			},
+			linkSubIssueFunc: func(opts api.LinkSubIssueOptions) error {
+				return linkErr
+			},
+			listProjectsFunc: func(owner, repo string) ([]api.Project, error) {
+				return nil, nil
+			},
+		}
+	}
+	export := ExportOptions{Fields: []string{"number", "url"}, Template: "{{.number}} {{.url}}"}
+	want := "43 https://github.com/owner/repo/issues/43"
+
+	t.Run("project warning", func(t *testing.T) {
+		var output, errOutput bytes.Buffer
+		runner := &Runner{Client: newClient(nil), Owner: "owner", Repo: "repo", Out: &output, ErrOut: &errOutput}
+		err := runner.Run(Options{
+			Parent:  42,
+			Title:   "Sub Issue",
+			Project: OptionalString{Value: "Roadmap", WasSet: true},
+			Export:  export,
+		})
+		if err != nil {
+			t.Fatalf("Run() error = %v", err)
+		}
+		if output.String() != want {
+			t.Errorf("output = %q, want %q", output.String(), want)
+		}
+		if !strings.Contains(errOutput.String(), "Warning:") {
+			t.Errorf("stderr = %q, want a warning", errOutput.String())
+		}
+	})
+
+	t.Run("link failure", func(t *testing.T) {
+		var output, errOutput bytes.Buffer
+		runner := &Runner{Client: newClient(errors.New("permission denied")), Owner: "owner", Repo: "repo", Out: &output, ErrOut: &errOutput}
+		err := runner.Run(Options{Parent: 42, Title: "Sub Issue", Export: export})
+		if err == nil || err.Error() != "issue #43 was created but not linked as a sub-issue of #42" {
+			t.Errorf("Run() error = %v", err)
+		}
+		if output.String() != want {
+			t.Errorf("output = %q, want %q", output.String(), want)
+		}
+		if !strings.Contains(errOutput.String(), "Warning: Issue created but failed to link as sub-issue: permission denied") {
+			t.Errorf("stderr = %q", errOutput.String())
+		}
+	})
+}
+
+func TestParseFlagsJSONUnknownField(t *testing.T) {
+	_, err := ParseFlags([]string{"-p", "42", "--json", "title"})
+	if err == nil {
+		t.Fatal("expected error for field not available on created issues")
+	}
+	if !strings.Contains(err.Error(), "Available fields:\n  id\n  number\n  url") {
+		t.Errorf("error should list valid fields, got %q", err.Error())
+	}
+}
+
+func TestParseFlagsCrossRepoParent(t *testing.T) {
+	tests := []struct {
+		name           string
+		args           []string
+		wantParent     int
+		wantParentRepo string
+		wantErr        bool
+	}{
+		{
+			name:       "plain number",
+			args:       []string{"-p", "42"},
+			wantParent: 42,
+		},
+		{
+			name:           "qualified reference",
+			args:           []string{"--parent", "org/planning#42"},
+			wantParent:     42,
+			wantParentRepo: "org/planning",
+		},
+		{
+			name:           "issue URL",
+			args:           []string{"-p", "https://github.com/org/planning/issues/7"},
+			wantParent:     7,
+			wantParentRepo: "org/planning",
+		},
+		{
+			name:    "invalid reference",
+			args:    []string{"-p", "planning#42"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts, err := ParseFlags(tt.args)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseFlags() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if opts.Parent != tt.wantParent {
+				t.Errorf("Parent = %d, want %d", opts.Parent, tt.wantParent)
+			}
+			if opts.ParentRepo != tt.wantParentRepo {
+				t.Errorf("ParentRepo = %q, want %q", opts.ParentRepo, tt.wantParentRepo)
+			}
+		})
+	}
+}
+
+func TestRunCrossRepoParent(t *testing.T) {
+	var gotIssueRepo, gotLinkRepo, gotCreateRepo string
+	client := &mockAPIClient{
+		getIssueFunc: func(owner, repo string, number int) (*api.Issue, error) {
+			gotIssueRepo = owner + "/" + repo
+			return &api.Issue{ID: 99999, Number: number, Title: "Epic"}, nil
+		},
+		createIssueFunc: func(opts api.CreateIssueOptions) (*api.IssueResult, error) {
+			gotCreateRepo = opts.Owner + "/" + opts.Repo
+			return &api.IssueResult{ID: 12345, Number: 7, URL: "https://github.com/owner/service/issues/7"}, nil
+		},
+		linkSubIssueFunc: func(opts api.LinkSubIssueOptions) error {
+			gotLinkRepo = opts.Owner + "/" + opts.Repo
+			if opts.ParentIssue != 42 || opts.SubIssueID != 12345 {
+				t.Errorf("unexpected link options: %+v", opts)
+			}
+			return nil
+		},
+	}
+
+	var output bytes.Buffer
+	runner := &Runner{Client: client, Owner: "owner", Repo: "service", Out: &output}
+
+	err := runner.Run(Options{Parent: 42, ParentRepo: "org/planning", Title: "Task"})
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	if gotIssueRepo != "org/planning" {
+		t.Errorf("parent validated in %q, want org/planning", gotIssueRepo)
+	}
+	if gotCreateRepo != "owner/service" {
+		t.Errorf("issue created in %q, want owner/service", gotCreateRepo)
+	}
+	if gotLinkRepo != "org/planning" {
+		t.Errorf("link made in %q, want org/planning", gotLinkRepo)
+	}
+}
+
+func TestRunCrossRepoParentNotFound(t *testing.T) {
+	client := &mockAPIClient{
+		getIssueFunc: func(owner, repo string, number int) (*api.Issue, error) {
+			return nil, errors.New("Not Found")
+		},
+		createIssueFunc: func(opts api.CreateIssueOptions) (*api.IssueResult, error) {
+			t.Error("issue should not be created when the parent is missing")
+			return nil, errors.New("unexpected")
+		},
+	}
+
+	runner := &Runner{Client: client, Owner: "owner", Repo: "service", Out: &bytes.Buffer{}}
+
+	err := runner.Run(Options{Parent: 42, ParentRepo: "org/planning", Title: "Task"})
+	if err == nil {
+		t.Fatal("expected error for missing parent")
+	}
+	for _, want := range []string{"org/planning", "owner/service"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error should name %s: %v", want, err)
+		}
+	}
+}
+
+func TestRunCrossRepoLinkFailure(t *testing.T) {
+	client := &mockAPIClient{
+		createIssueFunc: func(opts api.CreateIssueOptions) (*api.IssueResult, error) {
+			return &api.IssueResult{ID: 12345, Number: 7, URL: "https://github.com/owner/service/issues/7"}, nil
+		},
+		linkSubIssueFunc: func(opts api.LinkSubIssueOptions) error {
+			return errors.New("permission denied")
+		},
+	}
+
+	var output bytes.Buffer
+	runner := &Runner{Client: client, Owner: "owner", Repo: "service", Out: &output}
+
+	if err := runner.Run(Options{Parent: 42, ParentRepo: "org/planning", Title: "Task"}); err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	for _, want := range []string{
+		"Issue created in owner/service but failed to link as sub-issue of org/planning#42",
+		"gh api repos/org/planning/issues/42/sub_issues -F sub_issue_id=12345",
+	} {
+		if !strings.Contains(output.String(), want) {
+			t.Errorf("output should contain %q, got %q", want, output.String())
+		}
+	}
+}
+
+func TestRunParentRepoSameAsRepo(t *testing.T) {
+	client := &mockAPIClient{
+		getIssueFunc: func(owner, repo string, number int) (*api.Issue, error) {
+			t.Error("parent in the same repository should not be validated unless requested")
+			return nil, errors.New("unexpected")
+		},
+	}
+
+	runner := &Runner{Client: client, Owner: "owner", Repo: "repo", Out: &bytes.Buffer{}}
+
+	if err := runner.Run(Options{Parent: 42, ParentRepo: "owner/repo", Title: "Task"}); err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+}
+
+func TestParseFlagsIssueTemplate(t *testing.T) {
+	for _, args := range [][]string{
+		{"--parent", "42", "--issue-template", "Bug report"},
+		{"-p", "42", "-T", "Bug report"},
+	} {
+		opts, err := ParseFlags(args)
+		if err != nil {
+			t.Fatalf("ParseFlags(%v) error = %v", args, err)
+		}
+		if opts.IssueTemplate != "Bug report" {
+			t.Errorf("ParseFlags(%v) IssueTemplate = %q, want %q", args, opts.IssueTemplate, "Bug report")
+		}
+	}
+}
+
+func templateFiles(owner, repo string) ([]api.IssueTemplateFile, error) {
+	return []api.IssueTemplateFile{
+		{Name: "bug_report.md", Content: bugReportTemplate},
+		{Name: "feature.yml", Content: featureForm},
+	}, nil
+}
+
+func TestRunWithNamedMarkdownTemplate(t *testing.T) {
+	var created api.CreateIssueOptions
+	client := &mockAPIClient{
+		listIssueTemplatesFunc: templateFiles,
+		createIssueFunc: func(opts api.CreateIssueOptions) (*api.IssueResult, error) {
+			created = opts
+			return &api.IssueResult{ID: 1, Number: 1, URL: "https://github.com/owner/repo/issues/1"}, nil
+		},
+	}
+
+	var output bytes.Buffer
+	runner := &Runner{Client: client, Owner: "owner", Repo: "repo", Out: &output}
+
+	err := runner.Run(Options{Parent: 42, IssueTemplate: "bug_report", Labels: []string{"Bug", "ui"}})
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+
+	if created.Title != "[Bug]" {
+		t.Errorf("Title = %q, want the template title", created.Title)
+	}
+	if created.Body != "## Steps to reproduce\n" {
+		t.Errorf("Body = %q, want the template body", created.Body)
+	}
+	if strings.Join(created.Labels, ",") != "Bug,ui,triage" {
+		t.Errorf("Labels = %v, want [Bug ui triage]", created.Labels)
+	}
+	if strings.Join(created.Assignees, ",") != "octocat" {
+		t.Errorf("Assignees = %v, want [octocat]", created.Assignees)
+	}
+}
+
+func TestRunTemplateBodyFlagWins(t *testing.T) {
+	var created api.CreateIssueOptions
+	client := &mockAPIClient{
+		listIssueTemplatesFunc: templateFiles,
+		createIssueFunc: func(opts api.CreateIssueOptions) (*api.IssueResult, error) {
+			created = opts
+			return &api.IssueResult{ID: 1, Number: 1, URL: "https://github.com/owner/repo/issues/1"}, nil
+		},
+	}
+
+	var output bytes.Buffer
+	runner := &Runner{Client: client, Owner: "owner", Repo: "repo", Out: &output}
+
+	err := runner.Run(Options{Parent: 42, Title: "Crash", Body: "My own body", IssueTemplate: "Feature request"})
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	if created.Title != "Crash" || created.Body != "My own body" {
+		t.Errorf("flags should override the template, got title %q body %q", created.Title, created.Body)
+	}
+	if strings.Join(created.Labels, ",") != "enhancement" {
+		t.Errorf("Labels = %v, want the template labels", created.Labels)
+	}
+}
+
+func TestRunIssueFormRequiresPrompterOrBody(t *testing.T) {
+	client := &mockAPIClient{listIssueTemplatesFunc: templateFiles}
+
+	var output bytes.Buffer
+	runner := &Runner{Client: client, Owner: "owner", Repo: "repo", Out: &output}
+
+	err := runner.Run(Options{Parent: 42, Title: "Idea", IssueTemplate: "feature"})
+	if err == nil || !strings.Contains(err.Error(), "--body is required") {
+		t.Errorf("expected --body error for issue form, got %v", err)
+	}
+}
+
+func TestRunUnknownIssueTemplate(t *testing.T) {
+	client := &mockAPIClient{listIssueTemplatesFunc: templateFiles}
+
+	var output bytes.Buffer
+	runner := &Runner{Client: client, Owner: "owner", Repo: "repo", Out: &output}
+
+	err := runner.Run(Options{Parent: 42, Title: "Idea", IssueTemplate: "question"})
+	if err == nil || !strings.Contains(err.Error(), "Available templates") {
+		t.Errorf("expected unknown template error, got %v", err)
+	}
+}
+
+func TestRunInteractiveIssueForm(t *testing.T) {
+	var created api.CreateIssueOptions
+	client := &mockAPIClient{
+		listIssueTemplatesFunc: templateFiles,
+		createIssueFunc: func(opts api.CreateIssueOptions) (*api.IssueResult, error) {
+			created = opts
+			return &api.IssueResult{ID: 1, Number: 1, URL: "https://github.com/owner/repo/issues/1"}, nil
+		},
+	}
+
+	prompter := &mockPrompterInCreate{
+		selectFunc: func(prompt, defaultValue string, options []string) (int, error) {
+			if prompt != "Choose a template" {
+				t.Errorf("unexpected select prompt %q", prompt)
+			}
+			want := []string{"Bug report - Something is broken", "Feature request - Suggest an idea", blankIssueOption}
+			if !reflect.DeepEqual(options, want) {
+				t.Errorf("options = %v, want %v", options, want)
+			}
+			return 1, nil
+		},
+		inputFunc: func(prompt, defaultValue string) (string, error) {
+			switch prompt {
+			case "Title":
+				return defaultValue + "Dark mode", nil
+			case "Summary":
+				return "Add dark mode", nil
+			}
+			return "", nil
+		},
+		multiSelectFunc: func(prompt string, defaultValues, options []string) ([]int, error) {
+			return []int{0}, nil
+		},
+	}
+
+	var output bytes.Buffer
+	runner := &Runner{Client: client, Owner: "owner", Repo: "repo", Out: &output, Prompter: prompter}
+
+	if err := runner.Run(Options{Parent: 42}); err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	if created.Title != "[Feature]: Dark mode" {
+		t.Errorf("Title = %q", created.Title)
+	}
+	if !strings.HasPrefix(created.Body, "### Summary\n\nAdd dark mode\n\n") {
+		t.Errorf("Body = %q", created.Body)
+	}
+}
+
+func TestRunInteractiveBlankIssue(t *testing.T) {
+	var created api.CreateIssueOptions
+	client := &mockAPIClient{
+		listIssueTemplatesFunc: templateFiles,
+		createIssueFunc: func(opts api.CreateIssueOptions) (*api.IssueResult, error) {
+			created = opts
+			return &api.IssueResult{ID: 1, Number: 1, URL: "https://github.com/owner/repo/issues/1"}, nil
+		},
+	}
+
+	prompter := &mockPrompterInCreate{
+		selectFunc: func(prompt, defaultValue string, options []string) (int, error) {
+			return len(options) - 1, nil
+		},
+		editorFunc: func(prompt, defaultValue string) (string, error) {
+			if defaultValue != "" {
+				t.Errorf("editor seeded with %q, want empty", defaultValue)
+			}
+			return "Written by hand", nil
+		},
+	}
+
+	var output bytes.Buffer
+	runner := &Runner{Client: client, Owner: "owner", Repo: "repo", Out: &output, Prompter: prompter}
+
+	if err := runner.Run(Options{Parent: 42, Title: "Plain"}); err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	if created.Body != "Written by hand" || len(created.Labels) != 0 {
+		t.Errorf("blank issue should not use a template, got body %q labels %v", created.Body, created.Labels)
+	}
+}
+
+func TestRunEditorBody(t *testing.T) {
+	tests := []struct {
+		name      string
+		opts      Options
+		templates func(owner, repo string) ([]api.IssueTemplateFile, error)
+		edited    string
+		wantSeed  string
+		wantBody  string
+		wantErr   string
+		noEditor  bool
+	}{
+		{
+			name:     "blank body",
+			opts:     Options{Parent: 42, Title: "Task"},
+			edited:   "Details\n",
+			wantBody: "Details",
+		},
+		{
+			name:      "seeded with the template",
+			opts:      Options{Parent: 42, Title: "Crash", IssueTemplate: "Bug report"},
+			templates: templateFiles,
+			edited:    "## Steps to reproduce\nClick it\n",
+			wantSeed:  "## Steps to reproduce\n",
+			wantBody:  "## Steps to reproduce\nClick it",
+		},
+		{
+			name:     "comment lines stripped",
+			opts:     Options{Parent: 42, Title: "Task"},
+			edited:   "<!-- Describe the task -->\nDo it\n<!--\n  multi-line\n-->\nKeep <!-- inline --> text\n",
+			wantBody: "Do it\nKeep <!-- inline --> text",
+		},
+		{
+			name:    "empty body cancels",
+			opts:    Options{Parent: 42, Title: "Task"},
+			edited:  "<!-- only instructions -->\n\n",
+			wantErr: "cancelled",
+		},
+		{
+			name:     "body flag skips the editor",
+			opts:     Options{Parent: 42, Title: "Task", Body: "Given"},
+			wantBody: "Given",
+			noEditor: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var created api.CreateIssueOptions
+			client := &mockAPIClient{
+				listIssueTemplatesFunc: tt.templates,
+				createIssueFunc: func(opts api.CreateIssueOptions) (*api.IssueResult, error) {
+					created = opts
+					return &api.IssueResult{ID: 1, Number: 1, URL: "https://github.com/owner/repo/issues/1"}, nil
+				},
+			}
+
+			editorCalled := false
+			prompter := &mockPrompterInCreate{
+				editorFunc: func(prompt, defaultValue string) (string, error) {
+					editorCalled = true
+					if defaultValue != tt.wantSeed {
+						t.Errorf("editor seeded with %q, want %q", defaultValue, tt.wantSeed)
+					}
+					return tt.edited, nil
+				},
+			}
+
+			var output bytes.Buffer
+			runner := &Runner{Client: client, Owner: "owner", Repo: "repo", Out: &output, Prompter: prompter}
+
+			err := runner.Run(tt.opts)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Run() error = %v", err)
+			}
+			if editorCalled == tt.noEditor {
+				t.Errorf("editor called = %v, want %v", editorCalled, !tt.noEditor)
+			}
+			if created.Body != tt.wantBody {
+				t.Errorf("Body = %q, want %q", created.Body, tt.wantBody)
+			}
+		})
+	}
+}
+
+func TestParseFlagsFieldRequiresProject(t *testing.T) {
+	if _, err := ParseFlags([]string{"-p", "42", "--field", "Status=Todo"}); err == nil {
+		t.Error("expected error when --field is used without --project")
+	}
+
+	opts, err := ParseFlags([]string{"-p", "42", "--project", "Roadmap", "--field", "Status=Todo"})
+	if err != nil {
+		t.Fatalf("ParseFlags() error = %v", err)
+	}
+	if len(opts.Fields) != 1 || opts.Fields[0].Name != "Status" {
+		t.Errorf("Fields = %+v", opts.Fields)
+	}
+}
+
+func TestRunWithProjectFields(t *testing.T) {
+	var set []api.SetProjectFieldValueOptions
+	client := &mockAPIClient{
+		listProjectsFunc: func(owner, repo string) ([]api.Project, error) {
+			return []api.Project{{ID: "PVT_1", Title: "Roadmap", Number: 1}}, nil
+		},
+		addIssueToProjectFunc: func(projectID, issueNodeID string) (string, error) {
+			return "PVTI_new", nil
+		},
+		listProjectFieldsFunc: projectFieldsFixture,
+		setProjectFieldValueFunc: func(opts api.SetProjectFieldValueOptions) error {
+			set = append(set, opts)
+			return nil
+		},
+	}
+
+	var output bytes.Buffer
+	runner := &Runner{Client: client, Owner: "owner", Repo: "repo", Out: &output}
+
+	err := runner.Run(Options{
+		Parent:  42,
+		Title:   "Task",
+		Project: OptionalString{Value: "Roadmap", WasSet: true},
+		Fields: []FieldAssignment{
+			{Name: "Status", Value: "Todo", HasValue: true},
+			{Name: "Priority", Value: "P1", HasValue: true},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+
+	if len(set) != 1 || set[0].ItemID != "PVTI_new" || set[0].Value.SingleSelectOptionID != "opt_todo" {
+		t.Errorf("unexpected field updates: %+v", set)
+	}
+	if !strings.Contains(output.String(), `Warning: field "Priority" not found`) {
+		t.Errorf("expected warning for unknown field, got %q", output.String())
+	}
+	if !strings.Contains(output.String(), "issues/1") {
+		t.Errorf("expected issue URL in output, got %q", output.String())
+	}
+}
+
+func TestParseFlagsMilestoneAndLabelCreation(t *testing.T) {
+	opts, err := ParseFlags([]string{"-p", "42", "-t", "Task", "--milestone", "Sprint 3", "--create-missing-labels=0e8a16"})
+	if err != nil {
+		t.Fatalf("ParseFlags() error = %v", err)
+	}
+	if opts.Milestone != 0 || opts.MilestoneTitle != "Sprint 3" {
+		t.Errorf("Milestone = %d, MilestoneTitle = %q, want the title", opts.Milestone, opts.MilestoneTitle)
+	}
+	if opts.CreateMissingLabels != (labelCreation{Enabled: true, Color: "0e8a16"}) {
+		t.Errorf("CreateMissingLabels = %+v", opts.CreateMissingLabels)
+	}
+
+	opts, err = ParseFlags([]string{"-p", "42", "-m", "7", "--create-missing-labels", "-l", "bug"})
+	if err != nil {
+		t.Fatalf("ParseFlags() error = %v", err)
+	}
+	if opts.Milestone != 7 || opts.MilestoneTitle != "" {
+		t.Errorf("Milestone = %d, MilestoneTitle = %q, want 7", opts.Milestone, opts.MilestoneTitle)
+	}
+	if !opts.CreateMissingLabels.Enabled || strings.Join(opts.Labels, ",") != "bug" {
+		t.Errorf("CreateMissingLabels = %+v, Labels = %v", opts.CreateMissingLabels, opts.Labels)
+	}
+}
+
+func TestRunResolvesMilestoneTitle(t *testing.T) {
+	var created api.CreateIssueOptions
+	client := &mockAPIClient{
+		listMilestonesFunc: func(owner, repo string) ([]api.Milestone, error) {
+			return []api.Milestone{{Number: 4, Title: "Sprint 3", State: "open"}}, nil
+		},
+		createIssueFunc: func(opts api.CreateIssueOptions) (*api.IssueResult, error) {
+			created = opts
+			return &api.IssueResult{ID: 1, Number: 1, URL: "https://github.com/owner/repo/issues/1"}, nil
+		},
+	}
+
+	runner := &Runner{Client: client, Owner: "owner", Repo: "repo", Out: &bytes.Buffer{}}
+	if err := runner.Run(Options{Parent: 42, Title: "Task", MilestoneTitle: "sprint 3"}); err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	if created.Milestone != 4 {
+		t.Errorf("Milestone = %d, want 4", created.Milestone)
+	}
+}
+
+func TestRunRejectsUnknownLabelBeforeCreating(t *testing.T) {
+	client := &mockAPIClient{
+		createIssueFunc: func(opts api.CreateIssueOptions) (*api.IssueResult, error) {
+			t.Error("CreateIssue should not be called")
+			return nil, nil
+		},
+	}
+
+	runner := &Runner{Client: client, Owner: "owner", Repo: "repo", Out: &bytes.Buffer{}}
+	err := runner.Run(Options{Parent: 42, Title: "Task", Labels: []string{"enhancment"}})
+	if err == nil || !strings.Contains(err.Error(), `did you mean "enhancement"?`) {
+		t.Errorf("error = %v, want a suggestion", err)
+	}
+}
+
+func TestRunResolvesSpecialAssignees(t *testing.T) {
+	var created api.CreateIssueOptions
+	client := &mockAPIClient{
+		getIssueFunc: func(owner, repo string, number int) (*api.Issue, error) {
+			if owner != "my-org" || repo != "planning" || number != 42 {
+				t.Errorf("GetIssue(%s/%s#%d), want my-org/planning#42", owner, repo, number)
+			}
+			return &api.Issue{Number: 42, Assignees: []api.User{{Login: "hubot"}, {Login: "octocat"}}}, nil
+		},
+		createIssueFunc: func(opts api.CreateIssueOptions) (*api.IssueResult, error) {
+			created = opts
+			return &api.IssueResult{ID: 1, Number: 1, URL: "https://github.com/owner/repo/issues/1"}, nil
+		},
+	}
+
+	runner := &Runner{Client: client, Owner: "owner", Repo: "repo", Out: &bytes.Buffer{}}
+	opts := Options{Parent: 42, ParentRepo: "my-org/planning", Title: "Task", Assignees: []string{"@me", "@parent", "octocat"}}
+	if err := runner.Run(opts); err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	want := []string{"testuser", "hubot", "octocat"}
+	if !reflect.DeepEqual(created.Assignees, want) {
+		t.Errorf("Assignees = %v, want %v", created.Assignees, want)
+	}
+}
+
+func TestRunInteractiveMetadata(t *testing.T) {
+	client := &mockAPIClient{
+		listAssigneesFunc: func(owner, repo string) ([]api.User, error) {
+			return []api.User{{Login: "hubot"}, {Login: "octocat"}}, nil
+		},
+		listMilestonesFunc: func(owner, repo string) ([]api.Milestone, error) {
+			return []api.Milestone{
+				{Number: 1, Title: "v1.0", State: "closed"},
+				{Number: 2, Title: "Sprint 3", State: "open"},
+			}, nil
+		},
+	}
+
+	t.Run("prompts without metadata flags", func(t *testing.T) {
+		var created api.CreateIssueOptions
+		client.createIssueFunc = func(opts api.CreateIssueOptions) (*api.IssueResult, error) {
+			created = opts
+			return &api.IssueResult{ID: 1, Number: 1, URL: "https://github.com/owner/repo/issues/1"}, nil
+		}
+		var prompts []string
+		prompter := &mockPrompterInCreate{
+			multiSelectFunc: func(prompt string, defaultValues, options []string) ([]int, error) {
+				prompts = append(prompts, prompt)
+				if prompt == "Labels" {
+					return []int{0, 3}, nil
+				}
+				return []int{1}, nil
+			},
+			selectFunc: func(prompt, defaultValue string, options []string) (int, error) {
+				prompts = append(prompts, prompt)
+				if !reflect.DeepEqual(options, []string{"(none)", "Sprint 3"}) {
+					t.Errorf("milestone options = %v", options)
+				}
+				return 1, nil
+			},
+		}
+
+		runner := &Runner{Client: client, Owner: "owner", Repo: "repo", Out: &bytes.Buffer{}, Prompter: prompter}
+		if err := runner.Run(Options{Parent: 42, Title: "Task", Body: "Body"}); err != nil {
+			t.Fatalf("Run() error = %v", err)
+		}
+		if !reflect.DeepEqual(prompts, []string{"Labels", "Assignees", "Milestone"}) {
+			t.Errorf("prompts = %v", prompts)
+		}
+		if !reflect.DeepEqual(created.Labels, []string{"bug", "ui"}) {
+			t.Errorf("Labels = %v, want [bug ui]", created.Labels)
+		}
+		if !reflect.DeepEqual(created.Assignees, []string{"octocat"}) {
+			t.Errorf("Assignees = %v, want [octocat]", created.Assignees)
+		}
+		if created.Milestone != 2 {
+			t.Errorf("Milestone = %d, want 2", created.Milestone)
+		}
+	})
+
+	t.Run("skipped with a metadata flag", func(t *testing.T) {
+		client.createIssueFunc = nil
+		prompter := &mockPrompterInCreate{
+			multiSelectFunc: func(prompt string, defaultValues, options []string) ([]int, error) {
+				t.Errorf("MultiSelect(%q) should not be called", prompt)
+				return nil, nil
+			},
+		}
+
+		runner := &Runner{Client: client, Owner: "owner", Repo: "repo", Out: &bytes.Buffer{}, Prompter: prompter}
+		if err := runner.Run(Options{Parent: 42, Title: "Task", Body: "Body", Assignees: []string{"hubot"}}); err != nil {
+			t.Fatalf("Run() error = %v", err)
+		}
+	})
+}
+
+func TestRunIssueType(t *testing.T) {
+	orgTypes := func(owner string) ([]api.IssueType, error) {
+		return []api.IssueType{{Name: "Bug", Description: "An unexpected problem"}, {Name: "Task"}}, nil
+	}
+
+	t.Run("from the flag", func(t *testing.T) {
+		var created api.CreateIssueOptions
+		client := &mockAPIClient{
+			listIssueTypesFunc: orgTypes,
+			createIssueFunc: func(opts api.CreateIssueOptions) (*api.IssueResult, error) {
+				created = opts
+				return &api.IssueResult{ID: 1, Number: 1, URL: "https://github.com/owner/repo/issues/1"}, nil
+			},
+		}
+		runner := &Runner{Client: client, Owner: "owner", Repo: "repo", Out: &bytes.Buffer{}}
+		if err := runner.Run(Options{Parent: 42, Title: "Task", Type: "bug"}); err != nil {
+			t.Fatalf("Run() error = %v", err)
+		}
+		if created.Type != "Bug" {
+			t.Errorf("Type = %q, want Bug", created.Type)
+		}
+	})
+
+	t.Run("picked interactively", func(t *testing.T) {
+		var created api.CreateIssueOptions
+		client := &mockAPIClient{
+			listIssueTypesFunc: orgTypes,
+			createIssueFunc: func(opts api.CreateIssueOptions) (*api.IssueResult, error) {
+				created = opts
+				return &api.IssueResult{ID: 1, Number: 1, URL: "https://github.com/owner/repo/issues/1"}, nil
+			},
+		}
+		prompter := &mockPrompterInCreate{
+			selectFunc: func(prompt, defaultValue string, options []string) (int, error) {
+				if prompt != "Issue type" {
+					return 0, nil
+				}
+				want := []string{"(none)", "Bug - An unexpected problem", "Task"}
+				if !reflect.DeepEqual(options, want) {
+					t.Errorf("options = %v, want %v", options, want)
+				}
+				return 2, nil
+			},
+		}
+		runner := &Runner{Client: client, Owner: "owner", Repo: "repo", Out: &bytes.Buffer{}, Prompter: prompter}
+		if err := runner.Run(Options{Parent: 42, Title: "Task", Body: "Body", Labels: []string{"bug"}}); err != nil {
+			t.Fatalf("Run() error = %v", err)
+		}
+		if created.Type != "Task" {
+			t.Errorf("Type = %q, want Task", created.Type)
+		}
+	})
+
+	t.Run("repository owned by a user", func(t *testing.T) {
+		client := &mockAPIClient{
+			createIssueFunc: func(opts api.CreateIssueOptions) (*api.IssueResult, error) {
+				t.Error("CreateIssue should not be called")
+				return nil, nil
+			},
+		}
+		runner := &Runner{Client: client, Owner: "owner", Repo: "repo", Out: &bytes.Buffer{}}
+		err := runner.Run(Options{Parent: 42, Title: "Task", Type: "Bug"})
+		if err == nil || !strings.Contains(err.Error(), "only available for organizations") {
+			t.Errorf("error = %v", err)
+		}
+	})
+}
+
+func TestRunAtomic(t *testing.T) {
+	newClient := func(calls *[]string) *mockAPIClient {
+		return &mockAPIClient{
+			createIssueFunc: func(opts api.CreateIssueOptions) (*api.IssueResult, error) {
+				return &api.IssueResult{ID: 5700, Number: 57, URL: "https://github.com/owner/repo/issues/57"}, nil
+			},
+			linkSubIssueFunc: func(opts api.LinkSubIssueOptions) error {
+				return errors.New("parent has too many sub-issues")
+			},
+			deleteIssueFunc: func(issueNodeID string) error {
+				*calls = append(*calls, "delete "+issueNodeID)
+				return nil
+			},
+			removeSubIssueFunc: func(opts api.RemoveSubIssueOptions) error {
+				*calls = append(*calls, fmt.Sprintf("unlink #%d", opts.ParentIssue))
+				return nil
+			},
+			updateIssueFunc: func(opts api.UpdateIssueOptions) (*api.Issue, error) {
+				*calls = append(*calls, fmt.Sprintf("close #%d %s", opts.Number, *opts.StateReason))
+				return &api.Issue{Number: opts.Number}, nil
+			},
+			createCommentFunc: func(owner, repo string, number int, body string) error {
+				*calls = append(*calls, fmt.Sprintf("comment #%d", number))
+				if !strings.HasPrefix(body, "Closed automatically by `gh subissue create --atomic`") {
+					t.Errorf("comment = %q", body)
+				}
+				return nil
+			},
+		}
+	}
+
+	t.Run("deletes the issue when linking fails", func(t *testing.T) {
+		var calls []string
+		client := newClient(&calls)
+
+		var output bytes.Buffer
+		runner := &Runner{Client: client, Owner: "owner", Repo: "repo", Out: &output}
+		err := runner.Run(Options{Parent: 42, Title: "Task", Atomic: true})
+		want := "failed to link issue #57 as a sub-issue of #42: parent has too many sub-issues\nRolled back: deleted issue #57"
+		if err == nil || err.Error() != want {
+			t.Fatalf("error =\n%v\nwant\n%s", err, want)
+		}
+		if !reflect.DeepEqual(calls, []string{"delete I_mock_node_id"}) {
+			t.Errorf("calls = %v", calls)
+		}
+		if output.Len() != 0 {
+			t.Errorf("output = %q, want nothing", output.String())
+		}
+	})
+
+	t.Run("closes the issue when it cannot be deleted", func(t *testing.T) {
+		var calls []string
+		client := newClient(&calls)
+		client.deleteIssueFunc = func(issueNodeID string) error {
+			return errors.New("GraphQL error: must be an admin")
+		}
+
+		runner := &Runner{Client: client, Owner: "owner", Repo: "repo", Out: &bytes.Buffer{}}
+		err := runner.Run(Options{Parent: 42, Title: "Task", Atomic: true})
+		want := "Rolled back: could not delete issue #57 (GraphQL error: must be an admin), so it was closed as not planned: https://github.com/owner/repo/issues/57"
+		if err == nil || !strings.HasSuffix(err.Error(), want) {
+			t.Fatalf("error =\n%v\nwant suffix\n%s", err, want)
+		}
+		if !reflect.DeepEqual(calls, []string{"close #57 not_planned", "comment #57"}) {
+			t.Errorf("calls = %v", calls)
+		}
+	})
+
+	t.Run("reports a failed rollback", func(t *testing.T) {
+		var calls []string
+		client := newClient(&calls)
+		client.getIssueNodeIDFunc = func(owner, repo string, number int) (string, error) {
+			return "", errors.New("timeout")
+		}
+		client.updateIssueFunc = func(opts api.UpdateIssueOptions) (*api.Issue, error) {
+			return nil, errors.New("forbidden")
+		}
+
+		runner := &Runner{Client: client, Owner: "owner", Repo: "repo", Out: &bytes.Buffer{}}
+		err := runner.Run(Options{Parent: 42, Title: "Task", Atomic: true})
+		want := "Rollback failed: could not delete issue #57 (timeout) or close it (forbidden); remove it by hand: https://github.com/owner/repo/issues/57"
+		if err == nil || !strings.HasSuffix(err.Error(), want) {
+			t.Fatalf("error =\n%v\nwant suffix\n%s", err, want)
+		}
+	})
+
+	t.Run("rolls back a failed project add", func(t *testing.T) {
+		var calls []string
+		client := newClient(&calls)
+		client.linkSubIssueFunc = nil
+		client.deleteIssueFunc = func(issueNodeID string) error {
+			return errors.New("must be an admin")
+		}
+
+		runner := &Runner{Client: client, Owner: "owner", Repo: "repo", Out: &bytes.Buffer{}}
+		err := runner.Run(Options{Parent: 42, Title: "Task", Atomic: true, Project: OptionalString{Value: "Roadmap", WasSet: true}})
+		if err == nil || !strings.HasPrefix(err.Error(), "failed to add issue #57 to the project: project \"Roadmap\" not found") {
+			t.Fatalf("error = %v", err)
+		}
+		if !reflect.DeepEqual(calls, []string{"unlink #42", "close #57 not_planned", "comment #57"}) {
+			t.Errorf("calls = %v", calls)
+		}
+	})
+
+	t.Run("warns without --atomic", func(t *testing.T) {
+		var calls []string
+		client := newClient(&calls)
+
+		var output bytes.Buffer
+		runner := &Runner{Client: client, Owner: "owner", Repo: "repo", Out: &output}
+		if err := runner.Run(Options{Parent: 42, Title: "Task"}); err != nil {
+			t.Fatalf("Run() error = %v", err)
+		}
+		if len(calls) != 0 {
+			t.Errorf("calls = %v, want no rollback", calls)
+		}
+		if !strings.Contains(output.String(), "Warning: Issue created but failed to link") {
+			t.Errorf("output = %q", output.String())
+		}
+	})
+}
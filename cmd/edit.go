@@ -1,10 +1,12 @@
 package cmd
 
 import (
+	"errors"
 	"flag"
 	"fmt"
 	"io"
 	"strconv"
+	"strings"
 
 	"github.com/gwyn/gh-subissue/internal/api"
 	"github.com/gwyn/gh-subissue/internal/debug"
@@ -14,7 +16,31 @@ import (
 type EditOptions struct {
 	IssueNumber int
 	Project     OptionalString
+	Fields      []FieldAssignment // project fields to set, requires Project
+	Remove      OptionalString    // project to remove the issue from
+	Archive     OptionalString    // project to archive the issue's item in
 	Repo        string
+
+	Title           string
+	Body            OptionalString
+	BodyFile        string
+	AddLabels       []string
+	RemoveLabels    []string
+	AddAssignees    []string
+	RemoveAssignees []string
+	Milestone       OptionalString // milestone number or title; empty or "none" removes it
+	Type            OptionalString // issue type name; "none" removes it, empty prompts
+	State           string         // "open" or "closed"
+	Reason          string         // "completed" or "not_planned", with State "closed"
+	Parent          IssueRef       // new parent; a zero Number leaves the parent unchanged
+}
+
+// hasIssueChanges reports whether any of the issue's own fields are being edited.
+func (o EditOptions) hasIssueChanges() bool {
+	return o.Title != "" || o.Body.WasSet || o.BodyFile != "" ||
+		len(o.AddLabels) > 0 || len(o.RemoveLabels) > 0 ||
+		len(o.AddAssignees) > 0 || len(o.RemoveAssignees) > 0 ||
+		o.Milestone.WasSet || o.Type.WasSet || o.State != ""
 }
 
 // ParseEditFlags parses command line flags for the edit command.
@@ -24,8 +50,39 @@ func ParseEditFlags(args []string) (*EditOptions, error) {
 	opts := &EditOptions{}
 	fs := flag.NewFlagSet("edit", flag.ContinueOnError)
 
-	fs.Var(&opts.Project, "project", "Add to project (interactive if empty)")
-	fs.Var(&opts.Project, "P", "Add to project (interactive if empty)")
+	fs.Var(&opts.Project, "project", "Add to project by title, number or OWNER/NUMBER (interactive if empty)")
+	fs.Var(&opts.Project, "P", "Add to project by title, number or OWNER/NUMBER (interactive if empty)")
+
+	fs.Var(&opts.Remove, "remove-project", "Remove from project (interactive if empty)")
+	fs.Var(&opts.Archive, "archive-in-project", "Archive the project item (interactive if empty)")
+
+	var fields fieldAssignments
+	fs.Var(&fields, "field", "Set a project field as NAME=VALUE (can be repeated)")
+
+	fs.StringVar(&opts.Title, "title", "", "Set the title")
+	fs.StringVar(&opts.Title, "t", "", "Set the title")
+
+	fs.Var(&opts.Body, "body", "Set the body")
+	fs.Var(&opts.Body, "b", "Set the body")
+	fs.StringVar(&opts.BodyFile, "body-file", "", "Read body from file (use - for stdin)")
+
+	var addLabels, removeLabels, addAssignees, removeAssignees stringSlice
+	fs.Var(&addLabels, "add-label", "Add labels (can be repeated)")
+	fs.Var(&removeLabels, "remove-label", "Remove labels (can be repeated)")
+	fs.Var(&addAssignees, "add-assignee", "Add assignees (can be repeated)")
+	fs.Var(&removeAssignees, "remove-assignee", "Remove assignees (can be repeated)")
+
+	fs.Var(&opts.Milestone, "milestone", "Set the milestone by number or title (empty or \"none\" removes it)")
+	fs.Var(&opts.Milestone, "m", "Set the milestone by number or title (empty or \"none\" removes it)")
+
+	fs.Var(&opts.Type, "type", "Set the issue type (\"none\" removes it, interactive if empty)")
+
+	fs.StringVar(&opts.State, "state", "", "Set the state: {open|closed}")
+	fs.StringVar(&opts.Reason, "reason", "", "Reason for closing: {completed|not_planned}")
+
+	var parent string
+	fs.StringVar(&parent, "parent", "", "Move under a new parent: number, OWNER/REPO#NUMBER or URL")
+	fs.StringVar(&parent, "p", "", "Move under a new parent: number, OWNER/REPO#NUMBER or URL")
 
 	fs.StringVar(&opts.Repo, "repo", "", "Repository (owner/repo)")
 	fs.StringVar(&opts.Repo, "R", "", "Repository (owner/repo)")
@@ -49,6 +106,40 @@ func ParseEditFlags(args []string) (*EditOptions, error) {
 			return nil, err
 		}
 	}
+	opts.Fields = fields
+	opts.AddLabels = addLabels
+	opts.RemoveLabels = removeLabels
+	opts.AddAssignees = addAssignees
+	opts.RemoveAssignees = removeAssignees
+
+	if parent != "" {
+		ref, err := ParseIssueRef(parent)
+		if err != nil {
+			return nil, fmt.Errorf("invalid --parent: %w", err)
+		}
+		opts.Parent = ref
+	}
+
+	if len(opts.Fields) > 0 && !opts.Project.WasSet {
+		return nil, fmt.Errorf("--field requires --project")
+	}
+	if opts.Body.WasSet && opts.BodyFile != "" {
+		return nil, errors.New("specify only one of --body or --body-file")
+	}
+	switch opts.State {
+	case "", "open", "closed":
+	default:
+		return nil, fmt.Errorf("invalid state %q (expected open or closed)", opts.State)
+	}
+	switch opts.Reason {
+	case "":
+	case "completed", "not_planned":
+		if opts.State != "closed" {
+			return nil, errors.New("--reason requires --state closed")
+		}
+	default:
+		return nil, fmt.Errorf("invalid reason %q (expected completed or not_planned)", opts.Reason)
+	}
 
 	debug.Log("ParseEditFlags", "parsed", fmt.Sprintf("%+v", opts))
 	return opts, nil
@@ -56,9 +147,21 @@ func ParseEditFlags(args []string) (*EditOptions, error) {
 
 // EditAPIClient defines the interface for edit operations.
 type EditAPIClient interface {
+	GetIssue(owner, repo string, number int) (*api.Issue, error)
+	GetParentIssue(owner, repo string, number int) (*api.Issue, error)
+	LinkSubIssue(opts api.LinkSubIssueOptions) error
+	UpdateIssue(opts api.UpdateIssueOptions) (*api.Issue, error)
+	ListMilestones(owner, repo string) ([]api.Milestone, error)
+	ListIssueTypes(owner string) ([]api.IssueType, error)
 	ListProjects(owner, repo string) ([]api.Project, error)
+	GetProject(owner string, number int) (*api.Project, error)
 	GetIssueNodeID(owner, repo string, number int) (string, error)
-	AddIssueToProject(projectID, issueNodeID string) error
+	AddIssueToProject(projectID, issueNodeID string) (string, error)
+	ListProjectFields(projectID string) ([]api.ProjectField, error)
+	SetProjectFieldValue(opts api.SetProjectFieldValueOptions) error
+	ListIssueProjectItems(owner, repo string, number int) ([]api.ProjectItem, error)
+	DeleteProjectItem(projectID, itemID string) error
+	ArchiveProjectItem(projectID, itemID string) error
 }
 
 // EditRunner executes the edit subcommand.
@@ -67,22 +170,365 @@ type EditRunner struct {
 	Owner    string
 	Repo     string
 	Out      io.Writer
+	Stdin    io.Reader
 	Prompter Prompter
 }
 
 // Run executes the edit command.
 func (r *EditRunner) Run(opts EditOptions) error {
-	debug.Log("EditRunner.Run", "issue", opts.IssueNumber, "project", opts.Project.Value, "project_was_set", opts.Project.WasSet)
+	debug.Log("EditRunner.Run", "issue", opts.IssueNumber, "project", opts.Project.Value, "project_was_set", opts.Project.WasSet,
+		"remove_was_set", opts.Remove.WasSet, "archive_was_set", opts.Archive.WasSet)
+
+	if !opts.hasIssueChanges() && opts.Parent.Number == 0 && !opts.Project.WasSet && !opts.Remove.WasSet && !opts.Archive.WasSet {
+		return fmt.Errorf("no edit options specified\nUse flags such as --title, --add-label, --state, --parent or --project; see: gh subissue help")
+	}
+
+	if opts.hasIssueChanges() {
+		if err := r.updateIssue(opts); err != nil {
+			return err
+		}
+	}
+
+	if opts.Parent.Number != 0 {
+		if err := r.reparent(opts.IssueNumber, opts.Parent); err != nil {
+			return err
+		}
+	}
+
+	if opts.Project.WasSet {
+		if err := r.addToProject(opts); err != nil {
+			return err
+		}
+	}
+	if opts.Remove.WasSet {
+		if err := r.changeProjectItem(opts.IssueNumber, opts.Remove.Value, false); err != nil {
+			return err
+		}
+	}
+	if opts.Archive.WasSet {
+		if err := r.changeProjectItem(opts.IssueNumber, opts.Archive.Value, true); err != nil {
+			return err
+		}
+	}
+
+	debug.Log("EditRunner.Run", "result", "success")
+	return nil
+}
+
+// updateIssue applies the title, body, label, assignee, milestone, type and
+// state changes in a single request and prints what changed.
+func (r *EditRunner) updateIssue(opts EditOptions) error {
+	debug.Log("EditRunner.updateIssue", "issue", opts.IssueNumber)
+
+	before, err := r.Client.GetIssue(r.Owner, r.Repo, opts.IssueNumber)
+	if err != nil {
+		debug.Error("EditRunner.updateIssue", err, "stage", "get_issue")
+		return fmt.Errorf("failed to get issue #%d: %w", opts.IssueNumber, err)
+	}
+
+	update := api.UpdateIssueOptions{
+		Owner:  r.Owner,
+		Repo:   r.Repo,
+		Number: opts.IssueNumber,
+	}
+
+	if opts.Title != "" {
+		update.Title = &opts.Title
+	}
+
+	if opts.Body.WasSet {
+		update.Body = &opts.Body.Value
+	} else if opts.BodyFile != "" {
+		body, err := ReadBody(opts.BodyFile, r.Stdin)
+		if err != nil {
+			debug.Error("EditRunner.updateIssue", err, "stage", "read_body")
+			return err
+		}
+		update.Body = &body
+	}
+
+	if len(opts.AddLabels) > 0 || len(opts.RemoveLabels) > 0 {
+		labels := applyListChanges(labelNames(before.Labels), opts.AddLabels, opts.RemoveLabels)
+		update.Labels = &labels
+	}
+
+	if len(opts.AddAssignees) > 0 || len(opts.RemoveAssignees) > 0 {
+		assignees := applyListChanges(userLogins(before.Assignees), opts.AddAssignees, opts.RemoveAssignees)
+		update.Assignees = &assignees
+	}
+
+	if opts.Milestone.WasSet {
+		number, err := r.milestoneNumber(opts.Milestone.Value)
+		if err != nil {
+			return err
+		}
+		update.Milestone = &number
+	}
+
+	if opts.Type.WasSet {
+		name, err := r.issueType(opts.Type.Value, before.TypeName())
+		if err != nil {
+			return err
+		}
+		update.Type = &name
+	}
+
+	if opts.State != "" {
+		update.State = &opts.State
+		if opts.Reason != "" {
+			update.StateReason = &opts.Reason
+		}
+	}
+
+	after, err := r.Client.UpdateIssue(update)
+	if err != nil {
+		debug.Error("EditRunner.updateIssue", err, "stage", "update_issue")
+		return err
+	}
+
+	printIssueChanges(r.Out, before, after)
+	debug.Log("EditRunner.updateIssue", "result", "success")
+	return nil
+}
+
+// reparent moves the issue under a new parent, replacing its current one.
+// Moves that would make the issue an ancestor of itself are refused.
+func (r *EditRunner) reparent(number int, parent IssueRef) error {
+	debug.Log("EditRunner.reparent", "issue", number, "parent", parent.String())
+
+	if parent.Owner == "" {
+		parent.Owner, parent.Repo = r.Owner, r.Repo
+	}
+	issueRef := IssueRef{Owner: r.Owner, Repo: r.Repo, Number: number}
+	if sameIssue(parent, issueRef) {
+		return fmt.Errorf("issue #%d cannot be its own parent", number)
+	}
+
+	issue, err := r.Client.GetIssue(r.Owner, r.Repo, number)
+	if err != nil {
+		debug.Error("EditRunner.reparent", err, "stage", "get_issue")
+		return fmt.Errorf("failed to get issue #%d: %w", number, err)
+	}
+
+	oldParent, err := r.Client.GetParentIssue(r.Owner, r.Repo, number)
+	if err != nil {
+		debug.Error("EditRunner.reparent", err, "stage", "get_parent")
+		return fmt.Errorf("failed to get the parent of issue #%d: %w", number, err)
+	}
+	var oldRef IssueRef
+	if oldParent != nil {
+		oldRef = issueRefOf(*oldParent, r.Owner, r.Repo)
+		if sameIssue(oldRef, parent) {
+			fmt.Fprintf(r.Out, "Issue #%d is already a sub-issue of %s\n", number, parent.RelativeTo(r.Owner, r.Repo))
+			return nil
+		}
+	}
+
+	if err := r.checkCycle(issueRef, parent); err != nil {
+		return err
+	}
+
+	err = r.Client.LinkSubIssue(api.LinkSubIssueOptions{
+		Owner:         parent.Owner,
+		Repo:          parent.Repo,
+		ParentIssue:   parent.Number,
+		SubIssueID:    issue.ID,
+		ReplaceParent: true,
+	})
+	if err != nil {
+		debug.Error("EditRunner.reparent", err, "stage", "link_sub_issue")
+		return fmt.Errorf("failed to move issue #%d under %s: %w", number, parent.RelativeTo(r.Owner, r.Repo), err)
+	}
+
+	if oldParent == nil {
+		fmt.Fprintf(r.Out, "Moved issue #%d under %s (it had no parent)\n", number, parent.RelativeTo(r.Owner, r.Repo))
+	} else {
+		fmt.Fprintf(r.Out, "Moved issue #%d from %s to %s\n", number, oldRef.RelativeTo(r.Owner, r.Repo), parent.RelativeTo(r.Owner, r.Repo))
+	}
+	debug.Log("EditRunner.reparent", "result", "success", "old_parent", oldRef.Number, "new_parent", parent.Number)
+	return nil
+}
+
+// checkCycle walks up from the new parent and fails if it reaches the issue,
+// which would make the issue its own ancestor.
+func (r *EditRunner) checkCycle(issue, parent IssueRef) error {
+	chain := []IssueRef{parent}
+	visited := map[string]bool{strings.ToLower(parent.String()): true}
+	current := parent
+	for {
+		ancestor, err := r.Client.GetParentIssue(current.Owner, current.Repo, current.Number)
+		if err != nil {
+			debug.Error("EditRunner.checkCycle", err, "stage", "get_parent", "issue", current.String())
+			return fmt.Errorf("failed to check the ancestors of %s: %w", parent.RelativeTo(r.Owner, r.Repo), err)
+		}
+		if ancestor == nil {
+			return nil
+		}
+
+		current = issueRefOf(*ancestor, r.Owner, r.Repo)
+		chain = append(chain, current)
+		if sameIssue(current, issue) {
+			crumbs := make([]string, len(chain))
+			for i, ref := range chain {
+				crumbs[len(chain)-1-i] = ref.RelativeTo(r.Owner, r.Repo)
+			}
+			debug.Log("EditRunner.checkCycle", "result", "cycle", "chain", crumbs)
+			target := parent.RelativeTo(r.Owner, r.Repo)
+			return fmt.Errorf("cannot move #%d under %s: %s is a descendant of #%d (%s)",
+				issue.Number, target, target, issue.Number, strings.Join(crumbs, " > "))
+		}
+		key := strings.ToLower(current.String())
+		if visited[key] {
+			return nil
+		}
+		visited[key] = true
+	}
+}
+
+// issueType resolves a --type value; "none" is "", which removes the type,
+// and an empty value prompts for one of the organization's types.
+func (r *EditRunner) issueType(value, current string) (string, error) {
+	switch {
+	case strings.EqualFold(value, "none"):
+		return "", nil
+	case value == "":
+		if r.Prompter == nil {
+			err := errors.New("--type requires a value when not running interactively")
+			debug.Error("EditRunner.issueType", err, "reason", "no_prompter")
+			return "", err
+		}
+		return selectIssueType(r.Client, r.Prompter, r.Owner, current)
+	}
+	return resolveIssueType(r.Client, r.Owner, value)
+}
+
+// milestoneNumber resolves a --milestone value; empty or "none" is 0, which
+// removes the milestone, and anything but a number is looked up by title.
+func (r *EditRunner) milestoneNumber(value string) (int, error) {
+	if value == "" || strings.EqualFold(value, "none") {
+		return 0, nil
+	}
+	if number, err := strconv.Atoi(value); err == nil && number > 0 {
+		return number, nil
+	}
+	return resolveMilestone(r.Client, r.Owner, r.Repo, value)
+}
+
+// applyListChanges removes and then adds values, ignoring case. The order of
+// the existing values is kept and new values are appended.
+func applyListChanges(current, add, remove []string) []string {
+	result := make([]string, 0, len(current)+len(add))
+	for _, v := range current {
+		removed := false
+		for _, r := range remove {
+			if strings.EqualFold(v, r) {
+				removed = true
+				break
+			}
+		}
+		if !removed {
+			result = append(result, v)
+		}
+	}
+	return appendMissing(result, add...)
+}
+
+func labelNames(labels []api.Label) []string {
+	names := make([]string, len(labels))
+	for i, l := range labels {
+		names[i] = l.Name
+	}
+	return names
+}
+
+func userLogins(users []api.User) []string {
+	logins := make([]string, len(users))
+	for i, u := range users {
+		logins[i] = u.Login
+	}
+	return logins
+}
+
+// printIssueChanges prints a before/after line for every field that changed.
+func printIssueChanges(w io.Writer, before, after *api.Issue) {
+	type change struct{ field, from, to string }
+	var changes []change
+
+	if before.Title != after.Title {
+		changes = append(changes, change{"title", fmt.Sprintf("%q", before.Title), fmt.Sprintf("%q", after.Title)})
+	}
+	if before.Body != after.Body {
+		changes = append(changes, change{"body", fmt.Sprintf("%d characters", len(before.Body)), fmt.Sprintf("%d characters", len(after.Body))})
+	}
+	if from, to := listOrNone(labelNames(before.Labels)), listOrNone(labelNames(after.Labels)); from != to {
+		changes = append(changes, change{"labels", from, to})
+	}
+	if from, to := listOrNone(atLogins(before.Assignees)), listOrNone(atLogins(after.Assignees)); from != to {
+		changes = append(changes, change{"assignees", from, to})
+	}
+	if from, to := milestoneTitle(before.Milestone), milestoneTitle(after.Milestone); from != to {
+		changes = append(changes, change{"milestone", from, to})
+	}
+	if from, to := typeOrNone(before), typeOrNone(after); from != to {
+		changes = append(changes, change{"type", from, to})
+	}
+	if from, to := stateWithReason(before), stateWithReason(after); from != to {
+		changes = append(changes, change{"state", from, to})
+	}
+
+	if len(changes) == 0 {
+		fmt.Fprintf(w, "No changes to issue #%d\n", after.Number)
+		return
+	}
+	fmt.Fprintf(w, "Updated issue #%d\n", after.Number)
+	for _, c := range changes {
+		fmt.Fprintf(w, "  %-10s %s -> %s\n", c.field+":", c.from, c.to)
+	}
+}
+
+func listOrNone(values []string) string {
+	if len(values) == 0 {
+		return "(none)"
+	}
+	return strings.Join(values, ", ")
+}
+
+func atLogins(users []api.User) []string {
+	logins := userLogins(users)
+	for i := range logins {
+		logins[i] = "@" + logins[i]
+	}
+	return logins
+}
+
+func typeOrNone(issue *api.Issue) string {
+	if name := issue.TypeName(); name != "" {
+		return name
+	}
+	return "(none)"
+}
+
+func milestoneTitle(m *api.Milestone) string {
+	if m == nil {
+		return "(none)"
+	}
+	return m.Title
+}
 
-	// Currently only project assignment is supported
-	if !opts.Project.WasSet {
-		return fmt.Errorf("no edit options specified (use --project to add to a project)")
+// stateWithReason formats a state as "closed (not planned)".
+func stateWithReason(issue *api.Issue) string {
+	if issue.State == "closed" && issue.StateReason != "" {
+		return fmt.Sprintf("%s (%s)", issue.State, strings.ReplaceAll(issue.StateReason, "_", " "))
 	}
+	return issue.State
+}
 
+// addToProject adds the issue to a project and sets any requested fields.
+func (r *EditRunner) addToProject(opts EditOptions) error {
 	// List projects
 	projects, err := r.Client.ListProjects(r.Owner, r.Repo)
 	if err != nil {
-		debug.Error("EditRunner.Run", err, "stage", "list_projects")
+		debug.Error("EditRunner.addToProject", err, "stage", "list_projects")
 		return fmt.Errorf("failed to list projects: %w", err)
 	}
 
@@ -108,18 +554,18 @@ func (r *EditRunner) Run(opts EditOptions) error {
 
 		project, err := SelectProject(r.Prompter, projects)
 		if err != nil {
-			debug.Error("EditRunner.Run", err, "stage", "select_project")
+			debug.Error("EditRunner.addToProject", err, "stage", "select_project")
 			return err
 		}
 		selectedProject = project
 	} else {
-		// Find project by name
-		for i := range projects {
-			if projects[i].Title == opts.Project.Value {
-				selectedProject = &projects[i]
-				break
-			}
+		// Find project by title, number or OWNER/NUMBER
+		project, err := findProject(r.Client, projects, opts.Project.Value, r.Owner)
+		if err != nil {
+			debug.Error("EditRunner.addToProject", err, "stage", "find_project")
+			return err
 		}
+		selectedProject = project
 		if selectedProject == nil {
 			var names []string
 			for _, p := range projects {
@@ -132,18 +578,110 @@ func (r *EditRunner) Run(opts EditOptions) error {
 	// Get issue node ID
 	nodeID, err := r.Client.GetIssueNodeID(r.Owner, r.Repo, opts.IssueNumber)
 	if err != nil {
-		debug.Error("EditRunner.Run", err, "stage", "get_issue_node_id")
+		debug.Error("EditRunner.addToProject", err, "stage", "get_issue_node_id")
 		return fmt.Errorf("failed to get issue: %w", err)
 	}
 
 	// Add to project
-	err = r.Client.AddIssueToProject(selectedProject.ID, nodeID)
+	itemID, err := r.Client.AddIssueToProject(selectedProject.ID, nodeID)
 	if err != nil {
-		debug.Error("EditRunner.Run", err, "stage", "add_issue_to_project")
+		debug.Error("EditRunner.addToProject", err, "stage", "add_issue_to_project")
 		return fmt.Errorf("failed to add issue to project: %w", err)
 	}
 
 	fmt.Fprintf(r.Out, "Added issue #%d to project %q\n", opts.IssueNumber, selectedProject.Title)
-	debug.Log("EditRunner.Run", "result", "success", "project", selectedProject.Title)
+
+	// Set project fields; adding an existing item returns the same item ID
+	if len(opts.Fields) > 0 {
+		set, err := setProjectFields(r.Client, r.Prompter, selectedProject, itemID, opts.Fields)
+		for _, s := range set {
+			fmt.Fprintf(r.Out, "Set %s\n", s)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	debug.Log("EditRunner.addToProject", "result", "success", "project", selectedProject.Title)
+	return nil
+}
+
+// changeProjectItem archives the issue's item in a project when archive is
+// true, and removes it otherwise. Only projects the issue belongs to are
+// offered.
+func (r *EditRunner) changeProjectItem(issueNumber int, value string, archive bool) error {
+	debug.Log("EditRunner.changeProjectItem", "issue", issueNumber, "value", value, "archive", archive)
+
+	// flag names the option in messages
+	flag := "--remove-project"
+	if archive {
+		flag = "--archive-in-project"
+	}
+
+	items, err := r.Client.ListIssueProjectItems(r.Owner, r.Repo, issueNumber)
+	if err != nil {
+		debug.Error("EditRunner.changeProjectItem", err, "stage", "list_project_items")
+		return fmt.Errorf("failed to list the projects of issue #%d: %w", issueNumber, err)
+	}
+	if len(items) == 0 {
+		return fmt.Errorf("issue #%d is not in any project", issueNumber)
+	}
+
+	projects := make([]api.Project, len(items))
+	var names []string
+	for i, item := range items {
+		projects[i] = item.Project
+		names = append(names, item.Project.Title)
+	}
+
+	var selectedProject *api.Project
+	if value == "" {
+		// Interactive mode
+		if r.Prompter == nil {
+			return fmt.Errorf("%s requires a project name when not running interactively\nAvailable projects: %v\nExample: gh subissue edit %d %s %q", flag, names, issueNumber, flag, projects[0].Title)
+		}
+
+		project, err := SelectProject(r.Prompter, projects)
+		if err != nil {
+			debug.Error("EditRunner.changeProjectItem", err, "stage", "select_project")
+			return err
+		}
+		selectedProject = project
+	} else {
+		project, err := findProject(r.Client, projects, value, r.Owner)
+		if err != nil {
+			debug.Error("EditRunner.changeProjectItem", err, "stage", "find_project")
+			return err
+		}
+		selectedProject = project
+	}
+
+	var itemID string
+	if selectedProject != nil {
+		for _, item := range items {
+			if item.Project.ID == selectedProject.ID {
+				itemID = item.ID
+				break
+			}
+		}
+	}
+	if itemID == "" {
+		return fmt.Errorf("issue #%d is not in project %q\nAvailable projects: %v", issueNumber, value, names)
+	}
+
+	if archive {
+		if err := r.Client.ArchiveProjectItem(selectedProject.ID, itemID); err != nil {
+			debug.Error("EditRunner.changeProjectItem", err, "stage", "archive_item")
+			return fmt.Errorf("failed to archive issue in project: %w", err)
+		}
+		fmt.Fprintf(r.Out, "Archived issue #%d in project %q\n", issueNumber, selectedProject.Title)
+	} else {
+		if err := r.Client.DeleteProjectItem(selectedProject.ID, itemID); err != nil {
+			debug.Error("EditRunner.changeProjectItem", err, "stage", "delete_item")
+			return fmt.Errorf("failed to remove issue from project: %w", err)
+		}
+		fmt.Fprintf(r.Out, "Removed issue #%d from project %q\n", issueNumber, selectedProject.Title)
+	}
+
+	debug.Log("EditRunner.changeProjectItem", "result", "success", "project", selectedProject.Title)
 	return nil
 }
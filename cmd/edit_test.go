@@ -2,6 +2,9 @@ package cmd
 
 import (
 	"bytes"
+	"errors"
+	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/gwyn/gh-subissue/internal/api"
@@ -90,9 +93,63 @@ func TestParseEditFlags(t *testing.T) {
 
 // mockEditAPIClient implements the EditAPIClient interface for testing.
 type mockEditAPIClient struct {
-	listProjectsFunc      func(owner, repo string) ([]api.Project, error)
-	getIssueNodeIDFunc    func(owner, repo string, number int) (string, error)
-	addIssueToProjectFunc func(projectID, issueNodeID string) error
+	getIssueFunc              func(owner, repo string, number int) (*api.Issue, error)
+	updateIssueFunc           func(opts api.UpdateIssueOptions) (*api.Issue, error)
+	getParentIssueFunc        func(owner, repo string, number int) (*api.Issue, error)
+	linkSubIssueFunc          func(opts api.LinkSubIssueOptions) error
+	listMilestonesFunc        func(owner, repo string) ([]api.Milestone, error)
+	listIssueTypesFunc        func(owner string) ([]api.IssueType, error)
+	listProjectsFunc          func(owner, repo string) ([]api.Project, error)
+	getProjectFunc            func(owner string, number int) (*api.Project, error)
+	getIssueNodeIDFunc        func(owner, repo string, number int) (string, error)
+	addIssueToProjectFunc     func(projectID, issueNodeID string) (string, error)
+	listProjectFieldsFunc     func(projectID string) ([]api.ProjectField, error)
+	setProjectFieldValueFunc  func(opts api.SetProjectFieldValueOptions) error
+	listIssueProjectItemsFunc func(owner, repo string, number int) ([]api.ProjectItem, error)
+	deleteProjectItemFunc     func(projectID, itemID string) error
+	archiveProjectItemFunc    func(projectID, itemID string) error
+}
+
+func (m *mockEditAPIClient) GetIssue(owner, repo string, number int) (*api.Issue, error) {
+	if m.getIssueFunc != nil {
+		return m.getIssueFunc(owner, repo, number)
+	}
+	return &api.Issue{Number: number, Title: "Issue", State: "open"}, nil
+}
+
+func (m *mockEditAPIClient) UpdateIssue(opts api.UpdateIssueOptions) (*api.Issue, error) {
+	if m.updateIssueFunc != nil {
+		return m.updateIssueFunc(opts)
+	}
+	return &api.Issue{Number: opts.Number, Title: "Issue", State: "open"}, nil
+}
+
+func (m *mockEditAPIClient) GetParentIssue(owner, repo string, number int) (*api.Issue, error) {
+	if m.getParentIssueFunc != nil {
+		return m.getParentIssueFunc(owner, repo, number)
+	}
+	return nil, nil
+}
+
+func (m *mockEditAPIClient) LinkSubIssue(opts api.LinkSubIssueOptions) error {
+	if m.linkSubIssueFunc != nil {
+		return m.linkSubIssueFunc(opts)
+	}
+	return nil
+}
+
+func (m *mockEditAPIClient) ListMilestones(owner, repo string) ([]api.Milestone, error) {
+	if m.listMilestonesFunc != nil {
+		return m.listMilestonesFunc(owner, repo)
+	}
+	return nil, nil
+}
+
+func (m *mockEditAPIClient) ListIssueTypes(owner string) ([]api.IssueType, error) {
+	if m.listIssueTypesFunc != nil {
+		return m.listIssueTypesFunc(owner)
+	}
+	return nil, nil
 }
 
 func (m *mockEditAPIClient) ListProjects(owner, repo string) ([]api.Project, error) {
@@ -102,6 +159,13 @@ func (m *mockEditAPIClient) ListProjects(owner, repo string) ([]api.Project, err
 	return []api.Project{}, nil
 }
 
+func (m *mockEditAPIClient) GetProject(owner string, number int) (*api.Project, error) {
+	if m.getProjectFunc != nil {
+		return m.getProjectFunc(owner, number)
+	}
+	return nil, errors.New("not found")
+}
+
 func (m *mockEditAPIClient) GetIssueNodeID(owner, repo string, number int) (string, error) {
 	if m.getIssueNodeIDFunc != nil {
 		return m.getIssueNodeIDFunc(owner, repo, number)
@@ -109,10 +173,45 @@ func (m *mockEditAPIClient) GetIssueNodeID(owner, repo string, number int) (stri
 	return "I_mock", nil
 }
 
-func (m *mockEditAPIClient) AddIssueToProject(projectID, issueNodeID string) error {
+func (m *mockEditAPIClient) AddIssueToProject(projectID, issueNodeID string) (string, error) {
 	if m.addIssueToProjectFunc != nil {
 		return m.addIssueToProjectFunc(projectID, issueNodeID)
 	}
+	return "PVTI_mock", nil
+}
+
+func (m *mockEditAPIClient) ListProjectFields(projectID string) ([]api.ProjectField, error) {
+	if m.listProjectFieldsFunc != nil {
+		return m.listProjectFieldsFunc(projectID)
+	}
+	return nil, nil
+}
+
+func (m *mockEditAPIClient) SetProjectFieldValue(opts api.SetProjectFieldValueOptions) error {
+	if m.setProjectFieldValueFunc != nil {
+		return m.setProjectFieldValueFunc(opts)
+	}
+	return nil
+}
+
+func (m *mockEditAPIClient) ListIssueProjectItems(owner, repo string, number int) ([]api.ProjectItem, error) {
+	if m.listIssueProjectItemsFunc != nil {
+		return m.listIssueProjectItemsFunc(owner, repo, number)
+	}
+	return nil, nil
+}
+
+func (m *mockEditAPIClient) DeleteProjectItem(projectID, itemID string) error {
+	if m.deleteProjectItemFunc != nil {
+		return m.deleteProjectItemFunc(projectID, itemID)
+	}
+	return nil
+}
+
+func (m *mockEditAPIClient) ArchiveProjectItem(projectID, itemID string) error {
+	if m.archiveProjectItemFunc != nil {
+		return m.archiveProjectItemFunc(projectID, itemID)
+	}
 	return nil
 }
 
@@ -134,12 +233,12 @@ func TestEditRunnerAddToProject(t *testing.T) {
 			}
 			return "I_abc123", nil
 		},
-		addIssueToProjectFunc: func(projectID, issueNodeID string) error {
+		addIssueToProjectFunc: func(projectID, issueNodeID string) (string, error) {
 			addCalled = true
 			if projectID != "PVT_1" {
 				t.Errorf("expected project PVT_1, got %s", projectID)
 			}
-			return nil
+			return "PVTI_1", nil
 		},
 	}
 
@@ -179,11 +278,11 @@ func TestEditRunnerInteractiveProject(t *testing.T) {
 		getIssueNodeIDFunc: func(owner, repo string, number int) (string, error) {
 			return "I_abc123", nil
 		},
-		addIssueToProjectFunc: func(projectID, issueNodeID string) error {
+		addIssueToProjectFunc: func(projectID, issueNodeID string) (string, error) {
 			if projectID != "PVT_2" {
 				t.Errorf("expected project PVT_2 (second), got %s", projectID)
 			}
-			return nil
+			return "PVTI_1", nil
 		},
 	}
 
@@ -235,3 +334,635 @@ func TestEditRunnerNoProject(t *testing.T) {
 		t.Error("expected error when no project specified")
 	}
 }
+
+func TestParseEditFlagsFields(t *testing.T) {
+	opts, err := ParseEditFlags([]string{"43", "--project", "Roadmap", "--field", "Status=Todo", "--field", "Sprint"})
+	if err != nil {
+		t.Fatalf("ParseEditFlags() error = %v", err)
+	}
+	want := []FieldAssignment{{Name: "Status", Value: "Todo", HasValue: true}, {Name: "Sprint"}}
+	if !reflect.DeepEqual(opts.Fields, want) {
+		t.Errorf("Fields = %+v, want %+v", opts.Fields, want)
+	}
+
+	if _, err := ParseEditFlags([]string{"43", "--field", "Status=Todo"}); err == nil {
+		t.Error("expected error when --field is used without --project")
+	}
+}
+
+func TestEditRunnerSetsProjectFields(t *testing.T) {
+	var set []api.SetProjectFieldValueOptions
+	client := &mockEditAPIClient{
+		listProjectsFunc: func(owner, repo string) ([]api.Project, error) {
+			return []api.Project{{ID: "PVT_1", Title: "Roadmap", Number: 1}}, nil
+		},
+		addIssueToProjectFunc: func(projectID, issueNodeID string) (string, error) {
+			return "PVTI_43", nil
+		},
+		listProjectFieldsFunc: projectFieldsFixture,
+		setProjectFieldValueFunc: func(opts api.SetProjectFieldValueOptions) error {
+			set = append(set, opts)
+			return nil
+		},
+	}
+
+	var output bytes.Buffer
+	runner := &EditRunner{Client: client, Owner: "owner", Repo: "repo", Out: &output}
+
+	err := runner.Run(EditOptions{
+		IssueNumber: 43,
+		Project:     OptionalString{Value: "Roadmap", WasSet: true},
+		Fields: []FieldAssignment{
+			{Name: "Status", Value: "In progress", HasValue: true},
+			{Name: "Estimate", Value: "5", HasValue: true},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+
+	if len(set) != 2 || set[0].ItemID != "PVTI_43" || set[1].FieldID != "F_est" {
+		t.Errorf("unexpected field updates: %+v", set)
+	}
+	want := "Added issue #43 to project \"Roadmap\"\nSet Status=In progress\nSet Estimate=5\n"
+	if output.String() != want {
+		t.Errorf("output = %q, want %q", output.String(), want)
+	}
+}
+
+func TestParseEditFlagsRemoveAndArchive(t *testing.T) {
+	opts, err := ParseEditFlags([]string{"43", "--remove-project", "Roadmap", "--archive-in-project", ""})
+	if err != nil {
+		t.Fatalf("ParseEditFlags() error = %v", err)
+	}
+	if opts.Remove != (OptionalString{Value: "Roadmap", WasSet: true}) {
+		t.Errorf("Remove = %+v", opts.Remove)
+	}
+	if opts.Archive != (OptionalString{WasSet: true}) {
+		t.Errorf("Archive = %+v", opts.Archive)
+	}
+}
+
+func issueProjectItems(owner, repo string, number int) ([]api.ProjectItem, error) {
+	return []api.ProjectItem{
+		{ID: "PVTI_1", Project: api.Project{ID: "PVT_1", Title: "Roadmap", Number: 1, Owner: "owner"}},
+		{ID: "PVTI_2", Project: api.Project{ID: "PVT_2", Title: "Sprint", Number: 2, Owner: "owner"}},
+	}, nil
+}
+
+func TestEditRunnerRemoveAndArchive(t *testing.T) {
+	tests := []struct {
+		name        string
+		opts        EditOptions
+		prompter    Prompter
+		wantDeleted string
+		wantArchive string
+		wantOutput  string
+		wantErr     string
+	}{
+		{
+			name:        "remove by title",
+			opts:        EditOptions{IssueNumber: 43, Remove: OptionalString{Value: "Sprint", WasSet: true}},
+			wantDeleted: "PVT_2/PVTI_2",
+			wantOutput:  "Removed issue #43 from project \"Sprint\"\n",
+		},
+		{
+			name:        "archive by number",
+			opts:        EditOptions{IssueNumber: 43, Archive: OptionalString{Value: "1", WasSet: true}},
+			wantArchive: "PVT_1/PVTI_1",
+			wantOutput:  "Archived issue #43 in project \"Roadmap\"\n",
+		},
+		{
+			name: "interactive remove",
+			opts: EditOptions{IssueNumber: 43, Remove: OptionalString{WasSet: true}},
+			prompter: &mockPrompter{
+				selectFunc: func(prompt, defaultValue string, options []string) (int, error) {
+					return 1, nil
+				},
+			},
+			wantDeleted: "PVT_2/PVTI_2",
+			wantOutput:  "Removed issue #43 from project \"Sprint\"\n",
+		},
+		{
+			name:    "non-interactive without a name lists projects",
+			opts:    EditOptions{IssueNumber: 43, Remove: OptionalString{WasSet: true}},
+			wantErr: "--remove-project requires a project name when not running interactively\nAvailable projects: [Roadmap Sprint]",
+		},
+		{
+			name:    "issue not in the project",
+			opts:    EditOptions{IssueNumber: 43, Archive: OptionalString{Value: "Backlog", WasSet: true}},
+			wantErr: "issue #43 is not in project \"Backlog\"\nAvailable projects: [Roadmap Sprint]",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var deleted, archived string
+			client := &mockEditAPIClient{
+				listIssueProjectItemsFunc: issueProjectItems,
+				deleteProjectItemFunc: func(projectID, itemID string) error {
+					deleted = projectID + "/" + itemID
+					return nil
+				},
+				archiveProjectItemFunc: func(projectID, itemID string) error {
+					archived = projectID + "/" + itemID
+					return nil
+				},
+			}
+
+			var output bytes.Buffer
+			runner := &EditRunner{Client: client, Owner: "owner", Repo: "repo", Out: &output, Prompter: tt.prompter}
+
+			err := runner.Run(tt.opts)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Run() error = %v", err)
+			}
+			if deleted != tt.wantDeleted || archived != tt.wantArchive {
+				t.Errorf("deleted %q archived %q, want %q and %q", deleted, archived, tt.wantDeleted, tt.wantArchive)
+			}
+			if output.String() != tt.wantOutput {
+				t.Errorf("output = %q, want %q", output.String(), tt.wantOutput)
+			}
+		})
+	}
+}
+
+func TestEditRunnerRemoveNotInAnyProject(t *testing.T) {
+	var output bytes.Buffer
+	runner := &EditRunner{Client: &mockEditAPIClient{}, Owner: "owner", Repo: "repo", Out: &output}
+
+	err := runner.Run(EditOptions{IssueNumber: 43, Remove: OptionalString{Value: "Roadmap", WasSet: true}})
+	if err == nil || !strings.Contains(err.Error(), "not in any project") {
+		t.Errorf("expected not in any project error, got %v", err)
+	}
+}
+
+func TestParseEditFlagsIssueFields(t *testing.T) {
+	opts, err := ParseEditFlags([]string{"43",
+		"--title", "New title",
+		"--body", "",
+		"--add-label", "bug", "--add-label", "ui",
+		"--remove-label", "triage",
+		"--add-assignee", "alice",
+		"--remove-assignee", "bob",
+		"--milestone", "none",
+		"--state", "closed",
+		"--reason", "not_planned",
+	})
+	if err != nil {
+		t.Fatalf("ParseEditFlags() error = %v", err)
+	}
+	if opts.Title != "New title" {
+		t.Errorf("Title = %q, want %q", opts.Title, "New title")
+	}
+	if !opts.Body.WasSet || opts.Body.Value != "" {
+		t.Errorf("Body = %+v, want empty and set", opts.Body)
+	}
+	if !reflect.DeepEqual(opts.AddLabels, []string{"bug", "ui"}) {
+		t.Errorf("AddLabels = %v", opts.AddLabels)
+	}
+	if !reflect.DeepEqual(opts.RemoveLabels, []string{"triage"}) {
+		t.Errorf("RemoveLabels = %v", opts.RemoveLabels)
+	}
+	if !reflect.DeepEqual(opts.AddAssignees, []string{"alice"}) {
+		t.Errorf("AddAssignees = %v", opts.AddAssignees)
+	}
+	if !reflect.DeepEqual(opts.RemoveAssignees, []string{"bob"}) {
+		t.Errorf("RemoveAssignees = %v", opts.RemoveAssignees)
+	}
+	if !opts.Milestone.WasSet || opts.Milestone.Value != "none" {
+		t.Errorf("Milestone = %+v", opts.Milestone)
+	}
+	if opts.State != "closed" || opts.Reason != "not_planned" {
+		t.Errorf("State = %q, Reason = %q", opts.State, opts.Reason)
+	}
+	if !opts.hasIssueChanges() {
+		t.Error("hasIssueChanges() = false, want true")
+	}
+}
+
+func TestParseEditFlagsIssueFieldErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		wantErr string
+	}{
+		{
+			name:    "invalid state",
+			args:    []string{"43", "--state", "done"},
+			wantErr: "invalid state",
+		},
+		{
+			name:    "invalid reason",
+			args:    []string{"43", "--state", "closed", "--reason", "duplicate"},
+			wantErr: "invalid reason",
+		},
+		{
+			name:    "reason without closed state",
+			args:    []string{"43", "--reason", "completed"},
+			wantErr: "--reason requires --state closed",
+		},
+		{
+			name:    "body and body-file",
+			args:    []string{"43", "--body", "x", "--body-file", "body.md"},
+			wantErr: "only one of --body or --body-file",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseEditFlags(tt.args)
+			if err == nil {
+				t.Fatal("expected error")
+			}
+			if !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("error = %q, want it to contain %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestEditRunnerUpdatesIssue(t *testing.T) {
+	before := &api.Issue{
+		Number:    43,
+		Title:     "Old title",
+		Body:      "Old body",
+		State:     "open",
+		Labels:    []api.Label{{Name: "bug"}, {Name: "triage"}},
+		Assignees: []api.User{{Login: "bob"}},
+		Milestone: &api.Milestone{Number: 1, Title: "v1.0"},
+	}
+
+	updateCalls := 0
+	var got api.UpdateIssueOptions
+	client := &mockEditAPIClient{
+		getIssueFunc: func(owner, repo string, number int) (*api.Issue, error) {
+			return before, nil
+		},
+		updateIssueFunc: func(opts api.UpdateIssueOptions) (*api.Issue, error) {
+			updateCalls++
+			got = opts
+			return &api.Issue{
+				Number:      43,
+				Title:       *opts.Title,
+				Body:        "Old body",
+				State:       *opts.State,
+				StateReason: *opts.StateReason,
+				Labels:      []api.Label{{Name: "bug"}, {Name: "ui"}},
+				Assignees:   []api.User{{Login: "alice"}},
+			}, nil
+		},
+	}
+
+	var output bytes.Buffer
+	runner := &EditRunner{
+		Client: client,
+		Owner:  "owner",
+		Repo:   "repo",
+		Out:    &output,
+	}
+
+	err := runner.Run(EditOptions{
+		IssueNumber:     43,
+		Title:           "New title",
+		AddLabels:       []string{"UI", "Bug"},
+		RemoveLabels:    []string{"TRIAGE"},
+		AddAssignees:    []string{"alice"},
+		RemoveAssignees: []string{"bob"},
+		Milestone:       OptionalString{Value: "none", WasSet: true},
+		State:           "closed",
+		Reason:          "not_planned",
+	})
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+
+	if updateCalls != 1 {
+		t.Fatalf("UpdateIssue called %d times, want 1", updateCalls)
+	}
+	if !reflect.DeepEqual(*got.Labels, []string{"bug", "UI"}) {
+		t.Errorf("Labels = %v, want [bug UI]", *got.Labels)
+	}
+	if !reflect.DeepEqual(*got.Assignees, []string{"alice"}) {
+		t.Errorf("Assignees = %v, want [alice]", *got.Assignees)
+	}
+	if got.Milestone == nil || *got.Milestone != 0 {
+		t.Errorf("Milestone = %v, want 0", got.Milestone)
+	}
+	if got.Body != nil {
+		t.Errorf("Body = %q, want nil", *got.Body)
+	}
+
+	want := `Updated issue #43
+  title:     "Old title" -> "New title"
+  labels:    bug, triage -> bug, ui
+  assignees: @bob -> @alice
+  milestone: v1.0 -> (none)
+  state:     open -> closed (not planned)
+`
+	if output.String() != want {
+		t.Errorf("output =\n%s\nwant\n%s", output.String(), want)
+	}
+}
+
+func TestEditRunnerUpdateBodyFromFile(t *testing.T) {
+	var got api.UpdateIssueOptions
+	client := &mockEditAPIClient{
+		updateIssueFunc: func(opts api.UpdateIssueOptions) (*api.Issue, error) {
+			got = opts
+			return &api.Issue{Number: 43, Title: "Issue", State: "open", Body: *opts.Body}, nil
+		},
+	}
+
+	var output bytes.Buffer
+	runner := &EditRunner{
+		Client: client,
+		Owner:  "owner",
+		Repo:   "repo",
+		Out:    &output,
+		Stdin:  strings.NewReader("Body from stdin"),
+	}
+
+	if err := runner.Run(EditOptions{IssueNumber: 43, BodyFile: "-"}); err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	if got.Body == nil || *got.Body != "Body from stdin" {
+		t.Errorf("Body = %v, want %q", got.Body, "Body from stdin")
+	}
+	if got.Title != nil || got.Labels != nil || got.State != nil {
+		t.Errorf("unexpected fields in update: %+v", got)
+	}
+	if !strings.Contains(output.String(), "body:      0 characters -> 15 characters") {
+		t.Errorf("output = %q, want body change", output.String())
+	}
+}
+
+func TestEditRunnerMilestoneByTitle(t *testing.T) {
+	var got api.UpdateIssueOptions
+	client := &mockEditAPIClient{
+		listMilestonesFunc: func(owner, repo string) ([]api.Milestone, error) {
+			return []api.Milestone{{Number: 3, Title: "v2.0", State: "open"}}, nil
+		},
+		updateIssueFunc: func(opts api.UpdateIssueOptions) (*api.Issue, error) {
+			got = opts
+			return &api.Issue{Number: 43, State: "open", Milestone: &api.Milestone{Number: 3, Title: "v2.0"}}, nil
+		},
+	}
+	runner := &EditRunner{Client: client, Owner: "owner", Repo: "repo", Out: &bytes.Buffer{}}
+
+	if err := runner.Run(EditOptions{IssueNumber: 43, Milestone: OptionalString{Value: "V2.0", WasSet: true}}); err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	if got.Milestone == nil || *got.Milestone != 3 {
+		t.Errorf("Milestone = %v, want 3", got.Milestone)
+	}
+}
+
+func TestEditRunnerUnknownMilestone(t *testing.T) {
+	client := &mockEditAPIClient{
+		listMilestonesFunc: func(owner, repo string) ([]api.Milestone, error) {
+			return []api.Milestone{{Number: 3, Title: "v2.0", State: "open"}}, nil
+		},
+		updateIssueFunc: func(opts api.UpdateIssueOptions) (*api.Issue, error) {
+			t.Error("UpdateIssue should not be called")
+			return nil, nil
+		},
+	}
+	runner := &EditRunner{Client: client, Owner: "owner", Repo: "repo", Out: &bytes.Buffer{}}
+
+	err := runner.Run(EditOptions{IssueNumber: 43, Milestone: OptionalString{Value: "v2.1", WasSet: true}})
+	if err == nil || !strings.Contains(err.Error(), `milestone "v2.1" not found in owner/repo; did you mean "v2.0"?`) {
+		t.Errorf("error = %v, want a suggestion", err)
+	}
+}
+
+func TestEditRunnerType(t *testing.T) {
+	orgTypes := func(owner string) ([]api.IssueType, error) {
+		return []api.IssueType{{Name: "Bug"}, {Name: "Task"}}, nil
+	}
+
+	tests := []struct {
+		name     string
+		value    string
+		prompter Prompter
+		wantType string
+		wantOut  string
+		wantErr  string
+	}{
+		{
+			name:     "by name",
+			value:    "task",
+			wantType: "Task",
+			wantOut:  "Updated issue #43\n  type:      Bug -> Task\n",
+		},
+		{
+			name:     "remove",
+			value:    "none",
+			wantType: "",
+			wantOut:  "Updated issue #43\n  type:      Bug -> (none)\n",
+		},
+		{
+			name:  "interactive",
+			value: "",
+			prompter: &mockPrompter{
+				selectFunc: func(prompt, defaultValue string, options []string) (int, error) {
+					if defaultValue != "Bug" {
+						t.Errorf("default = %q, want the current type", defaultValue)
+					}
+					return 2, nil
+				},
+			},
+			wantType: "Task",
+			wantOut:  "Updated issue #43\n  type:      Bug -> Task\n",
+		},
+		{
+			name:    "empty without a prompter",
+			value:   "",
+			wantErr: "--type requires a value when not running interactively",
+		},
+		{
+			name:    "unknown type",
+			value:   "Bgu",
+			wantErr: `issue type "Bgu" not found in owner; did you mean "Bug"?`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got *string
+			client := &mockEditAPIClient{
+				listIssueTypesFunc: orgTypes,
+				getIssueFunc: func(owner, repo string, number int) (*api.Issue, error) {
+					return &api.Issue{Number: 43, State: "open", Type: &api.IssueType{Name: "Bug"}}, nil
+				},
+				updateIssueFunc: func(opts api.UpdateIssueOptions) (*api.Issue, error) {
+					got = opts.Type
+					issue := &api.Issue{Number: 43, State: "open"}
+					if *opts.Type != "" {
+						issue.Type = &api.IssueType{Name: *opts.Type}
+					}
+					return issue, nil
+				},
+			}
+
+			var output bytes.Buffer
+			runner := &EditRunner{Client: client, Owner: "owner", Repo: "repo", Out: &output, Prompter: tt.prompter}
+			err := runner.Run(EditOptions{IssueNumber: 43, Type: OptionalString{Value: tt.value, WasSet: true}})
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Run() error = %v", err)
+			}
+			if got == nil || *got != tt.wantType {
+				t.Errorf("Type = %v, want %q", got, tt.wantType)
+			}
+			if output.String() != tt.wantOut {
+				t.Errorf("output = %q, want %q", output.String(), tt.wantOut)
+			}
+		})
+	}
+}
+
+func TestParseEditFlagsParent(t *testing.T) {
+	opts, err := ParseEditFlags([]string{"43", "--parent", "other/repo#7"})
+	if err != nil {
+		t.Fatalf("ParseEditFlags() error = %v", err)
+	}
+	want := IssueRef{Owner: "other", Repo: "repo", Number: 7}
+	if opts.Parent != want {
+		t.Errorf("Parent = %+v, want %+v", opts.Parent, want)
+	}
+
+	if _, err := ParseEditFlags([]string{"43", "-p", "abc"}); err == nil {
+		t.Error("expected error for invalid parent")
+	}
+}
+
+// parentsClient returns a mock whose GetParentIssue follows the given
+// child -> parent map within owner/repo.
+func parentsClient(parents map[int]int) *mockEditAPIClient {
+	return &mockEditAPIClient{
+		getIssueFunc: func(owner, repo string, number int) (*api.Issue, error) {
+			return &api.Issue{ID: int64(number * 100), Number: number}, nil
+		},
+		getParentIssueFunc: func(owner, repo string, number int) (*api.Issue, error) {
+			parent, ok := parents[number]
+			if !ok {
+				return nil, nil
+			}
+			return &api.Issue{ID: int64(parent * 100), Number: parent, RepositoryURL: "https://api.github.com/repos/owner/repo"}, nil
+		},
+	}
+}
+
+func TestEditRunnerReparent(t *testing.T) {
+	tests := []struct {
+		name       string
+		parents    map[int]int
+		parent     IssueRef
+		wantLinked bool
+		wantOut    string
+		wantErr    string
+	}{
+		{
+			name:       "moves between parents",
+			parents:    map[int]int{43: 41},
+			parent:     IssueRef{Number: 42},
+			wantLinked: true,
+			wantOut:    "Moved issue #43 from #41 to #42\n",
+		},
+		{
+			name:       "issue without a parent",
+			parents:    map[int]int{},
+			parent:     IssueRef{Number: 42},
+			wantLinked: true,
+			wantOut:    "Moved issue #43 under #42 (it had no parent)\n",
+		},
+		{
+			name:       "parent in another repository",
+			parents:    map[int]int{43: 41},
+			parent:     IssueRef{Owner: "org", Repo: "planning", Number: 7},
+			wantLinked: true,
+			wantOut:    "Moved issue #43 from #41 to org/planning#7\n",
+		},
+		{
+			name:    "already under the parent",
+			parents: map[int]int{43: 42},
+			parent:  IssueRef{Number: 42},
+			wantOut: "Issue #43 is already a sub-issue of #42\n",
+		},
+		{
+			name:    "own parent",
+			parents: map[int]int{},
+			parent:  IssueRef{Number: 43},
+			wantErr: "cannot be its own parent",
+		},
+		{
+			name:    "descendant as parent",
+			parents: map[int]int{43: 41, 50: 48, 48: 43},
+			parent:  IssueRef{Number: 50},
+			wantErr: "cannot move #43 under #50: #50 is a descendant of #43 (#43 > #48 > #50)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := parentsClient(tt.parents)
+			var linked *api.LinkSubIssueOptions
+			client.linkSubIssueFunc = func(opts api.LinkSubIssueOptions) error {
+				linked = &opts
+				return nil
+			}
+
+			var output bytes.Buffer
+			runner := &EditRunner{Client: client, Owner: "owner", Repo: "repo", Out: &output}
+
+			err := runner.Run(EditOptions{IssueNumber: 43, Parent: tt.parent})
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("error = %v, want it to contain %q", err, tt.wantErr)
+				}
+				if linked != nil {
+					t.Error("LinkSubIssue should not be called")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Run() error = %v", err)
+			}
+
+			if (linked != nil) != tt.wantLinked {
+				t.Fatalf("linked = %v, want %v", linked != nil, tt.wantLinked)
+			}
+			if linked != nil {
+				wantOwner, wantRepo := "owner", "repo"
+				if tt.parent.Owner != "" {
+					wantOwner, wantRepo = tt.parent.Owner, tt.parent.Repo
+				}
+				want := api.LinkSubIssueOptions{
+					Owner:         wantOwner,
+					Repo:          wantRepo,
+					ParentIssue:   tt.parent.Number,
+					SubIssueID:    4300,
+					ReplaceParent: true,
+				}
+				if *linked != want {
+					t.Errorf("LinkSubIssue(%+v), want %+v", *linked, want)
+				}
+			}
+			if output.String() != tt.wantOut {
+				t.Errorf("output = %q, want %q", output.String(), tt.wantOut)
+			}
+		})
+	}
+}
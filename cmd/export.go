@@ -0,0 +1,133 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	"github.com/cli/go-gh/v2/pkg/jq"
+	"github.com/cli/go-gh/v2/pkg/template"
+
+	"github.com/gwyn/gh-subissue/internal/debug"
+)
+
+// exportFlagArgs lists the export flags that take a value, for commands that
+// reorder positional arguments before parsing.
+var exportFlagArgs = []string{"--json", "-json", "--jq", "-jq", "-q", "--template", "-template"}
+
+// ExportOptions contains the --json, --jq and --template output flags.
+type ExportOptions struct {
+	Fields   []string // fields requested with --json; empty means table output
+	JQ       string
+	Template string
+
+	json OptionalString
+}
+
+// Enabled reports whether machine-readable output was requested.
+func (e ExportOptions) Enabled() bool {
+	return len(e.Fields) > 0
+}
+
+// registerExportFlags adds the export flags to a command's flag set.
+func registerExportFlags(fs *flag.FlagSet, e *ExportOptions) {
+	fs.Var(&e.json, "json", "Output JSON with the specified comma-separated fields")
+	fs.StringVar(&e.JQ, "jq", "", "Filter JSON output using a jq expression")
+	fs.StringVar(&e.JQ, "q", "", "Filter JSON output using a jq expression")
+	fs.StringVar(&e.Template, "template", "", "Format JSON output using a Go template")
+}
+
+// validate checks the parsed export flags against the fields a command supports.
+func (e *ExportOptions) validate(validFields []string) error {
+	if !e.json.WasSet {
+		if e.JQ != "" {
+			return errors.New("cannot use `--jq` without specifying `--json`")
+		}
+		if e.Template != "" {
+			return errors.New("cannot use `--template` without specifying `--json`")
+		}
+		return nil
+	}
+	if e.JQ != "" && e.Template != "" {
+		return errors.New("specify only one of `--jq` or `--template`")
+	}
+
+	var fields []string
+	for _, f := range strings.Split(e.json.Value, ",") {
+		if f = strings.TrimSpace(f); f != "" {
+			fields = append(fields, f)
+		}
+	}
+	if len(fields) == 0 {
+		return fmt.Errorf("specify one or more comma-separated fields for `--json`:\n  %s", strings.Join(validFields, "\n  "))
+	}
+
+	for _, f := range fields {
+		if !slices.Contains(validFields, f) {
+			return fmt.Errorf("unknown JSON field: %q\nAvailable fields:\n  %s", f, strings.Join(validFields, "\n  "))
+		}
+	}
+
+	e.Fields = fields
+	return nil
+}
+
+// exporter is implemented by API types that can be written as JSON output.
+type exporter interface {
+	ExportData(fields []string) map[string]interface{}
+}
+
+// exportList converts a slice of API values into their exported form.
+func exportList[T exporter](items []T, fields []string) []map[string]interface{} {
+	data := make([]map[string]interface{}, len(items))
+	for i, item := range items {
+		data[i] = item.ExportData(fields)
+	}
+	return data
+}
+
+// Write writes data as JSON, filtered through --jq or --template when given.
+func (e ExportOptions) Write(w io.Writer, data interface{}) error {
+	debug.Log("ExportOptions.Write", "fields", e.Fields, "jq", e.JQ, "template", e.Template)
+
+	buf, err := json.Marshal(data)
+	if err != nil {
+		debug.Error("ExportOptions.Write", err, "stage", "marshal")
+		return err
+	}
+
+	switch {
+	case e.JQ != "":
+		if err := jq.Evaluate(bytes.NewReader(buf), w, e.JQ); err != nil {
+			debug.Error("ExportOptions.Write", err, "stage", "jq")
+			return err
+		}
+	case e.Template != "":
+		t := template.New(w, 80, false)
+		if err := t.Parse(e.Template); err != nil {
+			debug.Error("ExportOptions.Write", err, "stage", "template_parse")
+			return err
+		}
+		if err := t.Execute(bytes.NewReader(buf)); err != nil {
+			debug.Error("ExportOptions.Write", err, "stage", "template_execute")
+			return err
+		}
+		return t.Flush()
+	default:
+		var out bytes.Buffer
+		if err := json.Indent(&out, buf, "", "  "); err != nil {
+			return err
+		}
+		out.WriteByte('\n')
+		if _, err := w.Write(out.Bytes()); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,136 @@
+package cmd
+
+import (
+	"bytes"
+	"flag"
+	"strings"
+	"testing"
+
+	"github.com/gwyn/gh-subissue/internal/api"
+)
+
+func TestExportOptionsValidate(t *testing.T) {
+	tests := []struct {
+		name       string
+		args       []string
+		wantFields []string
+		wantErr    string
+	}{
+		{
+			name: "no export flags",
+			args: []string{},
+		},
+		{
+			name:       "json fields",
+			args:       []string{"--json", "number, title"},
+			wantFields: []string{"number", "title"},
+		},
+		{
+			name:       "json with jq",
+			args:       []string{"--json", "number", "--jq", ".[].number"},
+			wantFields: []string{"number"},
+		},
+		{
+			name:    "unknown field lists valid fields",
+			args:    []string{"--json", "number,labels"},
+			wantErr: "unknown JSON field: \"labels\"\nAvailable fields:\n  id\n  number\n  title\n  state\n  type\n  url",
+		},
+		{
+			name:    "empty json lists valid fields",
+			args:    []string{"--json", ""},
+			wantErr: "specify one or more comma-separated fields for `--json`:\n  id\n",
+		},
+		{
+			name:    "jq without json",
+			args:    []string{"-q", ".[]"},
+			wantErr: "cannot use `--jq` without specifying `--json`",
+		},
+		{
+			name:    "template without json",
+			args:    []string{"--template", "{{.}}"},
+			wantErr: "cannot use `--template` without specifying `--json`",
+		},
+		{
+			name:    "jq and template together",
+			args:    []string{"--json", "number", "--jq", ".", "--template", "{{.}}"},
+			wantErr: "specify only one of `--jq` or `--template`",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var e ExportOptions
+			fs := flag.NewFlagSet("test", flag.ContinueOnError)
+			registerExportFlags(fs, &e)
+			if err := fs.Parse(tt.args); err != nil {
+				t.Fatalf("Parse() error = %v", err)
+			}
+
+			err := e.validate(api.IssueFields)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("validate() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("validate() error = %v", err)
+			}
+			if strings.Join(e.Fields, ",") != strings.Join(tt.wantFields, ",") {
+				t.Errorf("Fields = %v, want %v", e.Fields, tt.wantFields)
+			}
+			if e.Enabled() != (len(tt.wantFields) > 0) {
+				t.Errorf("Enabled() = %v", e.Enabled())
+			}
+		})
+	}
+}
+
+func TestExportOptionsWrite(t *testing.T) {
+	issues := []api.Issue{
+		{Number: 43, Title: "First"},
+		{Number: 44, Title: "Second"},
+	}
+
+	tests := []struct {
+		name       string
+		export     ExportOptions
+		wantOutput string
+	}{
+		{
+			name:       "json",
+			export:     ExportOptions{Fields: []string{"number"}},
+			wantOutput: "[\n  {\n    \"number\": 43\n  },\n  {\n    \"number\": 44\n  }\n]\n",
+		},
+		{
+			name:       "jq",
+			export:     ExportOptions{Fields: []string{"number", "title"}, JQ: ".[].title"},
+			wantOutput: "First\nSecond\n",
+		},
+		{
+			name:       "template",
+			export:     ExportOptions{Fields: []string{"number", "title"}, Template: "{{range .}}#{{.number}} {{.title}}\n{{end}}"},
+			wantOutput: "#43 First\n#44 Second\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var output bytes.Buffer
+			if err := tt.export.Write(&output, exportList(issues, tt.export.Fields)); err != nil {
+				t.Fatalf("Write() error = %v", err)
+			}
+			if output.String() != tt.wantOutput {
+				t.Errorf("output = %q, want %q", output.String(), tt.wantOutput)
+			}
+		})
+	}
+}
+
+func TestExportOptionsWriteInvalidJQ(t *testing.T) {
+	export := ExportOptions{Fields: []string{"number"}, JQ: ".[] |"}
+	var output bytes.Buffer
+	if err := export.Write(&output, exportList([]api.Issue{{Number: 1}}, export.Fields)); err == nil {
+		t.Error("Write() expected error for invalid jq expression")
+	}
+}
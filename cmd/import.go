@@ -0,0 +1,310 @@
+package cmd
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/gwyn/gh-subissue/internal/api"
+	"github.com/gwyn/gh-subissue/internal/debug"
+)
+
+// ImportOptions contains the parsed command line options for the import command.
+type ImportOptions struct {
+	File   string
+	Parent int // existing issue to attach the root issues to; overrides the file
+	Repo   string
+}
+
+// ParseImportFlags parses command line flags for the import command.
+func ParseImportFlags(args []string) (*ImportOptions, error) {
+	debug.Log("ParseImportFlags", "args", args)
+
+	opts := &ImportOptions{}
+	fs := flag.NewFlagSet("import", flag.ContinueOnError)
+
+	fs.IntVar(&opts.Parent, "parent", 0, "Existing issue to attach the root issues to")
+	fs.IntVar(&opts.Parent, "p", 0, "Existing issue to attach the root issues to")
+
+	fs.StringVar(&opts.Repo, "repo", "", "Repository (owner/repo)")
+	fs.StringVar(&opts.Repo, "R", "", "Repository (owner/repo)")
+
+	positional, flagArgs := splitArgs(args, "-p", "--parent", "-parent", "-R", "--repo", "-repo")
+	if err := fs.Parse(flagArgs); err != nil {
+		debug.Error("ParseImportFlags", err, "stage", "fs.Parse")
+		return nil, err
+	}
+
+	if len(positional) != 1 {
+		return nil, errors.New("exactly one plan file is required\nExample: gh subissue import plan.yaml")
+	}
+	opts.File = positional[0]
+
+	debug.Log("ParseImportFlags", "parsed", fmt.Sprintf("%+v", opts))
+	return opts, nil
+}
+
+// planNode is one issue in an import plan, with the issues to create below it.
+type planNode struct {
+	Title     string      `yaml:"title" json:"title"`
+	Body      string      `yaml:"body" json:"body"`
+	Labels    []string    `yaml:"labels" json:"labels"`
+	Assignees []string    `yaml:"assignees" json:"assignees"`
+	Milestone int         `yaml:"milestone" json:"milestone"`
+	Children  []*planNode `yaml:"children" json:"children"`
+}
+
+// plan is the contents of an import file.
+type plan struct {
+	Parent int         `yaml:"parent" json:"parent"` // existing issue to attach the roots to
+	Issues []*planNode `yaml:"issues" json:"issues"`
+}
+
+// parsePlan reads an import plan. The format is chosen by the file extension:
+// .yaml/.yml, .json, or .md/.markdown for a nested bullet outline.
+func parsePlan(name string, r io.Reader) (*plan, error) {
+	debug.Log("parsePlan", "name", name)
+
+	var p plan
+	switch ext := strings.ToLower(filepath.Ext(name)); ext {
+	case ".yaml", ".yml":
+		dec := yaml.NewDecoder(r)
+		dec.KnownFields(true)
+		if err := dec.Decode(&p); err != nil && !errors.Is(err, io.EOF) {
+			return nil, fmt.Errorf("failed to parse %s: %w", name, err)
+		}
+	case ".json":
+		dec := json.NewDecoder(r)
+		dec.DisallowUnknownFields()
+		if err := dec.Decode(&p); err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", name, err)
+		}
+	case ".md", ".markdown":
+		issues, err := parseOutline(r)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", name, err)
+		}
+		p.Issues = issues
+	default:
+		return nil, fmt.Errorf("unsupported plan format %q (expected .yaml, .yml, .json, .md or .markdown)", ext)
+	}
+
+	if len(p.Issues) == 0 {
+		return nil, fmt.Errorf("%s does not contain any issues", name)
+	}
+	if err := validatePlan(p.Issues, ""); err != nil {
+		return nil, err
+	}
+
+	debug.Log("parsePlan", "roots", len(p.Issues), "parent", p.Parent)
+	return &p, nil
+}
+
+// validatePlan checks that every node has a title.
+func validatePlan(nodes []*planNode, prefix string) error {
+	for i, node := range nodes {
+		path := nodePath(prefix, i)
+		if node == nil || strings.TrimSpace(node.Title) == "" {
+			return fmt.Errorf("issue %s: title is required", path)
+		}
+		if err := validatePlan(node.Children, path); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// bulletPattern matches a Markdown list item, capturing its indentation and text.
+var bulletPattern = regexp.MustCompile(`^(\s*)[-*+]\s+(.*)$`)
+
+// checkboxPattern matches a task list marker at the start of a list item.
+var checkboxPattern = regexp.MustCompile(`^\[[ xX]\]\s+`)
+
+// parseOutline turns a nested Markdown bullet list into plan nodes. Each
+// bullet becomes an issue titled with its text; deeper bullets become its
+// children. Indented text under a bullet becomes the issue body. Lines
+// before the first bullet, such as a heading, are ignored.
+func parseOutline(r io.Reader) ([]*planNode, error) {
+	type level struct {
+		indent int
+		node   *planNode
+	}
+
+	var roots []*planNode
+	var stack []level
+
+	scanner := bufio.NewScanner(r)
+	for lineNo := 1; scanner.Scan(); lineNo++ {
+		line := strings.ReplaceAll(scanner.Text(), "\t", "    ")
+
+		m := bulletPattern.FindStringSubmatch(line)
+		if m == nil {
+			text := strings.TrimSpace(line)
+			if text == "" || len(stack) == 0 {
+				continue
+			}
+			node := stack[len(stack)-1].node
+			if node.Body != "" {
+				node.Body += "\n"
+			}
+			node.Body += text
+			continue
+		}
+
+		indent := len(m[1])
+		node := &planNode{Title: strings.TrimSpace(checkboxPattern.ReplaceAllString(m[2], ""))}
+		if node.Title == "" {
+			return nil, fmt.Errorf("line %d: empty list item", lineNo)
+		}
+
+		for len(stack) > 0 && stack[len(stack)-1].indent >= indent {
+			stack = stack[:len(stack)-1]
+		}
+		if len(stack) == 0 {
+			roots = append(roots, node)
+		} else {
+			parent := stack[len(stack)-1].node
+			parent.Children = append(parent.Children, node)
+		}
+		stack = append(stack, level{indent: indent, node: node})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return roots, nil
+}
+
+// nodePath numbers a node by its position in the plan, e.g. "1.2.1".
+func nodePath(prefix string, index int) string {
+	if prefix == "" {
+		return fmt.Sprintf("%d", index+1)
+	}
+	return fmt.Sprintf("%s.%d", prefix, index+1)
+}
+
+// ImportAPIClient defines the interface for import operations.
+type ImportAPIClient interface {
+	CreateIssue(opts api.CreateIssueOptions) (*api.IssueResult, error)
+	LinkSubIssue(opts api.LinkSubIssueOptions) error
+}
+
+// ImportRunner executes the import subcommand.
+type ImportRunner struct {
+	Client ImportAPIClient
+	Owner  string
+	Repo   string
+	Out    io.Writer
+}
+
+// Run executes the import command.
+func (r *ImportRunner) Run(opts ImportOptions) error {
+	debug.Log("ImportRunner.Run", "file", opts.File, "parent", opts.Parent)
+
+	f, err := os.Open(opts.File)
+	if err != nil {
+		debug.Error("ImportRunner.Run", err, "stage", "open_file")
+		return fmt.Errorf("failed to read plan: %w", err)
+	}
+	defer f.Close()
+
+	p, err := parsePlan(opts.File, f)
+	if err != nil {
+		debug.Error("ImportRunner.Run", err, "stage", "parse_plan")
+		return err
+	}
+
+	parent := p.Parent
+	if opts.Parent != 0 {
+		parent = opts.Parent
+	}
+
+	imp := &importer{runner: r}
+	imp.createAll(p.Issues, "", parent)
+
+	if imp.failed > 0 {
+		err := fmt.Errorf("failed to import %d of %d issues", imp.failed, imp.total)
+		debug.Error("ImportRunner.Run", err)
+		return err
+	}
+
+	debug.Log("ImportRunner.Run", "result", "success", "created", imp.total)
+	return nil
+}
+
+// importer creates plan nodes depth-first and keeps count of the outcome.
+type importer struct {
+	runner *ImportRunner
+	total  int
+	failed int
+}
+
+// createAll creates nodes and their children, linking each node under
+// parent (an issue number; 0 leaves the node unlinked). A node that cannot
+// be created is reported together with its whole subtree.
+func (imp *importer) createAll(nodes []*planNode, prefix string, parent int) {
+	r := imp.runner
+	for i, node := range nodes {
+		path := nodePath(prefix, i)
+		imp.total++
+
+		result, err := r.Client.CreateIssue(api.CreateIssueOptions{
+			Owner:     r.Owner,
+			Repo:      r.Repo,
+			Title:     node.Title,
+			Body:      node.Body,
+			Labels:    node.Labels,
+			Assignees: node.Assignees,
+			Milestone: node.Milestone,
+		})
+		if err != nil {
+			debug.Error("importer.createAll", err, "stage", "create_issue", "node", path)
+			skipped := countNodes(node.Children)
+			fmt.Fprintf(r.Out, "%s\t%s\tfailed: %v", path, node.Title, err)
+			if skipped > 0 {
+				fmt.Fprintf(r.Out, " (skipped %d sub-issues)", skipped)
+			}
+			fmt.Fprintln(r.Out)
+			imp.failed += 1 + skipped
+			imp.total += skipped
+			continue
+		}
+
+		if parent != 0 {
+			err := r.Client.LinkSubIssue(api.LinkSubIssueOptions{
+				Owner:       r.Owner,
+				Repo:        r.Repo,
+				ParentIssue: parent,
+				SubIssueID:  result.ID,
+			})
+			if err != nil {
+				debug.Error("importer.createAll", err, "stage", "link_sub_issue", "node", path)
+				fmt.Fprintf(r.Out, "%s\t%s\t%s (failed to link to #%d: %v)\n", path, node.Title, result.URL, parent, err)
+				imp.failed++
+				imp.createAll(node.Children, path, result.Number)
+				continue
+			}
+		}
+
+		fmt.Fprintf(r.Out, "%s\t%s\t%s\n", path, node.Title, result.URL)
+		imp.createAll(node.Children, path, result.Number)
+	}
+}
+
+// countNodes returns the number of nodes in a subtree.
+func countNodes(nodes []*planNode) int {
+	n := len(nodes)
+	for _, node := range nodes {
+		n += countNodes(node.Children)
+	}
+	return n
+}
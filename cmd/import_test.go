@@ -0,0 +1,323 @@
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/gwyn/gh-subissue/internal/api"
+)
+
+func TestParseImportFlags(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		want    ImportOptions
+		wantErr bool
+	}{
+		{
+			name: "file only",
+			args: []string{"plan.yaml"},
+			want: ImportOptions{File: "plan.yaml"},
+		},
+		{
+			name: "flags after file",
+			args: []string{"plan.md", "--parent", "42", "-R", "owner/repo"},
+			want: ImportOptions{File: "plan.md", Parent: 42, Repo: "owner/repo"},
+		},
+		{
+			name:    "no file",
+			args:    []string{},
+			wantErr: true,
+		},
+		{
+			name:    "two files",
+			args:    []string{"a.yaml", "b.yaml"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts, err := ParseImportFlags(tt.args)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ParseImportFlags() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			if *opts != tt.want {
+				t.Errorf("ParseImportFlags() = %+v, want %+v", *opts, tt.want)
+			}
+		})
+	}
+}
+
+// outlineString renders plan nodes as "title[children]" for comparisons.
+func outlineString(nodes []*planNode) string {
+	parts := make([]string, len(nodes))
+	for i, n := range nodes {
+		parts[i] = n.Title
+		if len(n.Children) > 0 {
+			parts[i] += "[" + outlineString(n.Children) + "]"
+		}
+	}
+	return strings.Join(parts, ",")
+}
+
+func TestParsePlan(t *testing.T) {
+	tests := []struct {
+		name       string
+		file       string
+		content    string
+		wantParent int
+		want       string
+		wantErr    string
+	}{
+		{
+			name: "yaml",
+			file: "plan.yaml",
+			content: `parent: 42
+issues:
+  - title: Epic
+    labels: [feature]
+    milestone: 3
+    children:
+      - title: Backend
+        assignees: [octocat]
+        children:
+          - title: API
+      - title: Frontend
+`,
+			wantParent: 42,
+			want:       "Epic[Backend[API],Frontend]",
+		},
+		{
+			name:    "json",
+			file:    "plan.json",
+			content: `{"issues": [{"title": "Epic", "children": [{"title": "Task", "body": "Details"}]}]}`,
+			want:    "Epic[Task]",
+		},
+		{
+			name: "markdown outline",
+			file: "plan.md",
+			content: `# Launch plan
+
+- Epic
+  Body of the epic
+  - [ ] Backend
+    - API
+  - Frontend
+- Second root
+`,
+			want: "Epic[Backend[API],Frontend],Second root",
+		},
+		{
+			name:    "missing title",
+			file:    "plan.yaml",
+			content: "issues:\n  - title: Epic\n    children:\n      - body: no title\n",
+			wantErr: "issue 1.1: title is required",
+		},
+		{
+			name:    "unknown field",
+			file:    "plan.yaml",
+			content: "issues:\n  - title: Epic\n    label: bug\n",
+			wantErr: "field label not found",
+		},
+		{
+			name:    "empty plan",
+			file:    "plan.md",
+			content: "# Nothing here\n",
+			wantErr: "does not contain any issues",
+		},
+		{
+			name:    "unsupported extension",
+			file:    "plan.txt",
+			content: "- Epic\n",
+			wantErr: "unsupported plan format",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, err := parsePlan(tt.file, strings.NewReader(tt.content))
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("parsePlan() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parsePlan() error = %v", err)
+			}
+			if p.Parent != tt.wantParent {
+				t.Errorf("Parent = %d, want %d", p.Parent, tt.wantParent)
+			}
+			if got := outlineString(p.Issues); got != tt.want {
+				t.Errorf("outline = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParsePlanMarkdownBody(t *testing.T) {
+	p, err := parsePlan("plan.md", strings.NewReader("- Epic\n  First line\n  Second line\n  - Task\n"))
+	if err != nil {
+		t.Fatalf("parsePlan() error = %v", err)
+	}
+	if p.Issues[0].Body != "First line\nSecond line" {
+		t.Errorf("Body = %q", p.Issues[0].Body)
+	}
+}
+
+// mockImportAPIClient implements the ImportAPIClient interface for testing.
+// Created issues are numbered from 100 in creation order.
+type mockImportAPIClient struct {
+	created  []api.CreateIssueOptions
+	links    []string // "parent<-child" pairs
+	failOn   string   // title whose creation fails
+	linkFail int      // parent number whose links fail
+}
+
+func (m *mockImportAPIClient) CreateIssue(opts api.CreateIssueOptions) (*api.IssueResult, error) {
+	if opts.Title == m.failOn {
+		return nil, errors.New("validation failed")
+	}
+	m.created = append(m.created, opts)
+	number := 99 + len(m.created)
+	return &api.IssueResult{
+		ID:     int64(number * 1000),
+		Number: number,
+		URL:    fmt.Sprintf("https://github.com/owner/repo/issues/%d", number),
+	}, nil
+}
+
+func (m *mockImportAPIClient) LinkSubIssue(opts api.LinkSubIssueOptions) error {
+	if opts.ParentIssue == m.linkFail {
+		return errors.New("permission denied")
+	}
+	m.links = append(m.links, fmt.Sprintf("%d<-%d", opts.ParentIssue, opts.SubIssueID/1000))
+	return nil
+}
+
+// Compile-time check
+var _ ImportAPIClient = (*mockImportAPIClient)(nil)
+
+func writePlan(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestImportRunnerRun(t *testing.T) {
+	path := writePlan(t, "plan.yaml", `parent: 42
+issues:
+  - title: Epic
+    body: The plan
+    labels: [feature]
+    assignees: [octocat]
+    milestone: 3
+    children:
+      - title: Backend
+        children:
+          - title: API
+      - title: Frontend
+`)
+
+	client := &mockImportAPIClient{}
+	var output bytes.Buffer
+	runner := &ImportRunner{Client: client, Owner: "owner", Repo: "repo", Out: &output}
+
+	if err := runner.Run(ImportOptions{File: path}); err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+
+	wantOutput := "1\tEpic\thttps://github.com/owner/repo/issues/100\n" +
+		"1.1\tBackend\thttps://github.com/owner/repo/issues/101\n" +
+		"1.1.1\tAPI\thttps://github.com/owner/repo/issues/102\n" +
+		"1.2\tFrontend\thttps://github.com/owner/repo/issues/103\n"
+	if output.String() != wantOutput {
+		t.Errorf("output = %q, want %q", output.String(), wantOutput)
+	}
+
+	wantLinks := "42<-100,100<-101,101<-102,100<-103"
+	if got := strings.Join(client.links, ","); got != wantLinks {
+		t.Errorf("links = %s, want %s", got, wantLinks)
+	}
+
+	epic := client.created[0]
+	if epic.Body != "The plan" || epic.Milestone != 3 || epic.Labels[0] != "feature" || epic.Assignees[0] != "octocat" {
+		t.Errorf("epic created with %+v", epic)
+	}
+}
+
+func TestImportRunnerParentFlagOverridesFile(t *testing.T) {
+	path := writePlan(t, "plan.json", `{"parent": 42, "issues": [{"title": "Epic"}]}`)
+
+	client := &mockImportAPIClient{}
+	runner := &ImportRunner{Client: client, Owner: "owner", Repo: "repo", Out: &bytes.Buffer{}}
+
+	if err := runner.Run(ImportOptions{File: path, Parent: 7}); err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	if got := strings.Join(client.links, ","); got != "7<-100" {
+		t.Errorf("links = %s, want 7<-100", got)
+	}
+}
+
+func TestImportRunnerRootsWithoutParentAreNotLinked(t *testing.T) {
+	path := writePlan(t, "plan.md", "- Epic\n  - Task\n")
+
+	client := &mockImportAPIClient{}
+	runner := &ImportRunner{Client: client, Owner: "owner", Repo: "repo", Out: &bytes.Buffer{}}
+
+	if err := runner.Run(ImportOptions{File: path}); err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	if got := strings.Join(client.links, ","); got != "100<-101" {
+		t.Errorf("links = %s, want 100<-101", got)
+	}
+}
+
+func TestImportRunnerCreateFailureSkipsSubtree(t *testing.T) {
+	path := writePlan(t, "plan.md", "- Epic\n  - Backend\n    - API\n  - Frontend\n")
+
+	client := &mockImportAPIClient{failOn: "Backend"}
+	var output bytes.Buffer
+	runner := &ImportRunner{Client: client, Owner: "owner", Repo: "repo", Out: &output}
+
+	err := runner.Run(ImportOptions{File: path})
+	if err == nil || err.Error() != "failed to import 2 of 4 issues" {
+		t.Fatalf("Run() error = %v", err)
+	}
+	if !strings.Contains(output.String(), "1.1\tBackend\tfailed: validation failed (skipped 1 sub-issues)") {
+		t.Errorf("output should report the failed subtree, got %q", output.String())
+	}
+	if !strings.Contains(output.String(), "1.2\tFrontend\thttps://github.com/owner/repo/issues/101") {
+		t.Errorf("siblings should still be created, got %q", output.String())
+	}
+}
+
+func TestImportRunnerLinkFailure(t *testing.T) {
+	path := writePlan(t, "plan.md", "- Epic\n")
+
+	client := &mockImportAPIClient{linkFail: 42}
+	var output bytes.Buffer
+	runner := &ImportRunner{Client: client, Owner: "owner", Repo: "repo", Out: &output}
+
+	err := runner.Run(ImportOptions{File: path, Parent: 42})
+	if err == nil {
+		t.Fatal("expected error when linking fails")
+	}
+	if !strings.Contains(output.String(), "https://github.com/owner/repo/issues/100 (failed to link to #42: permission denied)") {
+		t.Errorf("output = %q", output.String())
+	}
+}
@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"errors"
 	"flag"
 	"fmt"
 	"io"
@@ -13,7 +14,9 @@ import (
 type ListOptions struct {
 	Parent   int
 	Repo     string
+	Limit    int // maximum sub-issues to show; 0 shows all
 	NoHeader bool
+	Export   ExportOptions
 }
 
 // ParseListFlags parses command line flags for the list command.
@@ -29,13 +32,27 @@ func ParseListFlags(args []string) (*ListOptions, error) {
 	fs.StringVar(&opts.Repo, "repo", "", "Repository (owner/repo)")
 	fs.StringVar(&opts.Repo, "R", "", "Repository (owner/repo)")
 
+	fs.IntVar(&opts.Limit, "limit", 0, "Maximum number of sub-issues to list (0 for all)")
+	fs.IntVar(&opts.Limit, "L", 0, "Maximum number of sub-issues to list (0 for all)")
+
 	fs.BoolVar(&opts.NoHeader, "no-header", false, "Omit table header from output")
 
+	registerExportFlags(fs, &opts.Export)
+
 	if err := fs.Parse(args); err != nil {
 		debug.Error("ParseListFlags", err, "stage", "fs.Parse")
 		return nil, err
 	}
 
+	if opts.Limit < 0 {
+		return nil, errors.New("--limit cannot be negative")
+	}
+
+	if err := opts.Export.validate(api.IssueFields); err != nil {
+		debug.Error("ParseListFlags", err, "stage", "validate_export")
+		return nil, err
+	}
+
 	debug.Log("ParseListFlags", "parsed", fmt.Sprintf("%+v", opts))
 	return opts, nil
 }
@@ -57,7 +74,7 @@ type ListRunner struct {
 
 // Run executes the list command.
 func (r *ListRunner) Run(opts ListOptions) error {
-	debug.Log("ListRunner.Run", "parent", opts.Parent)
+	debug.Log("ListRunner.Run", "parent", opts.Parent, "limit", opts.Limit)
 
 	parent := opts.Parent
 
@@ -68,10 +85,10 @@ func (r *ListRunner) Run(opts ListOptions) error {
 		}
 
 		issues, err := r.Client.ListIssues(api.ListIssuesOptions{
-			Owner:   r.Owner,
-			Repo:    r.Repo,
-			State:   "open",
-			PerPage: 30,
+			Owner: r.Owner,
+			Repo:  r.Repo,
+			State: "open",
+			Limit: pickerIssueLimit,
 		})
 		if err != nil {
 			debug.Error("ListRunner.Run", err, "stage", "list_issues")
@@ -91,22 +108,27 @@ func (r *ListRunner) Run(opts ListOptions) error {
 		Owner:       r.Owner,
 		Repo:        r.Repo,
 		ParentIssue: parent,
+		Limit:       opts.Limit,
 	})
 	if err != nil {
 		debug.Error("ListRunner.Run", err, "stage", "list_sub_issues")
 		return err
 	}
 
+	if opts.Export.Enabled() {
+		return opts.Export.Write(r.Out, exportList(subIssues, opts.Export.Fields))
+	}
+
 	if len(subIssues) == 0 {
 		fmt.Fprintf(r.Out, "No sub-issues found for issue #%d\n", parent)
 		return nil
 	}
 
 	if !opts.NoHeader {
-		fmt.Fprintf(r.Out, "NUMBER\tTITLE\n")
+		fmt.Fprintf(r.Out, "NUMBER\tTYPE\tTITLE\n")
 	}
 	for _, issue := range subIssues {
-		fmt.Fprintf(r.Out, "#%d\t%s\n", issue.Number, issue.Title)
+		fmt.Fprintf(r.Out, "#%d\t%s\t%s\n", issue.Number, issue.TypeName(), issue.Title)
 	}
 
 	return nil
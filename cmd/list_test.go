@@ -2,6 +2,10 @@ package cmd
 
 import (
 	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
 	"github.com/gwyn/gh-subissue/internal/api"
@@ -40,6 +44,28 @@ func TestParseListFlags(t *testing.T) {
 			wantParent: 0,
 			wantErr:    false,
 		},
+		{
+			name:       "with json flag",
+			args:       []string{"-p", "42", "--json", "number,title"},
+			wantParent: 42,
+			wantErr:    false,
+		},
+		{
+			name:       "with limit flag",
+			args:       []string{"-p", "42", "-L", "5"},
+			wantParent: 42,
+			wantErr:    false,
+		},
+		{
+			name:    "with negative limit",
+			args:    []string{"-p", "42", "--limit", "-1"},
+			wantErr: true,
+		},
+		{
+			name:    "with unknown json field",
+			args:    []string{"-p", "42", "--json", "assignees"},
+			wantErr: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -49,6 +75,9 @@ func TestParseListFlags(t *testing.T) {
 				t.Errorf("ParseListFlags() error = %v, wantErr %v", err, tt.wantErr)
 				return
 			}
+			if tt.wantErr {
+				return
+			}
 			if opts.Parent != tt.wantParent {
 				t.Errorf("Parent = %d, want %d", opts.Parent, tt.wantParent)
 			}
@@ -94,10 +123,10 @@ func TestListRunnerRun(t *testing.T) {
 			name: "lists sub-issues",
 			opts: ListOptions{Parent: 42},
 			subIssues: []api.Issue{
-				{Number: 43, Title: "Sub-issue 1", URL: "https://github.com/owner/repo/issues/43"},
+				{Number: 43, Title: "Sub-issue 1", URL: "https://github.com/owner/repo/issues/43", Type: &api.IssueType{Name: "Bug"}},
 				{Number: 44, Title: "Sub-issue 2", URL: "https://github.com/owner/repo/issues/44"},
 			},
-			wantOutput: "NUMBER\tTITLE\n#43\tSub-issue 1\n#44\tSub-issue 2\n",
+			wantOutput: "NUMBER\tTYPE\tTITLE\n#43\tBug\tSub-issue 1\n#44\t\tSub-issue 2\n",
 			wantErr:    false,
 		},
 		{
@@ -106,7 +135,7 @@ func TestListRunnerRun(t *testing.T) {
 			subIssues: []api.Issue{
 				{Number: 43, Title: "Sub-issue 1", URL: "https://github.com/owner/repo/issues/43"},
 			},
-			wantOutput: "#43\tSub-issue 1\n",
+			wantOutput: "#43\t\tSub-issue 1\n",
 			wantErr:    false,
 		},
 		{
@@ -116,6 +145,22 @@ func TestListRunnerRun(t *testing.T) {
 			wantOutput: "No sub-issues found for issue #42\n",
 			wantErr:    false,
 		},
+		{
+			name: "json output",
+			opts: ListOptions{Parent: 42, Export: ExportOptions{Fields: []string{"number", "state"}}},
+			subIssues: []api.Issue{
+				{Number: 43, Title: "Sub-issue 1", State: "open"},
+			},
+			wantOutput: "[\n  {\n    \"number\": 43,\n    \"state\": \"open\"\n  }\n]\n",
+			wantErr:    false,
+		},
+		{
+			name:       "json output for empty list",
+			opts:       ListOptions{Parent: 42, Export: ExportOptions{Fields: []string{"number"}}},
+			subIssues:  []api.Issue{},
+			wantOutput: "[]\n",
+			wantErr:    false,
+		},
 	}
 
 	for _, tt := range tests {
@@ -147,6 +192,24 @@ func TestListRunnerRun(t *testing.T) {
 	}
 }
 
+func TestListRunnerPassesLimit(t *testing.T) {
+	client := &mockListAPIClient{
+		listSubIssuesFunc: func(opts api.ListSubIssuesOptions) ([]api.Issue, error) {
+			if opts.Limit != 5 {
+				t.Errorf("expected limit 5, got %d", opts.Limit)
+			}
+			return []api.Issue{{Number: 43, Title: "Sub-issue 1"}}, nil
+		},
+	}
+
+	var output bytes.Buffer
+	runner := &ListRunner{Client: client, Owner: "owner", Repo: "repo", Out: &output}
+
+	if err := runner.Run(ListOptions{Parent: 42, Limit: 5}); err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+}
+
 func TestListRunnerInteractiveParentSelection(t *testing.T) {
 	client := &mockListAPIClient{
 		listIssuesFunc: func(opts api.ListIssuesOptions) ([]api.Issue, error) {
@@ -185,3 +248,51 @@ func TestListRunnerInteractiveParentSelection(t *testing.T) {
 		t.Errorf("Run() error = %v", err)
 	}
 }
+
+func TestListRunnerPickerMakesOneListRequest(t *testing.T) {
+	issueRequests := 0
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/repos/owner/repo/issues":
+			issueRequests++
+			if got := r.URL.Query().Get("per_page"); got != "30" {
+				t.Errorf("per_page = %s, want 30", got)
+			}
+			// Offer more pages; the picker must not follow them
+			if issueRequests < 3 {
+				w.Header().Set("Link", fmt.Sprintf(`<%s/repos/owner/repo/issues?state=open&page=%d>; rel="next"`, server.URL, issueRequests+1))
+			}
+			issues := make([]map[string]interface{}, 30)
+			for i := range issues {
+				issues[i] = map[string]interface{}{"id": i + 1, "number": i + 1, "title": "Issue"}
+			}
+			json.NewEncoder(w).Encode(issues)
+		case "/repos/owner/repo/issues/1/sub_issues":
+			json.NewEncoder(w).Encode([]map[string]interface{}{})
+		default:
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	var offered int
+	prompter := &mockPrompter{
+		selectFunc: func(prompt, defaultValue string, options []string) (int, error) {
+			offered = len(options)
+			return 0, nil
+		},
+	}
+
+	client := &api.Client{HTTPClient: server.Client(), BaseURL: server.URL}
+	runner := &ListRunner{Client: client, Owner: "owner", Repo: "repo", Out: &bytes.Buffer{}, Prompter: prompter}
+	if err := runner.Run(ListOptions{}); err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	if issueRequests != 1 {
+		t.Errorf("listed issues with %d requests, want 1", issueRequests)
+	}
+	if offered != 30 {
+		t.Errorf("picker offered %d issues, want 30", offered)
+	}
+}
@@ -0,0 +1,431 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/gwyn/gh-subissue/internal/api"
+	"github.com/gwyn/gh-subissue/internal/debug"
+)
+
+// defaultLabelColor is GitHub's color for labels created without one.
+const defaultLabelColor = "ededed"
+
+var hexColorRe = regexp.MustCompile(`^[0-9a-fA-F]{6}$`)
+
+// labelCreation is the --create-missing-labels[=COLOR] flag. It is a boolean
+// flag that optionally carries the color for the new labels.
+type labelCreation struct {
+	Enabled bool
+	Color   string // hex color without "#"; empty means defaultLabelColor
+}
+
+func (l *labelCreation) String() string {
+	if !l.Enabled {
+		return ""
+	}
+	return l.color()
+}
+
+func (l *labelCreation) Set(value string) error {
+	if b, err := strconv.ParseBool(value); err == nil {
+		l.Enabled, l.Color = b, ""
+		return nil
+	}
+	color := strings.TrimPrefix(value, "#")
+	if !hexColorRe.MatchString(color) {
+		return fmt.Errorf("invalid label color %q (expected a hex color such as d73a4a)", value)
+	}
+	l.Enabled, l.Color = true, strings.ToLower(color)
+	return nil
+}
+
+// IsBoolFlag lets the flag be given without a value.
+func (l *labelCreation) IsBoolFlag() bool {
+	return true
+}
+
+func (l *labelCreation) color() string {
+	if l.Color == "" {
+		return defaultLabelColor
+	}
+	return l.Color
+}
+
+// LabelClient defines the API operations for checking and creating labels.
+type LabelClient interface {
+	ListLabels(owner, repo string) ([]api.Label, error)
+	CreateLabel(owner, repo, name, color string) (*api.Label, error)
+}
+
+// MilestoneLister defines the API operation for finding milestones by title.
+type MilestoneLister interface {
+	ListMilestones(owner, repo string) ([]api.Milestone, error)
+}
+
+// IssueTypeLister defines the API operation for finding issue types by name.
+type IssueTypeLister interface {
+	ListIssueTypes(owner string) ([]api.IssueType, error)
+}
+
+// AssigneeClient defines the API operations for resolving special assignees.
+type AssigneeClient interface {
+	GetAuthenticatedUser() (*api.User, error)
+	GetIssue(owner, repo string, number int) (*api.Issue, error)
+}
+
+// MetadataClient defines the API operations for the metadata pickers.
+type MetadataClient interface {
+	ListLabels(owner, repo string) ([]api.Label, error)
+	ListAssignees(owner, repo string) ([]api.User, error)
+	ListMilestones(owner, repo string) ([]api.Milestone, error)
+}
+
+// issueMetadata is the labels, assignees and milestone of a new issue.
+type issueMetadata struct {
+	Labels    []string
+	Assignees []string
+	Milestone int
+}
+
+// checkLabels checks names against the repository's labels, ignoring case.
+// Unknown labels are created when create is enabled, and otherwise reported
+// with the closest existing label.
+func checkLabels(client LabelClient, out io.Writer, owner, repo string, names []string, create labelCreation) error {
+	debug.Log("checkLabels", "owner", owner, "repo", repo, "labels", names, "create_missing", create.Enabled)
+
+	existing, err := client.ListLabels(owner, repo)
+	if err != nil {
+		debug.Error("checkLabels", err, "stage", "list_labels")
+		return fmt.Errorf("failed to list labels: %w", err)
+	}
+	existingNames := make([]string, len(existing))
+	for i, l := range existing {
+		existingNames[i] = l.Name
+	}
+
+	var missing []string
+	for _, name := range names {
+		if !containsFold(existingNames, name) {
+			missing = append(missing, name)
+		}
+	}
+
+	if len(missing) > 0 && !create.Enabled {
+		var b strings.Builder
+		for _, name := range missing {
+			fmt.Fprintf(&b, "label %q not found in %s/%s", name, owner, repo)
+			if s := suggest(name, existingNames); s != "" {
+				fmt.Fprintf(&b, "; did you mean %q?", s)
+			}
+			b.WriteString("\n")
+		}
+		b.WriteString("Use --create-missing-labels to create them")
+		debug.Log("checkLabels", "result", "missing", "labels", missing)
+		return errors.New(b.String())
+	}
+
+	for _, name := range missing {
+		label, err := client.CreateLabel(owner, repo, name, create.color())
+		if err != nil {
+			debug.Error("checkLabels", err, "stage", "create_label", "label", name)
+			return fmt.Errorf("failed to create label %q: %w", name, err)
+		}
+		fmt.Fprintf(out, "Created label %q\n", label.Name)
+	}
+
+	debug.Log("checkLabels", "result", "success", "created", len(missing))
+	return nil
+}
+
+// resolveMilestone looks a milestone up by title, ignoring case.
+func resolveMilestone(client MilestoneLister, owner, repo, title string) (int, error) {
+	debug.Log("resolveMilestone", "owner", owner, "repo", repo, "title", title)
+
+	milestones, err := client.ListMilestones(owner, repo)
+	if err != nil {
+		debug.Error("resolveMilestone", err, "stage", "list_milestones")
+		return 0, fmt.Errorf("failed to list milestones: %w", err)
+	}
+
+	var titles, open []string
+	for _, m := range milestones {
+		if strings.EqualFold(m.Title, title) {
+			debug.Log("resolveMilestone", "result", "found", "number", m.Number)
+			return m.Number, nil
+		}
+		titles = append(titles, m.Title)
+		if m.State == "open" {
+			open = append(open, fmt.Sprintf("%q", m.Title))
+		}
+	}
+
+	msg := fmt.Sprintf("milestone %q not found in %s/%s", title, owner, repo)
+	if s := suggest(title, titles); s != "" {
+		msg += fmt.Sprintf("; did you mean %q?", s)
+	}
+	if len(open) > 0 {
+		msg += "\nOpen milestones: " + strings.Join(open, " ")
+	}
+	return 0, errors.New(msg)
+}
+
+// resolveIssueType looks an issue type of the owner up by name, ignoring
+// case, and returns its name as GitHub spells it.
+func resolveIssueType(client IssueTypeLister, owner, name string) (string, error) {
+	debug.Log("resolveIssueType", "owner", owner, "name", name)
+
+	types, err := client.ListIssueTypes(owner)
+	if err != nil {
+		debug.Error("resolveIssueType", err, "stage", "list_issue_types")
+		return "", fmt.Errorf("failed to list issue types: %w", err)
+	}
+	if len(types) == 0 {
+		return "", fmt.Errorf("%s has no issue types; issue types are only available for organizations", owner)
+	}
+
+	names := make([]string, len(types))
+	for i, t := range types {
+		if strings.EqualFold(t.Name, name) {
+			debug.Log("resolveIssueType", "result", "found", "type", t.Name)
+			return t.Name, nil
+		}
+		names[i] = t.Name
+	}
+
+	msg := fmt.Sprintf("issue type %q not found in %s", name, owner)
+	if s := suggest(name, names); s != "" {
+		msg += fmt.Sprintf("; did you mean %q?", s)
+	}
+	quoted := make([]string, len(names))
+	for i, n := range names {
+		quoted[i] = fmt.Sprintf("%q", n)
+	}
+	msg += "\nAvailable types: " + strings.Join(quoted, " ")
+	return "", errors.New(msg)
+}
+
+// selectIssueType prompts for one of the owner's issue types, with current
+// preselected. It returns "" when "(none)" is chosen, and skips the prompt
+// when the owner has no issue types.
+func selectIssueType(client IssueTypeLister, p Prompter, owner, current string) (string, error) {
+	debug.Log("selectIssueType", "owner", owner, "current", current)
+
+	types, err := client.ListIssueTypes(owner)
+	if err != nil {
+		debug.Error("selectIssueType", err, "stage", "list_issue_types")
+		return "", fmt.Errorf("failed to list issue types: %w", err)
+	}
+	if len(types) == 0 {
+		debug.Log("selectIssueType", "result", "no_issue_types")
+		return current, nil
+	}
+
+	options := []string{"(none)"}
+	defaultValue := options[0]
+	for _, t := range types {
+		option := t.Name
+		if t.Description != "" {
+			option += " - " + t.Description
+		}
+		if strings.EqualFold(t.Name, current) {
+			defaultValue = option
+		}
+		options = append(options, option)
+	}
+
+	idx, err := p.Select("Issue type", defaultValue, options)
+	if err != nil {
+		debug.Error("selectIssueType", err, "stage", "prompt_select")
+		return "", err
+	}
+	if idx == 0 {
+		return "", nil
+	}
+	debug.Log("selectIssueType", "selected", types[idx-1].Name)
+	return types[idx-1].Name, nil
+}
+
+// resolveAssignees replaces "@me" with the authenticated user and "@parent"
+// with the assignees of parent, which must name its repository.
+func resolveAssignees(client AssigneeClient, parent IssueRef, logins []string) ([]string, error) {
+	var resolved []string
+	for _, login := range logins {
+		switch strings.ToLower(login) {
+		case "@me":
+			user, err := client.GetAuthenticatedUser()
+			if err != nil {
+				debug.Error("resolveAssignees", err, "stage", "get_authenticated_user")
+				return nil, fmt.Errorf("failed to resolve @me: %w", err)
+			}
+			resolved = appendMissing(resolved, user.Login)
+		case "@parent":
+			issue, err := client.GetIssue(parent.Owner, parent.Repo, parent.Number)
+			if err != nil {
+				debug.Error("resolveAssignees", err, "stage", "get_parent", "parent", parent.String())
+				return nil, fmt.Errorf("failed to resolve @parent: %w", err)
+			}
+			debug.Log("resolveAssignees", "parent", parent.String(), "parent_assignees", len(issue.Assignees))
+			resolved = appendMissing(resolved, userLogins(issue.Assignees)...)
+		default:
+			resolved = appendMissing(resolved, login)
+		}
+	}
+	debug.Log("resolveAssignees", "logins", logins, "resolved", resolved)
+	return resolved, nil
+}
+
+// promptMetadata offers the repository's labels, assignees and open
+// milestones, with the values in current preselected. A picker is skipped
+// when the repository has nothing to offer.
+func promptMetadata(client MetadataClient, p Prompter, owner, repo string, current issueMetadata) (issueMetadata, error) {
+	debug.Log("promptMetadata", "owner", owner, "repo", repo)
+	result := current
+
+	labels, err := client.ListLabels(owner, repo)
+	if err != nil {
+		debug.Error("promptMetadata", err, "stage", "list_labels")
+		return result, fmt.Errorf("failed to list labels: %w", err)
+	}
+	if result.Labels, err = multiSelectNames(p, "Labels", labelNames(labels), current.Labels); err != nil {
+		return result, err
+	}
+
+	users, err := client.ListAssignees(owner, repo)
+	if err != nil {
+		debug.Error("promptMetadata", err, "stage", "list_assignees")
+		return result, fmt.Errorf("failed to list assignees: %w", err)
+	}
+	if result.Assignees, err = multiSelectNames(p, "Assignees", userLogins(users), current.Assignees); err != nil {
+		return result, err
+	}
+
+	milestones, err := client.ListMilestones(owner, repo)
+	if err != nil {
+		debug.Error("promptMetadata", err, "stage", "list_milestones")
+		return result, fmt.Errorf("failed to list milestones: %w", err)
+	}
+	var open []api.Milestone
+	for _, m := range milestones {
+		if m.State == "open" {
+			open = append(open, m)
+		}
+	}
+	if len(open) > 0 {
+		options := []string{"(none)"}
+		for _, m := range open {
+			options = append(options, m.Title)
+		}
+		idx, err := p.Select("Milestone", options[0], options)
+		if err != nil {
+			debug.Error("promptMetadata", err, "stage", "select_milestone")
+			return result, err
+		}
+		result.Milestone = 0
+		if idx > 0 {
+			result.Milestone = open[idx-1].Number
+		}
+	}
+
+	debug.Log("promptMetadata", "labels", result.Labels, "assignees", result.Assignees, "milestone", result.Milestone)
+	return result, nil
+}
+
+// multiSelectNames prompts for a subset of names with selected preselected.
+// Selected values missing from names are offered too, so they can be
+// deselected. With nothing to offer, selected is returned unchanged.
+func multiSelectNames(p Prompter, prompt string, names, selected []string) ([]string, error) {
+	var defaults []string
+	for _, s := range selected {
+		found := false
+		for _, name := range names {
+			if strings.EqualFold(name, s) {
+				defaults = append(defaults, name)
+				found = true
+				break
+			}
+		}
+		if !found {
+			names = append(names, s)
+			defaults = append(defaults, s)
+		}
+	}
+	if len(names) == 0 {
+		return selected, nil
+	}
+
+	indexes, err := p.MultiSelect(prompt, defaults, names)
+	if err != nil {
+		debug.Error("multiSelectNames", err, "prompt", prompt)
+		return nil, err
+	}
+	result := make([]string, len(indexes))
+	for i, idx := range indexes {
+		result[i] = names[idx]
+	}
+	return result, nil
+}
+
+// containsFold reports whether candidates contains name, ignoring case.
+func containsFold(candidates []string, name string) bool {
+	for _, c := range candidates {
+		if strings.EqualFold(c, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// suggest returns the candidate closest to name, or "" when none is close
+// enough to be a likely typo.
+func suggest(name string, candidates []string) string {
+	lower := strings.ToLower(name)
+	best, bestDist := "", -1
+	for _, c := range candidates {
+		d := editDistance(lower, strings.ToLower(c))
+		if bestDist < 0 || d < bestDist {
+			best, bestDist = c, d
+		}
+	}
+
+	// Allow about one edit per three characters, and at least one
+	limit := len([]rune(name)) / 3
+	if limit < 1 {
+		limit = 1
+	}
+	if bestDist < 0 || bestDist > limit {
+		return ""
+	}
+	return best
+}
+
+// editDistance is the number of insertions, deletions, substitutions and
+// transpositions of adjacent characters needed to turn a into b.
+func editDistance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	d := make([][]int, len(ra)+1)
+	for i := range d {
+		d[i] = make([]int, len(rb)+1)
+		d[i][0] = i
+	}
+	for j := range d[0] {
+		d[0][j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			d[i][j] = min(d[i-1][j]+1, d[i][j-1]+1, d[i-1][j-1]+cost)
+			if i > 1 && j > 1 && ra[i-1] == rb[j-2] && ra[i-2] == rb[j-1] {
+				d[i][j] = min(d[i][j], d[i-2][j-2]+1)
+			}
+		}
+	}
+	return d[len(ra)][len(rb)]
+}
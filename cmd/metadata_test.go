@@ -0,0 +1,238 @@
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"strings"
+	"testing"
+
+	"github.com/gwyn/gh-subissue/internal/api"
+)
+
+func TestLabelCreationFlag(t *testing.T) {
+	tests := []struct {
+		name      string
+		args      []string
+		want      labelCreation
+		wantColor string
+		wantErr   bool
+	}{
+		{
+			name:      "not given",
+			args:      []string{},
+			want:      labelCreation{},
+			wantColor: defaultLabelColor,
+		},
+		{
+			name:      "without a color",
+			args:      []string{"--create-missing-labels"},
+			want:      labelCreation{Enabled: true},
+			wantColor: defaultLabelColor,
+		},
+		{
+			name:      "with a color",
+			args:      []string{"--create-missing-labels=#D73A4A"},
+			want:      labelCreation{Enabled: true, Color: "d73a4a"},
+			wantColor: "d73a4a",
+		},
+		{
+			name:      "explicitly false",
+			args:      []string{"--create-missing-labels=false"},
+			want:      labelCreation{},
+			wantColor: defaultLabelColor,
+		},
+		{
+			name:    "invalid color",
+			args:    []string{"--create-missing-labels=red"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got labelCreation
+			fs := flag.NewFlagSet("test", flag.ContinueOnError)
+			fs.SetOutput(&bytes.Buffer{})
+			fs.Var(&got, "create-missing-labels", "")
+
+			err := fs.Parse(tt.args)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Parse() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if got != tt.want {
+				t.Errorf("flag = %+v, want %+v", got, tt.want)
+			}
+			if got.color() != tt.wantColor {
+				t.Errorf("color() = %q, want %q", got.color(), tt.wantColor)
+			}
+		})
+	}
+}
+
+func TestCheckLabels(t *testing.T) {
+	t.Run("all labels exist", func(t *testing.T) {
+		client := &mockAPIClient{
+			createLabelFunc: func(owner, repo, name, color string) (*api.Label, error) {
+				t.Errorf("CreateLabel(%q) should not be called", name)
+				return nil, nil
+			},
+		}
+		err := checkLabels(client, &bytes.Buffer{}, "owner", "repo", []string{"Bug", "ui"}, labelCreation{})
+		if err != nil {
+			t.Errorf("checkLabels() error = %v", err)
+		}
+	})
+
+	t.Run("unknown labels", func(t *testing.T) {
+		client := &mockAPIClient{}
+		err := checkLabels(client, &bytes.Buffer{}, "owner", "repo", []string{"bgu", "ui", "needs-docs"}, labelCreation{})
+		if err == nil {
+			t.Fatal("expected error")
+		}
+		want := `label "bgu" not found in owner/repo; did you mean "bug"?
+label "needs-docs" not found in owner/repo
+Use --create-missing-labels to create them`
+		if err.Error() != want {
+			t.Errorf("error =\n%s\nwant\n%s", err, want)
+		}
+	})
+
+	t.Run("creates missing labels", func(t *testing.T) {
+		var created []string
+		client := &mockAPIClient{
+			createLabelFunc: func(owner, repo, name, color string) (*api.Label, error) {
+				created = append(created, name+"#"+color)
+				return &api.Label{Name: name, Color: color}, nil
+			},
+		}
+		var output bytes.Buffer
+		err := checkLabels(client, &output, "owner", "repo", []string{"bug", "needs-docs"}, labelCreation{Enabled: true, Color: "0e8a16"})
+		if err != nil {
+			t.Fatalf("checkLabels() error = %v", err)
+		}
+		if strings.Join(created, ",") != "needs-docs#0e8a16" {
+			t.Errorf("created = %v, want [needs-docs#0e8a16]", created)
+		}
+		if output.String() != "Created label \"needs-docs\"\n" {
+			t.Errorf("output = %q", output.String())
+		}
+	})
+
+	t.Run("list error", func(t *testing.T) {
+		client := &mockAPIClient{
+			listLabelsFunc: func(owner, repo string) ([]api.Label, error) {
+				return nil, errors.New("boom")
+			},
+		}
+		err := checkLabels(client, &bytes.Buffer{}, "owner", "repo", []string{"bug"}, labelCreation{})
+		if err == nil || !strings.Contains(err.Error(), "failed to list labels") {
+			t.Errorf("error = %v", err)
+		}
+	})
+}
+
+func TestResolveMilestone(t *testing.T) {
+	client := &mockAPIClient{
+		listMilestonesFunc: func(owner, repo string) ([]api.Milestone, error) {
+			return []api.Milestone{
+				{Number: 1, Title: "v1.0", State: "closed"},
+				{Number: 2, Title: "v2.0", State: "open"},
+				{Number: 3, Title: "Backlog", State: "open"},
+			}, nil
+		},
+	}
+
+	number, err := resolveMilestone(client, "owner", "repo", "backlog")
+	if err != nil {
+		t.Fatalf("resolveMilestone() error = %v", err)
+	}
+	if number != 3 {
+		t.Errorf("number = %d, want 3", number)
+	}
+
+	_, err = resolveMilestone(client, "owner", "repo", "v3.0")
+	want := `milestone "v3.0" not found in owner/repo; did you mean "v1.0"?
+Open milestones: "v2.0" "Backlog"`
+	if err == nil || err.Error() != want {
+		t.Errorf("error =\n%v\nwant\n%s", err, want)
+	}
+}
+
+func TestSuggest(t *testing.T) {
+	candidates := []string{"bug", "enhancement", "documentation", "good first issue"}
+	tests := []struct {
+		name string
+		want string
+	}{
+		{"bgu", "bug"},
+		{"BUGS", "bug"},
+		{"enhancment", "enhancement"},
+		{"documentaion", "documentation"},
+		{"good-first-issue", "good first issue"},
+		{"question", ""},
+		{"x", ""},
+	}
+
+	for _, tt := range tests {
+		if got := suggest(tt.name, candidates); got != tt.want {
+			t.Errorf("suggest(%q) = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestResolveAssignees(t *testing.T) {
+	client := &mockAPIClient{
+		getIssueFunc: func(owner, repo string, number int) (*api.Issue, error) {
+			return &api.Issue{Number: number, Assignees: []api.User{{Login: "TestUser"}, {Login: "hubot"}}}, nil
+		},
+	}
+
+	got, err := resolveAssignees(client, IssueRef{Owner: "owner", Repo: "repo", Number: 42}, []string{"@me", "@Parent"})
+	if err != nil {
+		t.Fatalf("resolveAssignees() error = %v", err)
+	}
+	if strings.Join(got, ",") != "testuser,hubot" {
+		t.Errorf("resolveAssignees() = %v, want [testuser hubot]", got)
+	}
+
+	client.getAuthenticatedUserFunc = func() (*api.User, error) {
+		return nil, errors.New("bad credentials")
+	}
+	_, err = resolveAssignees(client, IssueRef{Number: 42}, []string{"@me"})
+	if err == nil || err.Error() != "failed to resolve @me: bad credentials" {
+		t.Errorf("error = %v", err)
+	}
+}
+
+func TestMultiSelectNames(t *testing.T) {
+	var gotDefaults, gotOptions []string
+	p := &mockPrompter{
+		multiSelectFunc: func(prompt string, defaultValues, options []string) ([]int, error) {
+			gotDefaults, gotOptions = defaultValues, options
+			return []int{1, 2}, nil
+		},
+	}
+
+	got, err := multiSelectNames(p, "Labels", []string{"bug", "ui"}, []string{"UI", "from-template"})
+	if err != nil {
+		t.Fatalf("multiSelectNames() error = %v", err)
+	}
+	if strings.Join(gotDefaults, ",") != "ui,from-template" {
+		t.Errorf("defaults = %v, want [ui from-template]", gotDefaults)
+	}
+	if strings.Join(gotOptions, ",") != "bug,ui,from-template" {
+		t.Errorf("options = %v", gotOptions)
+	}
+	if strings.Join(got, ",") != "ui,from-template" {
+		t.Errorf("multiSelectNames() = %v", got)
+	}
+
+	got, err = multiSelectNames(p, "Assignees", nil, nil)
+	if err != nil || got != nil {
+		t.Errorf("multiSelectNames() with no names = %v, %v; want nil", got, err)
+	}
+}
@@ -0,0 +1,246 @@
+package cmd
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+
+	"github.com/gwyn/gh-subissue/internal/api"
+	"github.com/gwyn/gh-subissue/internal/debug"
+)
+
+// MoveOptions contains the parsed command line options for the move command.
+type MoveOptions struct {
+	Parent int
+	Issue  string // sub-issue number or URL to move (interactive if empty)
+	Repo   string
+	Before int
+	After  int
+	Top    bool
+	Bottom bool
+}
+
+// hasPosition reports whether a target position was given on the command line.
+func (o MoveOptions) hasPosition() bool {
+	return o.Before != 0 || o.After != 0 || o.Top || o.Bottom
+}
+
+// ParseMoveFlags parses command line flags for the move command.
+func ParseMoveFlags(args []string) (*MoveOptions, error) {
+	debug.Log("ParseMoveFlags", "args", args)
+
+	opts := &MoveOptions{}
+	fs := flag.NewFlagSet("move", flag.ContinueOnError)
+
+	fs.IntVar(&opts.Parent, "parent", 0, "Parent issue number")
+	fs.IntVar(&opts.Parent, "p", 0, "Parent issue number")
+
+	fs.StringVar(&opts.Repo, "repo", "", "Repository (owner/repo)")
+	fs.StringVar(&opts.Repo, "R", "", "Repository (owner/repo)")
+
+	fs.IntVar(&opts.Before, "before", 0, "Place before this sub-issue number")
+	fs.IntVar(&opts.After, "after", 0, "Place after this sub-issue number")
+	fs.BoolVar(&opts.Top, "top", false, "Move to the top of the list")
+	fs.BoolVar(&opts.Bottom, "bottom", false, "Move to the bottom of the list")
+
+	positional, flagArgs := splitArgs(args,
+		"-p", "--parent", "-parent", "-R", "--repo", "-repo",
+		"--before", "-before", "--after", "-after")
+	if err := fs.Parse(flagArgs); err != nil {
+		debug.Error("ParseMoveFlags", err, "stage", "fs.Parse")
+		return nil, err
+	}
+
+	if len(positional) > 1 {
+		return nil, fmt.Errorf("only one sub-issue can be moved at a time, got %d", len(positional))
+	}
+	if len(positional) == 1 {
+		opts.Issue = positional[0]
+	}
+
+	positions := 0
+	for _, set := range []bool{opts.Before != 0, opts.After != 0, opts.Top, opts.Bottom} {
+		if set {
+			positions++
+		}
+	}
+	if positions > 1 {
+		return nil, errors.New("specify only one of --before, --after, --top or --bottom")
+	}
+
+	debug.Log("ParseMoveFlags", "parsed", fmt.Sprintf("%+v", opts))
+	return opts, nil
+}
+
+// MoveAPIClient defines the interface for move operations.
+type MoveAPIClient interface {
+	ListIssues(opts api.ListIssuesOptions) ([]api.Issue, error)
+	ListSubIssues(opts api.ListSubIssuesOptions) ([]api.Issue, error)
+	ReprioritizeSubIssue(opts api.ReprioritizeSubIssueOptions) error
+}
+
+// MoveRunner executes the move subcommand.
+type MoveRunner struct {
+	Client   MoveAPIClient
+	Owner    string
+	Repo     string
+	Out      io.Writer
+	Prompter Prompter // nil means non-interactive mode
+}
+
+// Run executes the move command.
+func (r *MoveRunner) Run(opts MoveOptions) error {
+	debug.Log("MoveRunner.Run", "parent", opts.Parent, "issue", opts.Issue, "before", opts.Before, "after", opts.After, "top", opts.Top, "bottom", opts.Bottom)
+
+	parent := opts.Parent
+
+	// If no parent specified, prompt for selection
+	if parent == 0 {
+		if r.Prompter == nil {
+			return fmt.Errorf("--parent flag is required when not running interactively\nTo find parent issues: gh issue list -R %s/%s", r.Owner, r.Repo)
+		}
+
+		issues, err := r.Client.ListIssues(api.ListIssuesOptions{
+			Owner: r.Owner,
+			Repo:  r.Repo,
+			State: "open",
+			Limit: pickerIssueLimit,
+		})
+		if err != nil {
+			debug.Error("MoveRunner.Run", err, "stage", "list_issues")
+			return fmt.Errorf("failed to list issues: %w", err)
+		}
+
+		selected, err := SelectParentIssue(r.Prompter, issues)
+		if err != nil {
+			debug.Error("MoveRunner.Run", err, "stage", "select_parent")
+			return err
+		}
+		parent = selected
+	}
+
+	children, err := r.Client.ListSubIssues(api.ListSubIssuesOptions{
+		Owner:       r.Owner,
+		Repo:        r.Repo,
+		ParentIssue: parent,
+	})
+	if err != nil {
+		debug.Error("MoveRunner.Run", err, "stage", "list_sub_issues")
+		return err
+	}
+	if len(children) < 2 {
+		return fmt.Errorf("issue #%d needs at least two sub-issues to reorder", parent)
+	}
+
+	// Pick the sub-issue to move
+	var target api.Issue
+	if opts.Issue != "" {
+		ref, err := ParseIssueRef(opts.Issue)
+		if err != nil {
+			return err
+		}
+		idx, err := findIssue(children, ref, r.Owner, r.Repo)
+		if err != nil {
+			return err
+		}
+		if idx < 0 {
+			return fmt.Errorf("%s is not a sub-issue of #%d\nTo see sub-issues: gh subissue list --parent %d", ref, parent, parent)
+		}
+		target = children[idx]
+	} else {
+		if r.Prompter == nil {
+			return fmt.Errorf("sub-issue number is required when not running interactively\nExample: gh subissue move 43 --parent %d --top", parent)
+		}
+		idx, err := r.Prompter.Select("Select sub-issue to move", "", issueOptions(children))
+		if err != nil {
+			debug.Error("MoveRunner.Run", err, "stage", "select_sub_issue")
+			return err
+		}
+		target = children[idx]
+	}
+
+	// Siblings are the other children in their current order
+	var siblings []api.Issue
+	for _, child := range children {
+		if child.ID != target.ID {
+			siblings = append(siblings, child)
+		}
+	}
+
+	// anchor is the sibling picked interactively to move the target after
+	var anchor *api.Issue
+	if !opts.hasPosition() {
+		if r.Prompter == nil {
+			return errors.New("one of --before, --after, --top or --bottom is required when not running interactively")
+		}
+		options := []string{"To the top"}
+		for _, option := range issueOptions(siblings) {
+			options = append(options, "After "+option)
+		}
+		idx, err := r.Prompter.Select(fmt.Sprintf("Move %s where?", r.label(target)), "", options)
+		if err != nil {
+			debug.Error("MoveRunner.Run", err, "stage", "select_position")
+			return err
+		}
+		if idx == 0 {
+			opts.Top = true
+		} else {
+			anchor = &siblings[idx-1]
+		}
+	}
+
+	moveOpts := api.ReprioritizeSubIssueOptions{
+		Owner:       r.Owner,
+		Repo:        r.Repo,
+		ParentIssue: parent,
+		SubIssueID:  target.ID,
+	}
+	var description string
+	switch {
+	case anchor != nil:
+		moveOpts.AfterID = anchor.ID
+		description = "after " + r.label(*anchor)
+	case opts.Top:
+		moveOpts.BeforeID = siblings[0].ID
+		description = "to the top"
+	case opts.Bottom:
+		moveOpts.AfterID = siblings[len(siblings)-1].ID
+		description = "to the bottom"
+	case opts.Before != 0:
+		idx, err := findIssue(siblings, IssueRef{Number: opts.Before}, r.Owner, r.Repo)
+		if err != nil {
+			return fmt.Errorf("--before: %w", err)
+		}
+		if idx < 0 {
+			return fmt.Errorf("--before #%d is not another sub-issue of #%d", opts.Before, parent)
+		}
+		moveOpts.BeforeID = siblings[idx].ID
+		description = "before " + r.label(siblings[idx])
+	case opts.After != 0:
+		idx, err := findIssue(siblings, IssueRef{Number: opts.After}, r.Owner, r.Repo)
+		if err != nil {
+			return fmt.Errorf("--after: %w", err)
+		}
+		if idx < 0 {
+			return fmt.Errorf("--after #%d is not another sub-issue of #%d", opts.After, parent)
+		}
+		moveOpts.AfterID = siblings[idx].ID
+		description = "after " + r.label(siblings[idx])
+	}
+
+	if err := r.Client.ReprioritizeSubIssue(moveOpts); err != nil {
+		debug.Error("MoveRunner.Run", err, "stage", "reprioritize_sub_issue")
+		return err
+	}
+
+	fmt.Fprintf(r.Out, "Moved %s %s (parent #%d)\n", r.label(target), description, parent)
+	debug.Log("MoveRunner.Run", "result", "success", "position", description)
+	return nil
+}
+
+// label formats a sub-issue as "#43", or "owner/repo#43" when it is in
+// another repository.
+func (r *MoveRunner) label(issue api.Issue) string {
+	return issueRefOf(issue, r.Owner, r.Repo).RelativeTo(r.Owner, r.Repo)
+}
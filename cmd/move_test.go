@@ -0,0 +1,336 @@
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/gwyn/gh-subissue/internal/api"
+)
+
+func TestParseMoveFlags(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		want    MoveOptions
+		wantErr bool
+	}{
+		{
+			name: "issue with before",
+			args: []string{"44", "--parent", "42", "--before", "43"},
+			want: MoveOptions{Parent: 42, Issue: "44", Before: 43},
+		},
+		{
+			name: "flags first with top",
+			args: []string{"-p", "42", "--top", "44"},
+			want: MoveOptions{Parent: 42, Issue: "44", Top: true},
+		},
+		{
+			name: "bottom without issue",
+			args: []string{"-p", "42", "--bottom"},
+			want: MoveOptions{Parent: 42, Bottom: true},
+		},
+		{
+			name:    "conflicting positions",
+			args:    []string{"44", "-p", "42", "--top", "--after", "43"},
+			wantErr: true,
+		},
+		{
+			name:    "more than one issue",
+			args:    []string{"44", "45", "-p", "42"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts, err := ParseMoveFlags(tt.args)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ParseMoveFlags() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			if *opts != tt.want {
+				t.Errorf("ParseMoveFlags() = %+v, want %+v", *opts, tt.want)
+			}
+		})
+	}
+}
+
+// mockMoveAPIClient implements the MoveAPIClient interface for testing.
+type mockMoveAPIClient struct {
+	listIssuesFunc           func(opts api.ListIssuesOptions) ([]api.Issue, error)
+	listSubIssuesFunc        func(opts api.ListSubIssuesOptions) ([]api.Issue, error)
+	reprioritizeSubIssueFunc func(opts api.ReprioritizeSubIssueOptions) error
+}
+
+func (m *mockMoveAPIClient) ListIssues(opts api.ListIssuesOptions) ([]api.Issue, error) {
+	if m.listIssuesFunc != nil {
+		return m.listIssuesFunc(opts)
+	}
+	return []api.Issue{}, nil
+}
+
+func (m *mockMoveAPIClient) ListSubIssues(opts api.ListSubIssuesOptions) ([]api.Issue, error) {
+	if m.listSubIssuesFunc != nil {
+		return m.listSubIssuesFunc(opts)
+	}
+	return []api.Issue{
+		{ID: 430, Number: 43, Title: "First"},
+		{ID: 440, Number: 44, Title: "Second"},
+		{ID: 450, Number: 45, Title: "Third"},
+	}, nil
+}
+
+func (m *mockMoveAPIClient) ReprioritizeSubIssue(opts api.ReprioritizeSubIssueOptions) error {
+	if m.reprioritizeSubIssueFunc != nil {
+		return m.reprioritizeSubIssueFunc(opts)
+	}
+	return nil
+}
+
+// Compile-time check
+var _ MoveAPIClient = (*mockMoveAPIClient)(nil)
+
+func TestMoveRunnerRun(t *testing.T) {
+	tests := []struct {
+		name       string
+		opts       MoveOptions
+		wantAfter  int64
+		wantBefore int64
+		wantOutput string
+		wantErr    bool
+	}{
+		{
+			name:       "top",
+			opts:       MoveOptions{Parent: 42, Issue: "45", Top: true},
+			wantBefore: 430,
+			wantOutput: "Moved #45 to the top (parent #42)\n",
+		},
+		{
+			name:       "top when first child is the target",
+			opts:       MoveOptions{Parent: 42, Issue: "43", Top: true},
+			wantBefore: 440,
+			wantOutput: "Moved #43 to the top (parent #42)\n",
+		},
+		{
+			name:       "bottom",
+			opts:       MoveOptions{Parent: 42, Issue: "43", Bottom: true},
+			wantAfter:  450,
+			wantOutput: "Moved #43 to the bottom (parent #42)\n",
+		},
+		{
+			name:       "before",
+			opts:       MoveOptions{Parent: 42, Issue: "45", Before: 44},
+			wantBefore: 440,
+			wantOutput: "Moved #45 before #44 (parent #42)\n",
+		},
+		{
+			name:       "after",
+			opts:       MoveOptions{Parent: 42, Issue: "43", After: 44},
+			wantAfter:  440,
+			wantOutput: "Moved #43 after #44 (parent #42)\n",
+		},
+		{
+			name:    "issue is not a child",
+			opts:    MoveOptions{Parent: 42, Issue: "99", Top: true},
+			wantErr: true,
+		},
+		{
+			name:    "relative to itself",
+			opts:    MoveOptions{Parent: 42, Issue: "43", After: 43},
+			wantErr: true,
+		},
+		{
+			name:    "no position without prompter",
+			opts:    MoveOptions{Parent: 42, Issue: "43"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got api.ReprioritizeSubIssueOptions
+			client := &mockMoveAPIClient{
+				reprioritizeSubIssueFunc: func(opts api.ReprioritizeSubIssueOptions) error {
+					got = opts
+					return nil
+				},
+			}
+
+			var output bytes.Buffer
+			runner := &MoveRunner{
+				Client: client,
+				Owner:  "owner",
+				Repo:   "repo",
+				Out:    &output,
+			}
+
+			err := runner.Run(tt.opts)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Run() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			if got.AfterID != tt.wantAfter || got.BeforeID != tt.wantBefore {
+				t.Errorf("after/before = %d/%d, want %d/%d", got.AfterID, got.BeforeID, tt.wantAfter, tt.wantBefore)
+			}
+			if output.String() != tt.wantOutput {
+				t.Errorf("output = %q, want %q", output.String(), tt.wantOutput)
+			}
+		})
+	}
+}
+
+func TestMoveRunnerCrossRepoSubIssues(t *testing.T) {
+	children := []api.Issue{
+		{ID: 1430, Number: 43, Title: "Lib task", RepositoryURL: "https://api.github.com/repos/other/lib"},
+		{ID: 430, Number: 43, Title: "Task", RepositoryURL: "https://api.github.com/repos/owner/repo"},
+		{ID: 2500, Number: 50, Title: "Docs", RepositoryURL: "https://api.github.com/repos/other/docs"},
+		{ID: 1500, Number: 50, Title: "Lib docs", RepositoryURL: "https://api.github.com/repos/other/lib"},
+	}
+
+	tests := []struct {
+		name       string
+		opts       MoveOptions
+		wantID     int64
+		wantBefore int64
+		wantOutput string
+		wantErr    string
+	}{
+		{
+			name:       "bare number prefers the current repository",
+			opts:       MoveOptions{Parent: 42, Issue: "43", Bottom: true},
+			wantID:     430,
+			wantOutput: "Moved #43 to the bottom (parent #42)\n",
+		},
+		{
+			name:       "URL names the other repository",
+			opts:       MoveOptions{Parent: 42, Issue: "https://github.com/other/lib/issues/43", Before: 43},
+			wantID:     1430,
+			wantBefore: 430,
+			wantOutput: "Moved other/lib#43 before #43 (parent #42)\n",
+		},
+		{
+			name:       "qualified reference",
+			opts:       MoveOptions{Parent: 42, Issue: "other/docs#50", Top: true},
+			wantID:     2500,
+			wantBefore: 1430,
+			wantOutput: "Moved other/docs#50 to the top (parent #42)\n",
+		},
+		{
+			name:    "ambiguous number",
+			opts:    MoveOptions{Parent: 42, Issue: "50", Top: true},
+			wantErr: "#50 matches sub-issues in several repositories (other/docs#50, other/lib#50); use owner/repo#50 to pick one",
+		},
+		{
+			name:    "ambiguous position",
+			opts:    MoveOptions{Parent: 42, Issue: "43", After: 50},
+			wantErr: "--after: #50 matches sub-issues in several repositories (other/docs#50, other/lib#50); use owner/repo#50 to pick one",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got api.ReprioritizeSubIssueOptions
+			client := &mockMoveAPIClient{
+				listSubIssuesFunc: func(opts api.ListSubIssuesOptions) ([]api.Issue, error) {
+					return children, nil
+				},
+				reprioritizeSubIssueFunc: func(opts api.ReprioritizeSubIssueOptions) error {
+					got = opts
+					return nil
+				},
+			}
+
+			var output bytes.Buffer
+			runner := &MoveRunner{Client: client, Owner: "owner", Repo: "repo", Out: &output}
+
+			err := runner.Run(tt.opts)
+			if tt.wantErr != "" {
+				if err == nil || err.Error() != tt.wantErr {
+					t.Errorf("Run() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Run() error = %v", err)
+			}
+			if got.SubIssueID != tt.wantID {
+				t.Errorf("moved %d, want %d", got.SubIssueID, tt.wantID)
+			}
+			if got.BeforeID != tt.wantBefore {
+				t.Errorf("before = %d, want %d", got.BeforeID, tt.wantBefore)
+			}
+			if output.String() != tt.wantOutput {
+				t.Errorf("output = %q, want %q", output.String(), tt.wantOutput)
+			}
+		})
+	}
+}
+
+func TestMoveRunnerInteractive(t *testing.T) {
+	var got api.ReprioritizeSubIssueOptions
+	client := &mockMoveAPIClient{
+		reprioritizeSubIssueFunc: func(opts api.ReprioritizeSubIssueOptions) error {
+			got = opts
+			return nil
+		},
+	}
+
+	var positionOptions []string
+	prompter := &mockPrompter{
+		selectFunc: func(prompt, defaultValue string, options []string) (int, error) {
+			if prompt == "Select sub-issue to move" {
+				return 0, nil // #43
+			}
+			positionOptions = options
+			return 2, nil // after #45
+		},
+	}
+
+	var output bytes.Buffer
+	runner := &MoveRunner{
+		Client:   client,
+		Owner:    "owner",
+		Repo:     "repo",
+		Out:      &output,
+		Prompter: prompter,
+	}
+
+	if err := runner.Run(MoveOptions{Parent: 42}); err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+
+	wantOptions := "To the top|After #44 Second|After #45 Third"
+	if strings.Join(positionOptions, "|") != wantOptions {
+		t.Errorf("position options = %q, want %q", strings.Join(positionOptions, "|"), wantOptions)
+	}
+	if got.SubIssueID != 430 || got.AfterID != 450 {
+		t.Errorf("got %+v, want sub-issue 430 after 450", got)
+	}
+}
+
+func TestMoveRunnerNeedsTwoChildren(t *testing.T) {
+	client := &mockMoveAPIClient{
+		listSubIssuesFunc: func(opts api.ListSubIssuesOptions) ([]api.Issue, error) {
+			return []api.Issue{{ID: 430, Number: 43}}, nil
+		},
+	}
+
+	var output bytes.Buffer
+	runner := &MoveRunner{
+		Client: client,
+		Owner:  "owner",
+		Repo:   "repo",
+		Out:    &output,
+	}
+
+	if err := runner.Run(MoveOptions{Parent: 42, Issue: "43", Top: true}); err == nil {
+		t.Error("expected error with a single sub-issue")
+	}
+}
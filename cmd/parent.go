@@ -0,0 +1,179 @@
+package cmd
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/gwyn/gh-subissue/internal/api"
+	"github.com/gwyn/gh-subissue/internal/debug"
+)
+
+// NoParentError is returned when an issue is not a sub-issue of anything.
+// main maps it to a distinct exit status so scripts can tell it apart from failures.
+type NoParentError struct {
+	Issue string // issue label, e.g. "#43" or "owner/repo#43"
+}
+
+func (e *NoParentError) Error() string {
+	return fmt.Sprintf("issue %s has no parent", e.Issue)
+}
+
+// ParentOptions contains the parsed command line options for the parent command.
+type ParentOptions struct {
+	Issue     string // issue number or URL (interactive if empty)
+	Repo      string
+	Ancestors bool
+}
+
+// ParseParentFlags parses command line flags for the parent command.
+func ParseParentFlags(args []string) (*ParentOptions, error) {
+	debug.Log("ParseParentFlags", "args", args)
+
+	opts := &ParentOptions{}
+	fs := flag.NewFlagSet("parent", flag.ContinueOnError)
+
+	fs.StringVar(&opts.Repo, "repo", "", "Repository (owner/repo)")
+	fs.StringVar(&opts.Repo, "R", "", "Repository (owner/repo)")
+
+	fs.BoolVar(&opts.Ancestors, "ancestors", false, "Show the full chain of parents up to the root")
+
+	positional, flagArgs := splitArgs(args, "-R", "--repo", "-repo")
+	if err := fs.Parse(flagArgs); err != nil {
+		debug.Error("ParseParentFlags", err, "stage", "fs.Parse")
+		return nil, err
+	}
+
+	if len(positional) > 1 {
+		return nil, fmt.Errorf("only one issue can be given, got %d", len(positional))
+	}
+	if len(positional) == 1 {
+		opts.Issue = positional[0]
+	}
+
+	debug.Log("ParseParentFlags", "parsed", fmt.Sprintf("%+v", opts))
+	return opts, nil
+}
+
+// ParentAPIClient defines the interface for parent operations.
+type ParentAPIClient interface {
+	GetIssue(owner, repo string, number int) (*api.Issue, error)
+	GetParentIssue(owner, repo string, number int) (*api.Issue, error)
+	ListIssues(opts api.ListIssuesOptions) ([]api.Issue, error)
+}
+
+// ParentRunner executes the parent subcommand.
+type ParentRunner struct {
+	Client   ParentAPIClient
+	Owner    string
+	Repo     string
+	Out      io.Writer
+	Prompter Prompter // nil means non-interactive mode
+}
+
+// Run executes the parent command.
+func (r *ParentRunner) Run(opts ParentOptions) error {
+	debug.Log("ParentRunner.Run", "issue", opts.Issue, "ancestors", opts.Ancestors)
+
+	ref := IssueRef{Owner: r.Owner, Repo: r.Repo}
+	if opts.Issue == "" {
+		if r.Prompter == nil {
+			return fmt.Errorf("issue number is required when not running interactively\nExample: gh subissue parent 43 -R %s/%s", r.Owner, r.Repo)
+		}
+
+		issues, err := r.Client.ListIssues(api.ListIssuesOptions{
+			Owner: r.Owner,
+			Repo:  r.Repo,
+			State: "open",
+			Limit: pickerIssueLimit,
+		})
+		if err != nil {
+			debug.Error("ParentRunner.Run", err, "stage", "list_issues")
+			return fmt.Errorf("failed to list issues: %w", err)
+		}
+
+		idx, err := r.Prompter.Select("Select issue", "", issueOptions(issues))
+		if err != nil {
+			debug.Error("ParentRunner.Run", err, "stage", "select_issue")
+			return err
+		}
+		ref.Number = issues[idx].Number
+	} else {
+		parsed, err := ParseIssueRef(opts.Issue)
+		if err != nil {
+			return err
+		}
+		ref.Number = parsed.Number
+		if parsed.Owner != "" {
+			ref.Owner, ref.Repo = parsed.Owner, parsed.Repo
+		}
+	}
+
+	// Fetch the issue itself so a missing issue is not reported as "no parent"
+	issue, err := r.Client.GetIssue(ref.Owner, ref.Repo, ref.Number)
+	if err != nil {
+		debug.Error("ParentRunner.Run", err, "stage", "get_issue")
+		return err
+	}
+
+	chain := []api.Issue{*issue}
+	visited := map[int64]bool{issue.ID: true}
+	current := ref
+	for {
+		parent, err := r.Client.GetParentIssue(current.Owner, current.Repo, current.Number)
+		if err != nil {
+			debug.Error("ParentRunner.Run", err, "stage", "get_parent", "issue", current.String())
+			return err
+		}
+		if parent == nil || visited[parent.ID] {
+			break
+		}
+		visited[parent.ID] = true
+		chain = append(chain, *parent)
+
+		if !opts.Ancestors {
+			break
+		}
+
+		// The parent may live in another repository
+		current = IssueRef{Owner: current.Owner, Repo: current.Repo, Number: parent.Number}
+		if owner, repo := parent.Repository(); owner != "" {
+			current.Owner, current.Repo = owner, repo
+		}
+	}
+
+	if len(chain) == 1 {
+		err := &NoParentError{Issue: r.label(*issue, ref.Owner, ref.Repo)}
+		debug.Log("ParentRunner.Run", "result", "no_parent")
+		return err
+	}
+
+	if !opts.Ancestors {
+		parent := chain[1]
+		fmt.Fprintf(r.Out, "%s %s\n", r.label(parent, ref.Owner, ref.Repo), parent.Title)
+		fmt.Fprintln(r.Out, parent.URL)
+		debug.Log("ParentRunner.Run", "result", "success", "parent", parent.Number)
+		return nil
+	}
+
+	// Breadcrumb from the root down to the issue itself
+	crumbs := make([]string, 0, len(chain))
+	for i := len(chain) - 1; i >= 0; i-- {
+		crumbs = append(crumbs, fmt.Sprintf("%s %s", r.label(chain[i], ref.Owner, ref.Repo), chain[i].Title))
+	}
+	fmt.Fprintln(r.Out, strings.Join(crumbs, " > "))
+
+	debug.Log("ParentRunner.Run", "result", "success", "depth", len(chain)-1)
+	return nil
+}
+
+// label formats an issue as "#42", or "owner/repo#42" when it lives outside
+// the repository given by owner and repo.
+func (r *ParentRunner) label(issue api.Issue, owner, repo string) string {
+	ref := IssueRef{Number: issue.Number}
+	if o, n := issue.Repository(); o != "" && (o != owner || n != repo) {
+		ref.Owner, ref.Repo = o, n
+	}
+	return ref.String()
+}
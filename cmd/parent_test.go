@@ -0,0 +1,219 @@
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/gwyn/gh-subissue/internal/api"
+)
+
+func TestParseParentFlags(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		want    ParentOptions
+		wantErr bool
+	}{
+		{
+			name: "issue only",
+			args: []string{"43"},
+			want: ParentOptions{Issue: "43"},
+		},
+		{
+			name: "ancestors after issue",
+			args: []string{"43", "--ancestors", "-R", "owner/repo"},
+			want: ParentOptions{Issue: "43", Repo: "owner/repo", Ancestors: true},
+		},
+		{
+			name: "no issue",
+			args: []string{},
+			want: ParentOptions{},
+		},
+		{
+			name:    "too many issues",
+			args:    []string{"43", "44"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts, err := ParseParentFlags(tt.args)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ParseParentFlags() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			if *opts != tt.want {
+				t.Errorf("ParseParentFlags() = %+v, want %+v", *opts, tt.want)
+			}
+		})
+	}
+}
+
+// mockParentAPIClient implements the ParentAPIClient interface for testing.
+// Issues are keyed by "owner/repo#number"; parents maps a key to its parent's key.
+type mockParentAPIClient struct {
+	issues  map[string]api.Issue
+	parents map[string]string
+}
+
+func (m *mockParentAPIClient) GetIssue(owner, repo string, number int) (*api.Issue, error) {
+	issue, ok := m.issues[fmt.Sprintf("%s/%s#%d", owner, repo, number)]
+	if !ok {
+		return nil, errors.New("not found")
+	}
+	return &issue, nil
+}
+
+func (m *mockParentAPIClient) GetParentIssue(owner, repo string, number int) (*api.Issue, error) {
+	key, ok := m.parents[fmt.Sprintf("%s/%s#%d", owner, repo, number)]
+	if !ok {
+		return nil, nil
+	}
+	issue := m.issues[key]
+	return &issue, nil
+}
+
+func (m *mockParentAPIClient) ListIssues(opts api.ListIssuesOptions) ([]api.Issue, error) {
+	return []api.Issue{m.issues["owner/repo#45"]}, nil
+}
+
+// Compile-time check
+var _ ParentAPIClient = (*mockParentAPIClient)(nil)
+
+func newMockParentClient() *mockParentAPIClient {
+	return &mockParentAPIClient{
+		issues: map[string]api.Issue{
+			"owner/repo#45": {ID: 45, Number: 45, Title: "API", URL: "https://github.com/owner/repo/issues/45", RepositoryURL: "https://api.github.com/repos/owner/repo"},
+			"owner/repo#43": {ID: 43, Number: 43, Title: "Backend", URL: "https://github.com/owner/repo/issues/43", RepositoryURL: "https://api.github.com/repos/owner/repo"},
+			"org/roadmap#7": {ID: 7, Number: 7, Title: "Launch v2", URL: "https://github.com/org/roadmap/issues/7", RepositoryURL: "https://api.github.com/repos/org/roadmap"},
+			"owner/repo#50": {ID: 50, Number: 50, Title: "Orphan", RepositoryURL: "https://api.github.com/repos/owner/repo"},
+		},
+		parents: map[string]string{
+			"owner/repo#45": "owner/repo#43",
+			"owner/repo#43": "org/roadmap#7",
+		},
+	}
+}
+
+func TestParentRunnerRun(t *testing.T) {
+	tests := []struct {
+		name       string
+		opts       ParentOptions
+		wantOutput string
+	}{
+		{
+			name:       "shows direct parent",
+			opts:       ParentOptions{Issue: "45"},
+			wantOutput: "#43 Backend\nhttps://github.com/owner/repo/issues/43\n",
+		},
+		{
+			name:       "parent in another repository",
+			opts:       ParentOptions{Issue: "43"},
+			wantOutput: "org/roadmap#7 Launch v2\nhttps://github.com/org/roadmap/issues/7\n",
+		},
+		{
+			name:       "ancestors across repositories",
+			opts:       ParentOptions{Issue: "45", Ancestors: true},
+			wantOutput: "org/roadmap#7 Launch v2 > #43 Backend > #45 API\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var output bytes.Buffer
+			runner := &ParentRunner{
+				Client: newMockParentClient(),
+				Owner:  "owner",
+				Repo:   "repo",
+				Out:    &output,
+			}
+
+			if err := runner.Run(tt.opts); err != nil {
+				t.Fatalf("Run() error = %v", err)
+			}
+			if output.String() != tt.wantOutput {
+				t.Errorf("output = %q, want %q", output.String(), tt.wantOutput)
+			}
+		})
+	}
+}
+
+func TestParentRunnerNoParent(t *testing.T) {
+	for _, ancestors := range []bool{false, true} {
+		var output bytes.Buffer
+		runner := &ParentRunner{
+			Client: newMockParentClient(),
+			Owner:  "owner",
+			Repo:   "repo",
+			Out:    &output,
+		}
+
+		err := runner.Run(ParentOptions{Issue: "50", Ancestors: ancestors})
+		var noParent *NoParentError
+		if !errors.As(err, &noParent) {
+			t.Fatalf("Run(ancestors=%v) error = %v, want NoParentError", ancestors, err)
+		}
+		if err.Error() != "issue #50 has no parent" {
+			t.Errorf("error = %q", err.Error())
+		}
+		if output.Len() != 0 {
+			t.Errorf("expected no output, got %q", output.String())
+		}
+	}
+}
+
+func TestParentRunnerMissingIssue(t *testing.T) {
+	runner := &ParentRunner{
+		Client: newMockParentClient(),
+		Owner:  "owner",
+		Repo:   "repo",
+		Out:    &bytes.Buffer{},
+	}
+
+	err := runner.Run(ParentOptions{Issue: "999"})
+	var noParent *NoParentError
+	if err == nil || errors.As(err, &noParent) {
+		t.Errorf("Run() error = %v, want a lookup error rather than NoParentError", err)
+	}
+}
+
+func TestParentRunnerInteractive(t *testing.T) {
+	var output bytes.Buffer
+	runner := &ParentRunner{
+		Client: newMockParentClient(),
+		Owner:  "owner",
+		Repo:   "repo",
+		Out:    &output,
+		Prompter: &mockPrompter{
+			selectFunc: func(prompt, defaultValue string, options []string) (int, error) {
+				return 0, nil
+			},
+		},
+	}
+
+	if err := runner.Run(ParentOptions{}); err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	if output.String() != "#43 Backend\nhttps://github.com/owner/repo/issues/43\n" {
+		t.Errorf("output = %q", output.String())
+	}
+}
+
+func TestParentRunnerNoPrompterRequiresIssue(t *testing.T) {
+	runner := &ParentRunner{
+		Client: newMockParentClient(),
+		Owner:  "owner",
+		Repo:   "repo",
+		Out:    &bytes.Buffer{},
+	}
+
+	if err := runner.Run(ParentOptions{}); err == nil {
+		t.Error("Run() expected error without an issue or prompter")
+	}
+}
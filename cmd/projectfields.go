@@ -0,0 +1,204 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gwyn/gh-subissue/internal/api"
+	"github.com/gwyn/gh-subissue/internal/debug"
+)
+
+// FieldAssignment is a --field "Name=value" flag. A missing value is
+// prompted for interactively.
+type FieldAssignment struct {
+	Name     string
+	Value    string
+	HasValue bool
+}
+
+// fieldAssignments is a flag.Value that collects repeated --field flags.
+type fieldAssignments []FieldAssignment
+
+func (f *fieldAssignments) String() string {
+	parts := make([]string, len(*f))
+	for i, a := range *f {
+		parts[i] = a.Name
+		if a.HasValue {
+			parts[i] += "=" + a.Value
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+func (f *fieldAssignments) Set(value string) error {
+	name, val, hasValue := strings.Cut(value, "=")
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return fmt.Errorf("invalid field %q (expected NAME=VALUE)", value)
+	}
+	*f = append(*f, FieldAssignment{Name: name, Value: strings.TrimSpace(val), HasValue: hasValue})
+	return nil
+}
+
+// ProjectFieldsClient defines the API operations for setting project fields.
+type ProjectFieldsClient interface {
+	ListProjectFields(projectID string) ([]api.ProjectField, error)
+	SetProjectFieldValue(opts api.SetProjectFieldValueOptions) error
+}
+
+// setProjectFields sets each assignment on a project item. Fields without a
+// value are prompted for; p may be nil in non-interactive mode.
+// Returns the values that were set, formatted for display.
+func setProjectFields(client ProjectFieldsClient, p Prompter, project *api.Project, itemID string, assignments []FieldAssignment) ([]string, error) {
+	debug.Log("setProjectFields", "project", project.Title, "item_id", itemID, "count", len(assignments))
+
+	fields, err := client.ListProjectFields(project.ID)
+	if err != nil {
+		debug.Error("setProjectFields", err, "stage", "list_fields")
+		return nil, fmt.Errorf("failed to list fields of project %q: %w", project.Title, err)
+	}
+
+	var set []string
+	for _, a := range assignments {
+		field := findProjectField(fields, a.Name)
+		if field == nil {
+			return set, fmt.Errorf("field %q not found in project %q\nAvailable fields: %s", a.Name, project.Title, settableFieldNames(fields))
+		}
+
+		value, display, err := resolveFieldValue(p, field, a)
+		if err != nil {
+			debug.Error("setProjectFields", err, "stage", "resolve_value", "field", field.Name)
+			return set, err
+		}
+
+		err = client.SetProjectFieldValue(api.SetProjectFieldValueOptions{
+			ProjectID: project.ID,
+			ItemID:    itemID,
+			FieldID:   field.ID,
+			Value:     value,
+		})
+		if err != nil {
+			debug.Error("setProjectFields", err, "stage", "set_value", "field", field.Name)
+			return set, fmt.Errorf("failed to set field %q: %w", field.Name, err)
+		}
+		set = append(set, fmt.Sprintf("%s=%s", field.Name, display))
+	}
+
+	debug.Log("setProjectFields", "result", "success", "set", set)
+	return set, nil
+}
+
+// findProjectField looks a field up by name, ignoring case.
+func findProjectField(fields []api.ProjectField, name string) *api.ProjectField {
+	for i := range fields {
+		if strings.EqualFold(fields[i].Name, name) {
+			return &fields[i]
+		}
+	}
+	return nil
+}
+
+// settableFieldNames lists the fields whose values can be set with --field.
+func settableFieldNames(fields []api.ProjectField) string {
+	var names []string
+	for _, f := range fields {
+		switch f.DataType {
+		case api.ProjectFieldText, api.ProjectFieldNumber, api.ProjectFieldDate,
+			api.ProjectFieldSingleSelect, api.ProjectFieldIteration:
+			names = append(names, fmt.Sprintf("%q", f.Name))
+		}
+	}
+	return strings.Join(names, " ")
+}
+
+// resolveFieldValue converts an assignment into a value for the field's type,
+// prompting when the value was omitted.
+func resolveFieldValue(p Prompter, field *api.ProjectField, a FieldAssignment) (api.ProjectFieldValue, string, error) {
+	switch field.DataType {
+	case api.ProjectFieldSingleSelect, api.ProjectFieldIteration:
+		if len(field.Options) == 0 {
+			return api.ProjectFieldValue{}, "", fmt.Errorf("field %q has no options", field.Name)
+		}
+
+		var option *api.ProjectFieldOption
+		if !a.HasValue {
+			if p == nil {
+				return api.ProjectFieldValue{}, "", fieldNeedsValue(field)
+			}
+			names := make([]string, len(field.Options))
+			for i, o := range field.Options {
+				names[i] = o.Name
+				if o.StartDate != "" {
+					names[i] = fmt.Sprintf("%s (starts %s)", o.Name, o.StartDate)
+				}
+			}
+			idx, err := p.Select(field.Name, "", names)
+			if err != nil {
+				return api.ProjectFieldValue{}, "", err
+			}
+			option = &field.Options[idx]
+		} else {
+			for i := range field.Options {
+				if strings.EqualFold(field.Options[i].Name, a.Value) {
+					option = &field.Options[i]
+					break
+				}
+			}
+			if option == nil {
+				names := make([]string, len(field.Options))
+				for i, o := range field.Options {
+					names[i] = fmt.Sprintf("%q", o.Name)
+				}
+				return api.ProjectFieldValue{}, "", fmt.Errorf("invalid value %q for field %q\nAvailable options: %s", a.Value, field.Name, strings.Join(names, " "))
+			}
+		}
+
+		if field.DataType == api.ProjectFieldIteration {
+			return api.ProjectFieldValue{IterationID: option.ID}, option.Name, nil
+		}
+		return api.ProjectFieldValue{SingleSelectOptionID: option.ID}, option.Name, nil
+
+	case api.ProjectFieldText, api.ProjectFieldNumber, api.ProjectFieldDate:
+		value := a.Value
+		if !a.HasValue {
+			if p == nil {
+				return api.ProjectFieldValue{}, "", fieldNeedsValue(field)
+			}
+			prompt := field.Name
+			if field.DataType == api.ProjectFieldDate {
+				prompt += " (YYYY-MM-DD)"
+			}
+			input, err := p.Input(prompt, "")
+			if err != nil {
+				return api.ProjectFieldValue{}, "", err
+			}
+			value = strings.TrimSpace(input)
+		}
+
+		switch field.DataType {
+		case api.ProjectFieldNumber:
+			n, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				return api.ProjectFieldValue{}, "", fmt.Errorf("invalid number %q for field %q", value, field.Name)
+			}
+			return api.ProjectFieldValue{Number: &n}, value, nil
+		case api.ProjectFieldDate:
+			if _, err := time.Parse("2006-01-02", value); err != nil {
+				return api.ProjectFieldValue{}, "", fmt.Errorf("invalid date %q for field %q (expected YYYY-MM-DD)", value, field.Name)
+			}
+			return api.ProjectFieldValue{Date: value}, value, nil
+		default:
+			return api.ProjectFieldValue{Text: value}, value, nil
+		}
+
+	default:
+		return api.ProjectFieldValue{}, "", fmt.Errorf("field %q has type %s, which cannot be set with --field", field.Name, field.DataType)
+	}
+}
+
+// fieldNeedsValue is the error for a field given without a value in non-interactive mode.
+func fieldNeedsValue(field *api.ProjectField) error {
+	return fmt.Errorf("--field %q requires a value when not running interactively\nExample: --field %q", field.Name, field.Name+"=VALUE")
+}
@@ -0,0 +1,185 @@
+package cmd
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/gwyn/gh-subissue/internal/api"
+)
+
+func TestFieldAssignmentsSet(t *testing.T) {
+	var fields fieldAssignments
+	for _, v := range []string{"Status=In progress", "Estimate = 3", "Sprint", "Note="} {
+		if err := fields.Set(v); err != nil {
+			t.Fatalf("Set(%q) error = %v", v, err)
+		}
+	}
+
+	want := fieldAssignments{
+		{Name: "Status", Value: "In progress", HasValue: true},
+		{Name: "Estimate", Value: "3", HasValue: true},
+		{Name: "Sprint"},
+		{Name: "Note", HasValue: true},
+	}
+	if !reflect.DeepEqual(fields, want) {
+		t.Errorf("fields = %+v, want %+v", fields, want)
+	}
+
+	if err := fields.Set("=value"); err == nil {
+		t.Error("expected error for a field without a name")
+	}
+}
+
+// projectFieldsFixture returns one field of each supported type plus an unsupported one.
+func projectFieldsFixture(projectID string) ([]api.ProjectField, error) {
+	return []api.ProjectField{
+		{ID: "F_status", Name: "Status", DataType: api.ProjectFieldSingleSelect, Options: []api.ProjectFieldOption{
+			{ID: "opt_todo", Name: "Todo"},
+			{ID: "opt_wip", Name: "In progress"},
+		}},
+		{ID: "F_sprint", Name: "Sprint", DataType: api.ProjectFieldIteration, Options: []api.ProjectFieldOption{
+			{ID: "it_1", Name: "Sprint 1", StartDate: "2026-10-05"},
+			{ID: "it_2", Name: "Sprint 2", StartDate: "2026-10-19"},
+		}},
+		{ID: "F_est", Name: "Estimate", DataType: api.ProjectFieldNumber},
+		{ID: "F_due", Name: "Due", DataType: api.ProjectFieldDate},
+		{ID: "F_note", Name: "Note", DataType: api.ProjectFieldText},
+		{ID: "F_assignees", Name: "Assignees", DataType: "ASSIGNEES"},
+	}, nil
+}
+
+func TestSetProjectFields(t *testing.T) {
+	three := 3.0
+	tests := []struct {
+		name       string
+		assignment FieldAssignment
+		prompter   Prompter
+		wantField  string
+		wantValue  api.ProjectFieldValue
+		wantErr    string
+	}{
+		{
+			name:       "single select ignores case",
+			assignment: FieldAssignment{Name: "status", Value: "in progress", HasValue: true},
+			wantField:  "F_status",
+			wantValue:  api.ProjectFieldValue{SingleSelectOptionID: "opt_wip"},
+		},
+		{
+			name:       "iteration by title",
+			assignment: FieldAssignment{Name: "Sprint", Value: "Sprint 2", HasValue: true},
+			wantField:  "F_sprint",
+			wantValue:  api.ProjectFieldValue{IterationID: "it_2"},
+		},
+		{
+			name:       "number",
+			assignment: FieldAssignment{Name: "Estimate", Value: "3", HasValue: true},
+			wantField:  "F_est",
+			wantValue:  api.ProjectFieldValue{Number: &three},
+		},
+		{
+			name:       "date",
+			assignment: FieldAssignment{Name: "Due", Value: "2026-11-01", HasValue: true},
+			wantField:  "F_due",
+			wantValue:  api.ProjectFieldValue{Date: "2026-11-01"},
+		},
+		{
+			name:       "text",
+			assignment: FieldAssignment{Name: "Note", Value: "needs review", HasValue: true},
+			wantField:  "F_note",
+			wantValue:  api.ProjectFieldValue{Text: "needs review"},
+		},
+		{
+			name:       "picker for omitted option",
+			assignment: FieldAssignment{Name: "Sprint"},
+			prompter: &mockPrompter{
+				selectFunc: func(prompt, defaultValue string, options []string) (int, error) {
+					if options[0] != "Sprint 1 (starts 2026-10-05)" {
+						t.Errorf("unexpected options %v", options)
+					}
+					return 0, nil
+				},
+			},
+			wantField: "F_sprint",
+			wantValue: api.ProjectFieldValue{IterationID: "it_1"},
+		},
+		{
+			name:       "input for omitted number",
+			assignment: FieldAssignment{Name: "Estimate"},
+			prompter: &mockPrompter{
+				inputFunc: func(prompt, defaultValue string) (string, error) {
+					return "3", nil
+				},
+			},
+			wantField: "F_est",
+			wantValue: api.ProjectFieldValue{Number: &three},
+		},
+		{
+			name:       "omitted value without prompter",
+			assignment: FieldAssignment{Name: "Status"},
+			wantErr:    "requires a value when not running interactively",
+		},
+		{
+			name:       "unknown option",
+			assignment: FieldAssignment{Name: "Status", Value: "Blocked", HasValue: true},
+			wantErr:    `Available options: "Todo" "In progress"`,
+		},
+		{
+			name:       "invalid number",
+			assignment: FieldAssignment{Name: "Estimate", Value: "three", HasValue: true},
+			wantErr:    "invalid number",
+		},
+		{
+			name:       "invalid date",
+			assignment: FieldAssignment{Name: "Due", Value: "11/01/2026", HasValue: true},
+			wantErr:    "expected YYYY-MM-DD",
+		},
+		{
+			name:       "unknown field",
+			assignment: FieldAssignment{Name: "Priority", Value: "P1", HasValue: true},
+			wantErr:    `Available fields: "Status" "Sprint" "Estimate" "Due" "Note"`,
+		},
+		{
+			name:       "unsupported type",
+			assignment: FieldAssignment{Name: "Assignees", Value: "octocat", HasValue: true},
+			wantErr:    "cannot be set with --field",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got *api.SetProjectFieldValueOptions
+			client := &mockEditAPIClient{
+				listProjectFieldsFunc: projectFieldsFixture,
+				setProjectFieldValueFunc: func(opts api.SetProjectFieldValueOptions) error {
+					got = &opts
+					return nil
+				},
+			}
+
+			project := &api.Project{ID: "PVT_1", Title: "Roadmap"}
+			_, err := setProjectFields(client, tt.prompter, project, "PVTI_1", []FieldAssignment{tt.assignment})
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("error = %v, want %q", err, tt.wantErr)
+				}
+				if got != nil {
+					t.Error("SetProjectFieldValue should not be called on error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("setProjectFields() error = %v", err)
+			}
+			if got == nil {
+				t.Fatal("SetProjectFieldValue was not called")
+			}
+			if got.ProjectID != "PVT_1" || got.ItemID != "PVTI_1" || got.FieldID != tt.wantField {
+				t.Errorf("unexpected target: %+v", got)
+			}
+			if !reflect.DeepEqual(got.Value, tt.wantValue) {
+				t.Errorf("Value = %+v, want %+v", got.Value, tt.wantValue)
+			}
+		})
+	}
+}
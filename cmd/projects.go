@@ -0,0 +1,85 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/gwyn/gh-subissue/internal/api"
+	"github.com/gwyn/gh-subissue/internal/debug"
+)
+
+// ProjectGetter looks up a single project by owner and number.
+type ProjectGetter interface {
+	GetProject(owner string, number int) (*api.Project, error)
+}
+
+// findProject resolves a --project value against the listed projects. The
+// value may be a title, a project number, or OWNER/NUMBER. A bare number
+// that several owners use is resolved in favour of repoOwner. An
+// OWNER/NUMBER that is not listed is fetched directly, so projects of other
+// organizations can be used. Returns nil when nothing matches.
+func findProject(client ProjectGetter, projects []api.Project, value, repoOwner string) (*api.Project, error) {
+	debug.Log("findProject", "value", value, "project_count", len(projects))
+
+	// Titles win, so a project named "2026" is still found by name
+	for i := range projects {
+		if projects[i].Title == value {
+			return &projects[i], nil
+		}
+	}
+	for i := range projects {
+		if strings.EqualFold(projects[i].Title, value) {
+			return &projects[i], nil
+		}
+	}
+
+	if owner, num, ok := strings.Cut(value, "/"); ok {
+		number, err := strconv.Atoi(num)
+		if err != nil || owner == "" || number <= 0 {
+			return nil, nil
+		}
+		for i := range projects {
+			if strings.EqualFold(projects[i].Owner, owner) && projects[i].Number == number {
+				return &projects[i], nil
+			}
+		}
+		project, err := client.GetProject(owner, number)
+		if err != nil {
+			debug.Error("findProject", err, "stage", "get_project", "owner", owner, "number", number)
+			return nil, fmt.Errorf("project %s/%d not found: %w", owner, number, err)
+		}
+		return project, nil
+	}
+
+	number, err := strconv.Atoi(value)
+	if err != nil || number <= 0 {
+		return nil, nil
+	}
+	var matches []*api.Project
+	for i := range projects {
+		if projects[i].Number == number {
+			matches = append(matches, &projects[i])
+		}
+	}
+	if len(matches) > 1 {
+		var preferred []*api.Project
+		for _, p := range matches {
+			if strings.EqualFold(p.Owner, repoOwner) {
+				preferred = append(preferred, p)
+			}
+		}
+		if len(preferred) == 1 {
+			return preferred[0], nil
+		}
+		refs := make([]string, len(matches))
+		for i, p := range matches {
+			refs[i] = fmt.Sprintf("%s/%d", p.Owner, p.Number)
+		}
+		return nil, fmt.Errorf("project number %d is ambiguous; use OWNER/NUMBER: %s", number, strings.Join(refs, ", "))
+	}
+	if len(matches) == 1 {
+		return matches[0], nil
+	}
+	return nil, nil
+}
@@ -0,0 +1,70 @@
+package cmd
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/gwyn/gh-subissue/internal/api"
+)
+
+func TestFindProject(t *testing.T) {
+	projects := []api.Project{
+		{ID: "PVT_1", Title: "Roadmap", Number: 1, Owner: "org"},
+		{ID: "PVT_2", Title: "2026", Number: 2, Owner: "org"},
+		{ID: "PVT_3", Title: "Personal", Number: 1, Owner: "octocat"},
+		{ID: "PVT_4", Title: "Bugs", Number: 5, Owner: "octocat"},
+		{ID: "PVT_5", Title: "Other", Number: 9, Owner: "team-a"},
+		{ID: "PVT_6", Title: "More", Number: 9, Owner: "team-b"},
+	}
+
+	tests := []struct {
+		name    string
+		value   string
+		wantID  string
+		wantErr string
+	}{
+		{name: "exact title", value: "Roadmap", wantID: "PVT_1"},
+		{name: "title ignoring case", value: "roadmap", wantID: "PVT_1"},
+		{name: "numeric title beats number", value: "2026", wantID: "PVT_2"},
+		{name: "unique number", value: "5", wantID: "PVT_4"},
+		{name: "shared number prefers repo owner", value: "1", wantID: "PVT_1"},
+		{name: "owner and number", value: "octocat/1", wantID: "PVT_3"},
+		{name: "owner and number fetched when not listed", value: "elsewhere/4", wantID: "PVT_remote"},
+		{name: "ambiguous number", value: "9", wantErr: "team-a/9, team-b/9"},
+		{name: "unknown owner and number", value: "missing/3", wantErr: "project missing/3 not found"},
+		{name: "no match", value: "Nope"},
+		{name: "unknown number", value: "42"},
+	}
+
+	client := &mockEditAPIClient{
+		getProjectFunc: func(owner string, number int) (*api.Project, error) {
+			if owner == "elsewhere" && number == 4 {
+				return &api.Project{ID: "PVT_remote", Title: "Remote", Number: 4, Owner: "elsewhere"}, nil
+			}
+			return nil, errors.New("not found")
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			project, err := findProject(client, projects, tt.value, "org")
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("findProject() error = %v", err)
+			}
+			gotID := ""
+			if project != nil {
+				gotID = project.ID
+			}
+			if gotID != tt.wantID {
+				t.Errorf("findProject(%q) = %q, want %q", tt.value, gotID, tt.wantID)
+			}
+		})
+	}
+}
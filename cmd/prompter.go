@@ -1,7 +1,142 @@
 package cmd
 
+import (
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+
+	"github.com/cli/go-gh/v2/pkg/prompter"
+	"github.com/google/shlex"
+	"github.com/gwyn/gh-subissue/internal/debug"
+)
+
 // Prompter handles interactive user prompts.
 type Prompter interface {
 	Select(prompt string, defaultValue string, options []string) (int, error)
+	MultiSelect(prompt string, defaultValues, options []string) ([]int, error)
 	Input(prompt, defaultValue string) (string, error)
+	Confirm(prompt string, defaultValue bool) (bool, error)
+	Editor(prompt, defaultValue string) (string, error)
+}
+
+// editorEnvVars are checked in order to find the user's editor.
+var editorEnvVars = []string{"GH_EDITOR", "VISUAL", "EDITOR"}
+
+// EditorCommand returns the editor configured in the environment,
+// falling back to nano (notepad on Windows).
+func EditorCommand(getenv func(string) string) string {
+	for _, name := range editorEnvVars {
+		if editor := strings.TrimSpace(getenv(name)); editor != "" {
+			return editor
+		}
+	}
+	if runtime.GOOS == "windows" {
+		return "notepad"
+	}
+	return "nano"
+}
+
+// TerminalPrompter adds an editor prompt to go-gh's terminal prompter.
+type TerminalPrompter struct {
+	*prompter.Prompter
+	EditorCmd string // editor command line, e.g. "code --wait"
+	Stdin     *os.File
+	Stdout    io.Writer
+	Stderr    io.Writer
+}
+
+// NewTerminalPrompter creates a prompter reading from stdin and writing to stdout and stderr.
+func NewTerminalPrompter(stdin, stdout, stderr *os.File) *TerminalPrompter {
+	return &TerminalPrompter{
+		Prompter:  prompter.New(stdin, stdout, stderr),
+		EditorCmd: EditorCommand(os.Getenv),
+		Stdin:     stdin,
+		Stdout:    stdout,
+		Stderr:    stderr,
+	}
+}
+
+// Editor opens the editor on a temporary file seeded with defaultValue and
+// returns the saved contents.
+func (p *TerminalPrompter) Editor(prompt, defaultValue string) (string, error) {
+	debug.Log("TerminalPrompter.Editor", "prompt", prompt, "editor", p.EditorCmd)
+
+	// Split like a shell so a quoted path with spaces stays one argument
+	args, err := shlex.Split(p.EditorCmd)
+	if err != nil {
+		return "", fmt.Errorf("invalid editor command %q: %w", p.EditorCmd, err)
+	}
+	if len(args) == 0 {
+		return "", fmt.Errorf("no editor configured; set GH_EDITOR, VISUAL or EDITOR")
+	}
+
+	file, err := os.CreateTemp("", "gh-subissue-*.md")
+	if err != nil {
+		debug.Error("TerminalPrompter.Editor", err, "stage", "create_temp")
+		return "", fmt.Errorf("failed to create temporary file: %w", err)
+	}
+	defer os.Remove(file.Name())
+
+	if _, err := file.WriteString(defaultValue); err != nil {
+		file.Close()
+		debug.Error("TerminalPrompter.Editor", err, "stage", "write_temp")
+		return "", fmt.Errorf("failed to write temporary file: %w", err)
+	}
+	if err := file.Close(); err != nil {
+		return "", fmt.Errorf("failed to write temporary file: %w", err)
+	}
+
+	if p.Stderr != nil {
+		fmt.Fprintf(p.Stderr, "%s: waiting for %s to close the file...\n", prompt, args[0])
+	}
+
+	cmd := exec.Command(args[0], append(args[1:], file.Name())...)
+	cmd.Stdin = p.Stdin
+	cmd.Stdout = p.Stdout
+	cmd.Stderr = p.Stderr
+	if err := cmd.Run(); err != nil {
+		debug.Error("TerminalPrompter.Editor", err, "stage", "run_editor")
+		return "", fmt.Errorf("editor %q failed: %w", p.EditorCmd, err)
+	}
+
+	data, err := os.ReadFile(file.Name())
+	if err != nil {
+		debug.Error("TerminalPrompter.Editor", err, "stage", "read_temp")
+		return "", fmt.Errorf("failed to read temporary file: %w", err)
+	}
+	debug.Log("TerminalPrompter.Editor", "bytes_read", len(data))
+	return string(data), nil
+}
+
+// stripComments removes lines that hold only an HTML comment, including
+// comments spanning several lines, as GitHub templates use them for
+// instructions.
+func stripComments(text string) string {
+	lines := strings.Split(text, "\n")
+	kept := make([]string, 0, len(lines))
+	inComment := false
+	for _, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		if inComment {
+			if strings.Contains(trimmed, "-->") {
+				inComment = false
+			}
+			continue
+		}
+		if strings.HasPrefix(trimmed, "<!--") {
+			end := strings.Index(trimmed[4:], "-->")
+			if end == -1 {
+				inComment = true
+				continue
+			}
+			if 4+end+3 == len(trimmed) {
+				continue
+			}
+		}
+		kept = append(kept, line)
+	}
+	return strings.Join(kept, "\n")
 }
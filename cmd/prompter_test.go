@@ -0,0 +1,137 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+)
+
+func TestEditorCommand(t *testing.T) {
+	tests := []struct {
+		name string
+		env  map[string]string
+		want string
+	}{
+		{
+			name: "GH_EDITOR wins",
+			env:  map[string]string{"GH_EDITOR": "vim", "VISUAL": "code --wait", "EDITOR": "nano"},
+			want: "vim",
+		},
+		{
+			name: "VISUAL before EDITOR",
+			env:  map[string]string{"VISUAL": "code --wait", "EDITOR": "nano"},
+			want: "code --wait",
+		},
+		{
+			name: "EDITOR",
+			env:  map[string]string{"EDITOR": "emacs"},
+			want: "emacs",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := EditorCommand(func(name string) string { return tt.env[name] })
+			if got != tt.want {
+				t.Errorf("EditorCommand() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStripComments(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{
+			name: "single line comment",
+			in:   "<!-- hint -->\nbody",
+			want: "body",
+		},
+		{
+			name: "indented multi-line comment",
+			in:   "a\n  <!--\n  hint\n  -->\nb",
+			want: "a\nb",
+		},
+		{
+			name: "inline comment kept",
+			in:   "text <!-- note -->\n<!-- x --> after",
+			want: "text <!-- note -->\n<!-- x --> after",
+		},
+		{
+			name: "no comments",
+			in:   "line one\nline two\n",
+			want: "line one\nline two\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := stripComments(tt.in); got != tt.want {
+				t.Errorf("stripComments() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTerminalPrompterEditor(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses a shell script as the editor")
+	}
+
+	// The fake editor checks the seed and appends a line.
+	script := filepath.Join(t.TempDir(), "editor.sh")
+	content := "#!/bin/sh\ngrep -q '^seed$' \"$1\" || exit 1\necho edited >> \"$1\"\n"
+	if err := os.WriteFile(script, []byte(content), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	var stderr bytes.Buffer
+	p := &TerminalPrompter{EditorCmd: script, Stdout: &bytes.Buffer{}, Stderr: &stderr}
+
+	got, err := p.Editor("Body", "seed\n")
+	if err != nil {
+		t.Fatalf("Editor() error = %v", err)
+	}
+	if got != "seed\nedited\n" {
+		t.Errorf("Editor() = %q, want %q", got, "seed\nedited\n")
+	}
+}
+
+func TestTerminalPrompterEditorQuotedPath(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses a shell script as the editor")
+	}
+
+	// An editor installed under a path with spaces, as on macOS:
+	// "/Applications/Sublime Text.app/Contents/SharedSupport/bin/subl" -w
+	dir := filepath.Join(t.TempDir(), "Sublime Text.app", "bin")
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	script := filepath.Join(dir, "subl")
+	content := "#!/bin/sh\n[ \"$1\" = -w ] || exit 1\necho edited >> \"$2\"\n"
+	if err := os.WriteFile(script, []byte(content), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	p := &TerminalPrompter{EditorCmd: `"` + script + `" -w`, Stdout: &bytes.Buffer{}, Stderr: &bytes.Buffer{}}
+	got, err := p.Editor("Body", "")
+	if err != nil {
+		t.Fatalf("Editor() error = %v", err)
+	}
+	if got != "edited\n" {
+		t.Errorf("Editor() = %q, want %q", got, "edited\n")
+	}
+}
+
+func TestTerminalPrompterEditorFailure(t *testing.T) {
+	p := &TerminalPrompter{EditorCmd: filepath.Join(t.TempDir(), "missing-editor")}
+	if _, err := p.Editor("Body", ""); err == nil {
+		t.Error("expected error when the editor cannot run")
+	}
+}
@@ -0,0 +1,202 @@
+package cmd
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/gwyn/gh-subissue/internal/api"
+	"github.com/gwyn/gh-subissue/internal/debug"
+)
+
+// RemoveOptions contains the parsed command line options for the remove command.
+type RemoveOptions struct {
+	Parent int
+	Issues []string // sub-issue numbers or URLs to unlink
+	Repo   string
+	Yes    bool
+}
+
+// ParseRemoveFlags parses command line flags for the remove command.
+func ParseRemoveFlags(args []string) (*RemoveOptions, error) {
+	debug.Log("ParseRemoveFlags", "args", args)
+
+	opts := &RemoveOptions{}
+	fs := flag.NewFlagSet("remove", flag.ContinueOnError)
+
+	fs.IntVar(&opts.Parent, "parent", 0, "Parent issue number")
+	fs.IntVar(&opts.Parent, "p", 0, "Parent issue number")
+
+	fs.StringVar(&opts.Repo, "repo", "", "Repository (owner/repo)")
+	fs.StringVar(&opts.Repo, "R", "", "Repository (owner/repo)")
+
+	fs.BoolVar(&opts.Yes, "yes", false, "Skip the confirmation prompt")
+	fs.BoolVar(&opts.Yes, "y", false, "Skip the confirmation prompt")
+
+	positional, flagArgs := splitArgs(args, "-p", "--parent", "-parent", "-R", "--repo", "-repo")
+	if err := fs.Parse(flagArgs); err != nil {
+		debug.Error("ParseRemoveFlags", err, "stage", "fs.Parse")
+		return nil, err
+	}
+	opts.Issues = positional
+
+	debug.Log("ParseRemoveFlags", "parsed", fmt.Sprintf("%+v", opts))
+	return opts, nil
+}
+
+// RemoveAPIClient defines the interface for remove operations.
+type RemoveAPIClient interface {
+	ListIssues(opts api.ListIssuesOptions) ([]api.Issue, error)
+	ListSubIssues(opts api.ListSubIssuesOptions) ([]api.Issue, error)
+	RemoveSubIssue(opts api.RemoveSubIssueOptions) error
+}
+
+// RemoveRunner executes the remove subcommand.
+type RemoveRunner struct {
+	Client   RemoveAPIClient
+	Owner    string
+	Repo     string
+	Out      io.Writer
+	Prompter Prompter // nil means non-interactive mode
+}
+
+// Run executes the remove command.
+func (r *RemoveRunner) Run(opts RemoveOptions) error {
+	debug.Log("RemoveRunner.Run", "parent", opts.Parent, "issues", opts.Issues, "yes", opts.Yes, "has_prompter", r.Prompter != nil)
+
+	parent := opts.Parent
+
+	// If no parent specified, prompt for selection
+	if parent == 0 {
+		if r.Prompter == nil {
+			return fmt.Errorf("--parent flag is required when not running interactively\nTo find parent issues: gh issue list -R %s/%s", r.Owner, r.Repo)
+		}
+
+		issues, err := r.Client.ListIssues(api.ListIssuesOptions{
+			Owner: r.Owner,
+			Repo:  r.Repo,
+			State: "open",
+			Limit: pickerIssueLimit,
+		})
+		if err != nil {
+			debug.Error("RemoveRunner.Run", err, "stage", "list_issues")
+			return fmt.Errorf("failed to list issues: %w", err)
+		}
+
+		selected, err := SelectParentIssue(r.Prompter, issues)
+		if err != nil {
+			debug.Error("RemoveRunner.Run", err, "stage", "select_parent")
+			return err
+		}
+		parent = selected
+	}
+
+	// The parent's current children provide the IDs needed for unlinking
+	children, err := r.Client.ListSubIssues(api.ListSubIssuesOptions{
+		Owner:       r.Owner,
+		Repo:        r.Repo,
+		ParentIssue: parent,
+	})
+	if err != nil {
+		debug.Error("RemoveRunner.Run", err, "stage", "list_sub_issues")
+		return err
+	}
+
+	var targets []api.Issue
+	var failed int
+	total := len(opts.Issues)
+	if len(opts.Issues) == 0 {
+		if r.Prompter == nil {
+			return fmt.Errorf("at least one sub-issue is required when not running interactively\nTo see sub-issues: gh subissue list --parent %d", parent)
+		}
+		if len(children) == 0 {
+			return fmt.Errorf("issue #%d has no sub-issues", parent)
+		}
+
+		targets, err = SelectIssues(r.Prompter, fmt.Sprintf("Select sub-issues to remove from #%d", parent), children)
+		if err != nil {
+			debug.Error("RemoveRunner.Run", err, "stage", "select_sub_issues")
+			return err
+		}
+		total = len(targets)
+	} else {
+		for _, arg := range opts.Issues {
+			child, err := r.findChild(children, arg)
+			if err != nil {
+				debug.Error("RemoveRunner.Run", err, "stage", "find_child", "arg", arg)
+				fmt.Fprintf(r.Out, "Failed to remove %s: %v\n", arg, err)
+				failed++
+				continue
+			}
+			targets = append(targets, child)
+		}
+	}
+
+	// Confirm before unlinking unless --yes was given
+	if len(targets) > 0 && !opts.Yes {
+		if r.Prompter == nil {
+			return errors.New("--yes is required when not running interactively")
+		}
+
+		names := make([]string, len(targets))
+		for i, t := range targets {
+			names[i] = fmt.Sprintf("#%d", t.Number)
+		}
+		confirmed, err := r.Prompter.Confirm(fmt.Sprintf("Remove %s from #%d?", strings.Join(names, ", "), parent), false)
+		if err != nil {
+			debug.Error("RemoveRunner.Run", err, "stage", "confirm")
+			return err
+		}
+		if !confirmed {
+			debug.Log("RemoveRunner.Run", "result", "cancelled")
+			return errors.New("removal cancelled")
+		}
+	}
+
+	for _, child := range targets {
+		err := r.Client.RemoveSubIssue(api.RemoveSubIssueOptions{
+			Owner:       r.Owner,
+			Repo:        r.Repo,
+			ParentIssue: parent,
+			SubIssueID:  child.ID,
+		})
+		if err != nil {
+			debug.Error("RemoveRunner.Run", err, "stage", "remove_sub_issue", "sub_issue", child.Number)
+			fmt.Fprintf(r.Out, "Failed to remove #%d: %v\n", child.Number, err)
+			failed++
+			continue
+		}
+		fmt.Fprintf(r.Out, "Removed #%d from #%d\n", child.Number, parent)
+	}
+
+	if failed > 0 {
+		err := fmt.Errorf("failed to remove %d of %d sub-issues", failed, total)
+		debug.Error("RemoveRunner.Run", err)
+		return err
+	}
+
+	debug.Log("RemoveRunner.Run", "result", "success", "removed", len(targets))
+	return nil
+}
+
+// findChild matches an issue number or URL against the parent's sub-issues.
+// A bare number prefers a sub-issue in the current repository; when it
+// matches sub-issues in several other repositories, the argument has to say
+// which one.
+func (r *RemoveRunner) findChild(children []api.Issue, arg string) (api.Issue, error) {
+	ref, err := ParseIssueRef(arg)
+	if err != nil {
+		return api.Issue{}, err
+	}
+
+	idx, err := findIssue(children, ref, r.Owner, r.Repo)
+	if err != nil {
+		return api.Issue{}, err
+	}
+	if idx < 0 {
+		return api.Issue{}, errors.New("not a sub-issue of this parent")
+	}
+	return children[idx], nil
+}
@@ -0,0 +1,316 @@
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/gwyn/gh-subissue/internal/api"
+)
+
+func TestParseRemoveFlags(t *testing.T) {
+	tests := []struct {
+		name       string
+		args       []string
+		wantParent int
+		wantIssues []string
+		wantYes    bool
+		wantErr    bool
+	}{
+		{
+			name:       "issue with parent and yes",
+			args:       []string{"43", "--parent", "42", "--yes"},
+			wantParent: 42,
+			wantIssues: []string{"43"},
+			wantYes:    true,
+		},
+		{
+			name:       "short flags",
+			args:       []string{"-p", "42", "-y", "43", "44"},
+			wantParent: 42,
+			wantIssues: []string{"43", "44"},
+			wantYes:    true,
+		},
+		{
+			name:       "no issues",
+			args:       []string{"-p", "42"},
+			wantParent: 42,
+		},
+		{
+			name:    "unknown flag",
+			args:    []string{"--bogus"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts, err := ParseRemoveFlags(tt.args)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ParseRemoveFlags() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			if opts.Parent != tt.wantParent {
+				t.Errorf("Parent = %d, want %d", opts.Parent, tt.wantParent)
+			}
+			if strings.Join(opts.Issues, ",") != strings.Join(tt.wantIssues, ",") {
+				t.Errorf("Issues = %v, want %v", opts.Issues, tt.wantIssues)
+			}
+			if opts.Yes != tt.wantYes {
+				t.Errorf("Yes = %v, want %v", opts.Yes, tt.wantYes)
+			}
+		})
+	}
+}
+
+// mockRemoveAPIClient implements the RemoveAPIClient interface for testing.
+type mockRemoveAPIClient struct {
+	listIssuesFunc     func(opts api.ListIssuesOptions) ([]api.Issue, error)
+	listSubIssuesFunc  func(opts api.ListSubIssuesOptions) ([]api.Issue, error)
+	removeSubIssueFunc func(opts api.RemoveSubIssueOptions) error
+}
+
+func (m *mockRemoveAPIClient) ListIssues(opts api.ListIssuesOptions) ([]api.Issue, error) {
+	if m.listIssuesFunc != nil {
+		return m.listIssuesFunc(opts)
+	}
+	return []api.Issue{}, nil
+}
+
+func (m *mockRemoveAPIClient) ListSubIssues(opts api.ListSubIssuesOptions) ([]api.Issue, error) {
+	if m.listSubIssuesFunc != nil {
+		return m.listSubIssuesFunc(opts)
+	}
+	return []api.Issue{
+		{ID: 430, Number: 43, Title: "Task A", URL: "https://github.com/owner/repo/issues/43"},
+		{ID: 440, Number: 44, Title: "Task B", URL: "https://github.com/owner/repo/issues/44"},
+	}, nil
+}
+
+func (m *mockRemoveAPIClient) RemoveSubIssue(opts api.RemoveSubIssueOptions) error {
+	if m.removeSubIssueFunc != nil {
+		return m.removeSubIssueFunc(opts)
+	}
+	return nil
+}
+
+// Compile-time check
+var _ RemoveAPIClient = (*mockRemoveAPIClient)(nil)
+
+func TestRemoveRunnerWithYes(t *testing.T) {
+	var removed []int64
+	client := &mockRemoveAPIClient{
+		removeSubIssueFunc: func(opts api.RemoveSubIssueOptions) error {
+			if opts.ParentIssue != 42 {
+				t.Errorf("expected parent 42, got %d", opts.ParentIssue)
+			}
+			removed = append(removed, opts.SubIssueID)
+			return nil
+		},
+	}
+
+	var output bytes.Buffer
+	runner := &RemoveRunner{
+		Client: client,
+		Owner:  "owner",
+		Repo:   "repo",
+		Out:    &output,
+	}
+
+	err := runner.Run(RemoveOptions{Parent: 42, Issues: []string{"44"}, Yes: true})
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	if len(removed) != 1 || removed[0] != 440 {
+		t.Errorf("removed IDs = %v, want [440]", removed)
+	}
+	if output.String() != "Removed #44 from #42\n" {
+		t.Errorf("output = %q", output.String())
+	}
+}
+
+func TestRemoveRunnerRequiresYesWithoutPrompter(t *testing.T) {
+	client := &mockRemoveAPIClient{
+		removeSubIssueFunc: func(opts api.RemoveSubIssueOptions) error {
+			t.Error("RemoveSubIssue should not be called without confirmation")
+			return nil
+		},
+	}
+
+	var output bytes.Buffer
+	runner := &RemoveRunner{
+		Client: client,
+		Owner:  "owner",
+		Repo:   "repo",
+		Out:    &output,
+	}
+
+	err := runner.Run(RemoveOptions{Parent: 42, Issues: []string{"43"}})
+	if err == nil || !strings.Contains(err.Error(), "--yes") {
+		t.Errorf("expected --yes error, got %v", err)
+	}
+}
+
+func TestRemoveRunnerNotAChild(t *testing.T) {
+	var output bytes.Buffer
+	runner := &RemoveRunner{
+		Client: &mockRemoveAPIClient{},
+		Owner:  "owner",
+		Repo:   "repo",
+		Out:    &output,
+	}
+
+	err := runner.Run(RemoveOptions{Parent: 42, Issues: []string{"43", "99"}, Yes: true})
+	if err == nil {
+		t.Fatal("expected error when an issue is not a sub-issue")
+	}
+	if !strings.Contains(output.String(), "Removed #43 from #42") {
+		t.Errorf("expected #43 to be removed, got %q", output.String())
+	}
+	if !strings.Contains(output.String(), "Failed to remove 99: not a sub-issue") {
+		t.Errorf("expected failure line for 99, got %q", output.String())
+	}
+}
+
+func TestRemoveRunnerInteractivePickAndConfirm(t *testing.T) {
+	var removed []int64
+	client := &mockRemoveAPIClient{
+		removeSubIssueFunc: func(opts api.RemoveSubIssueOptions) error {
+			removed = append(removed, opts.SubIssueID)
+			return nil
+		},
+	}
+
+	var confirmPrompt string
+	prompter := &mockPrompter{
+		multiSelectFunc: func(prompt string, defaultValues, options []string) ([]int, error) {
+			return []int{1}, nil
+		},
+		confirmFunc: func(prompt string, defaultValue bool) (bool, error) {
+			confirmPrompt = prompt
+			return true, nil
+		},
+	}
+
+	var output bytes.Buffer
+	runner := &RemoveRunner{
+		Client:   client,
+		Owner:    "owner",
+		Repo:     "repo",
+		Out:      &output,
+		Prompter: prompter,
+	}
+
+	if err := runner.Run(RemoveOptions{Parent: 42}); err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	if confirmPrompt != "Remove #44 from #42?" {
+		t.Errorf("confirm prompt = %q", confirmPrompt)
+	}
+	if len(removed) != 1 || removed[0] != 440 {
+		t.Errorf("removed IDs = %v, want [440]", removed)
+	}
+}
+
+func TestRemoveRunnerConfirmDeclined(t *testing.T) {
+	client := &mockRemoveAPIClient{
+		removeSubIssueFunc: func(opts api.RemoveSubIssueOptions) error {
+			t.Error("RemoveSubIssue should not be called when declined")
+			return nil
+		},
+	}
+
+	prompter := &mockPrompter{
+		confirmFunc: func(prompt string, defaultValue bool) (bool, error) {
+			return false, nil
+		},
+	}
+
+	var output bytes.Buffer
+	runner := &RemoveRunner{
+		Client:   client,
+		Owner:    "owner",
+		Repo:     "repo",
+		Out:      &output,
+		Prompter: prompter,
+	}
+
+	if err := runner.Run(RemoveOptions{Parent: 42, Issues: []string{"43"}}); err == nil {
+		t.Error("expected error when confirmation is declined")
+	}
+}
+
+func TestRemoveRunnerAPIError(t *testing.T) {
+	client := &mockRemoveAPIClient{
+		removeSubIssueFunc: func(opts api.RemoveSubIssueOptions) error {
+			return errors.New("forbidden")
+		},
+	}
+
+	var output bytes.Buffer
+	runner := &RemoveRunner{
+		Client: client,
+		Owner:  "owner",
+		Repo:   "repo",
+		Out:    &output,
+	}
+
+	err := runner.Run(RemoveOptions{Parent: 42, Issues: []string{"43"}, Yes: true})
+	if err == nil {
+		t.Fatal("expected error when removal fails")
+	}
+	if !strings.Contains(output.String(), "Failed to remove #43: forbidden") {
+		t.Errorf("output = %q", output.String())
+	}
+}
+
+func TestRemoveRunnerFindChildAcrossRepos(t *testing.T) {
+	children := []api.Issue{
+		{ID: 1430, Number: 43, Title: "Lib task", RepositoryURL: "https://api.github.com/repos/other/lib"},
+		{ID: 430, Number: 43, Title: "Task A", RepositoryURL: "https://api.github.com/repos/owner/repo"},
+		{ID: 2500, Number: 50, Title: "Docs", RepositoryURL: "https://api.github.com/repos/other/docs"},
+		{ID: 1500, Number: 50, Title: "Lib docs", RepositoryURL: "https://api.github.com/repos/other/lib"},
+		{ID: 600, Number: 60, Title: "Site", RepositoryURL: "https://api.github.com/repos/other/site"},
+	}
+	runner := &RemoveRunner{Owner: "owner", Repo: "repo"}
+
+	tests := []struct {
+		name    string
+		arg     string
+		wantID  int64
+		wantErr string
+	}{
+		{name: "bare number prefers the current repository", arg: "43", wantID: 430},
+		{name: "qualified reference", arg: "other/lib#43", wantID: 1430},
+		{name: "URL", arg: "https://github.com/other/lib/issues/50", wantID: 1500},
+		{name: "only match in another repository", arg: "60", wantID: 600},
+		{
+			name:    "ambiguous across other repositories",
+			arg:     "50",
+			wantErr: "#50 matches sub-issues in several repositories (other/docs#50, other/lib#50); use owner/repo#50 to pick one",
+		},
+		{name: "qualified reference not a child", arg: "other/lib#60", wantErr: "not a sub-issue of this parent"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			child, err := runner.findChild(children, tt.arg)
+			if tt.wantErr != "" {
+				if err == nil || err.Error() != tt.wantErr {
+					t.Errorf("findChild() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("findChild() error = %v", err)
+			}
+			if child.ID != tt.wantID {
+				t.Errorf("findChild() = %d, want %d", child.ID, tt.wantID)
+			}
+		})
+	}
+}
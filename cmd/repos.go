@@ -4,6 +4,7 @@ import (
 	"flag"
 	"fmt"
 	"io"
+	"slices"
 
 	"github.com/gwyn/gh-subissue/internal/api"
 	"github.com/gwyn/gh-subissue/internal/debug"
@@ -16,6 +17,7 @@ type ReposOptions struct {
 	Enabled  bool
 	Disabled bool
 	NoHeader bool
+	Export   ExportOptions
 }
 
 // ParseReposFlags parses command line flags for the repos command.
@@ -32,6 +34,8 @@ func ParseReposFlags(args []string) (*ReposOptions, error) {
 	fs.BoolVar(&opts.Disabled, "disabled", false, "Show only repos where sub-issues don't work")
 	fs.BoolVar(&opts.NoHeader, "no-header", false, "Omit table header from output")
 
+	registerExportFlags(fs, &opts.Export)
+
 	// Extract positional owner arg before flags (if present)
 	// Go's flag package stops at the first non-flag, so we need to
 	// reorder args to put flags first
@@ -46,7 +50,7 @@ func ParseReposFlags(args []string) (*ReposOptions, error) {
 		if len(arg) > 0 && arg[0] == '-' {
 			flagArgs = append(flagArgs, arg)
 			// Check if this flag takes a value
-			if arg == "-L" || arg == "--limit" {
+			if arg == "-L" || arg == "--limit" || slices.Contains(exportFlagArgs, arg) {
 				if i+1 < len(args) {
 					i++
 					flagArgs = append(flagArgs, args[i])
@@ -63,6 +67,11 @@ func ParseReposFlags(args []string) (*ReposOptions, error) {
 		return nil, err
 	}
 
+	if err := opts.Export.validate(api.RepositoryFields); err != nil {
+		debug.Error("ParseReposFlags", err, "stage", "validate_export")
+		return nil, err
+	}
+
 	debug.Log("ParseReposFlags", "parsed", fmt.Sprintf("%+v", opts))
 	return opts, nil
 }
@@ -96,36 +105,14 @@ func (r *ReposRunner) Run(opts ReposOptions) error {
 		debug.Log("ReposRunner.Run", "resolved_owner", owner)
 	}
 
-	// Fetch repositories (may need pagination for large limits)
-	var allRepos []api.Repository
-	perPage := 100
-	if opts.Limit < perPage {
-		perPage = opts.Limit
-	}
-
-	page := 1
-	for len(allRepos) < opts.Limit {
-		repos, err := r.Client.ListRepositories(api.ListRepositoriesOptions{
-			Owner:   owner,
-			PerPage: perPage,
-			Page:    page,
-		})
-		if err != nil {
-			debug.Error("ReposRunner.Run", err, "stage", "list_repositories")
-			return err
-		}
-
-		if len(repos) == 0 {
-			break
-		}
-
-		allRepos = append(allRepos, repos...)
-		page++
-
-		// If we got fewer than requested, no more pages
-		if len(repos) < perPage {
-			break
-		}
+	// Fetch repositories; the client follows pagination up to the limit
+	allRepos, err := r.Client.ListRepositories(api.ListRepositoriesOptions{
+		Owner: owner,
+		Limit: opts.Limit,
+	})
+	if err != nil {
+		debug.Error("ReposRunner.Run", err, "stage", "list_repositories")
+		return err
 	}
 
 	// Truncate to limit
@@ -146,6 +133,10 @@ func (r *ReposRunner) Run(opts ReposOptions) error {
 		filtered = append(filtered, repo)
 	}
 
+	if opts.Export.Enabled() {
+		return opts.Export.Write(r.Out, exportList(filtered, opts.Export.Fields))
+	}
+
 	if len(filtered) == 0 {
 		if opts.Enabled || opts.Disabled {
 			fmt.Fprintf(r.Out, "No matching repositories found for %s\n", owner)
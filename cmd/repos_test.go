@@ -66,11 +66,23 @@ func TestParseReposFlags(t *testing.T) {
 			wantLimit:    10,
 			wantErr:      false,
 		},
+		{
+			name:      "json flag before owner",
+			args:      []string{"--json", "fullName", "myorg"},
+			wantOwner: "myorg",
+			wantLimit: 30,
+			wantErr:   false,
+		},
 		{
 			name:    "invalid limit",
 			args:    []string{"-L", "notanumber"},
 			wantErr: true,
 		},
+		{
+			name:    "unknown json field",
+			args:    []string{"--json", "stars"},
+			wantErr: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -179,6 +191,16 @@ func TestReposRunnerRun(t *testing.T) {
 			wantOutput: "testorg/repo1       enabled\n",
 			wantErr:    false,
 		},
+		{
+			name: "jq output",
+			opts: ReposOptions{Owner: "testorg", Limit: 30, Export: ExportOptions{Fields: []string{"fullName", "hasIssues"}, JQ: ".[] | select(.hasIssues) | .fullName"}},
+			repos: []api.Repository{
+				{Name: "repo1", FullName: "testorg/repo1", HasIssues: true},
+				{Name: "repo2", FullName: "testorg/repo2", HasIssues: false},
+			},
+			wantOutput: "testorg/repo1\n",
+			wantErr:    false,
+		},
 	}
 
 	for _, tt := range tests {
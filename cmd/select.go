@@ -8,6 +8,10 @@ import (
 	"github.com/gwyn/gh-subissue/internal/debug"
 )
 
+// pickerIssueLimit is how many open issues the interactive pickers offer.
+// It fits in a single page, so a picker makes one list request.
+const pickerIssueLimit = 30
+
 // SelectParentIssue prompts user to select an issue from a list.
 // Returns the selected issue number.
 func SelectParentIssue(p Prompter, issues []api.Issue) (int, error) {
@@ -19,15 +23,7 @@ func SelectParentIssue(p Prompter, issues []api.Issue) (int, error) {
 		return 0, err
 	}
 
-	options := make([]string, len(issues))
-	for i, issue := range issues {
-		// Format: "#123 Issue title" (truncate title if needed)
-		title := issue.Title
-		if len(title) > 50 {
-			title = title[:47] + "..."
-		}
-		options[i] = fmt.Sprintf("#%d %s", issue.Number, title)
-	}
+	options := issueOptions(issues)
 
 	debug.Log("SelectParentIssue", "action", "prompting_user", "options_count", len(options))
 	idx, err := p.Select("Select parent issue", "", options)
@@ -41,6 +37,54 @@ func SelectParentIssue(p Prompter, issues []api.Issue) (int, error) {
 	return selectedNumber, nil
 }
 
+// SelectIssues prompts user to select one or more issues from a list.
+// Returns the selected issues in list order.
+func SelectIssues(p Prompter, prompt string, issues []api.Issue) ([]api.Issue, error) {
+	debug.Log("SelectIssues", "issue_count", len(issues))
+
+	if len(issues) == 0 {
+		err := fmt.Errorf("no issues available to select")
+		debug.Error("SelectIssues", err)
+		return nil, err
+	}
+
+	options := issueOptions(issues)
+
+	debug.Log("SelectIssues", "action", "prompting_user", "options_count", len(options))
+	indexes, err := p.MultiSelect(prompt, nil, options)
+	if err != nil {
+		debug.Error("SelectIssues", err, "stage", "prompt_multiselect")
+		return nil, err
+	}
+
+	if len(indexes) == 0 {
+		err := fmt.Errorf("no issues selected")
+		debug.Error("SelectIssues", err)
+		return nil, err
+	}
+
+	selected := make([]api.Issue, len(indexes))
+	for i, idx := range indexes {
+		selected[i] = issues[idx]
+	}
+	debug.Log("SelectIssues", "selected_count", len(selected))
+	return selected, nil
+}
+
+// issueOptions formats issues as prompt options: "#123 Issue title",
+// truncating long titles.
+func issueOptions(issues []api.Issue) []string {
+	options := make([]string, len(issues))
+	for i, issue := range issues {
+		title := issue.Title
+		if len(title) > 50 {
+			title = title[:47] + "..."
+		}
+		options[i] = fmt.Sprintf("#%d %s", issue.Number, title)
+	}
+	return options
+}
+
 // SelectProject prompts user to select a project from a list.
 // Returns the selected project.
 func SelectProject(p Prompter, projects []api.Project) (*api.Project, error) {
@@ -54,8 +98,12 @@ func SelectProject(p Prompter, projects []api.Project) (*api.Project, error) {
 
 	options := make([]string, len(projects))
 	for i, project := range projects {
-		// Format: "Project Title (#N)"
-		options[i] = fmt.Sprintf("%s (#%d)", project.Title, project.Number)
+		// Format: "Project Title (owner/N)", or "Project Title (#N)" without an owner
+		if project.Owner != "" {
+			options[i] = fmt.Sprintf("%s (%s/%d)", project.Title, project.Owner, project.Number)
+		} else {
+			options[i] = fmt.Sprintf("%s (#%d)", project.Title, project.Number)
+		}
 	}
 
 	debug.Log("SelectProject", "action", "prompting_user", "options_count", len(options))
@@ -70,6 +118,39 @@ func SelectProject(p Prompter, projects []api.Project) (*api.Project, error) {
 	return selected, nil
 }
 
+// blankIssueOption is the picker entry for creating an issue without a template.
+const blankIssueOption = "Open a blank issue"
+
+// SelectIssueTemplate prompts user to choose an issue template.
+// Returns nil when the user picks a blank issue.
+func SelectIssueTemplate(p Prompter, templates []*issueTemplate) (*issueTemplate, error) {
+	debug.Log("SelectIssueTemplate", "template_count", len(templates))
+
+	options := make([]string, 0, len(templates)+1)
+	for _, tmpl := range templates {
+		option := tmpl.Name
+		if tmpl.About != "" {
+			option = fmt.Sprintf("%s - %s", tmpl.Name, tmpl.About)
+		}
+		options = append(options, option)
+	}
+	options = append(options, blankIssueOption)
+
+	debug.Log("SelectIssueTemplate", "action", "prompting_user", "options_count", len(options))
+	idx, err := p.Select("Choose a template", "", options)
+	if err != nil {
+		debug.Error("SelectIssueTemplate", err, "stage", "prompt_select")
+		return nil, err
+	}
+
+	if idx >= len(templates) {
+		debug.Log("SelectIssueTemplate", "selected", "blank")
+		return nil, nil
+	}
+	debug.Log("SelectIssueTemplate", "selected_index", idx, "selected_template", templates[idx].Name)
+	return templates[idx], nil
+}
+
 // PromptRepository prompts user to enter a repository in owner/repo format.
 // Returns the owner and repo name separately.
 func PromptRepository(p Prompter) (string, string, error) {
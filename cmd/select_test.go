@@ -9,8 +9,11 @@ import (
 
 // mockPrompter implements Prompter for testing.
 type mockPrompter struct {
-	selectFunc func(prompt string, defaultValue string, options []string) (int, error)
-	inputFunc  func(prompt, defaultValue string) (string, error)
+	selectFunc      func(prompt string, defaultValue string, options []string) (int, error)
+	multiSelectFunc func(prompt string, defaultValues, options []string) ([]int, error)
+	inputFunc       func(prompt, defaultValue string) (string, error)
+	confirmFunc     func(prompt string, defaultValue bool) (bool, error)
+	editorFunc      func(prompt, defaultValue string) (string, error)
 }
 
 func (m *mockPrompter) Select(prompt string, defaultValue string, options []string) (int, error) {
@@ -20,6 +23,13 @@ func (m *mockPrompter) Select(prompt string, defaultValue string, options []stri
 	return 0, nil
 }
 
+func (m *mockPrompter) MultiSelect(prompt string, defaultValues, options []string) ([]int, error) {
+	if m.multiSelectFunc != nil {
+		return m.multiSelectFunc(prompt, defaultValues, options)
+	}
+	return nil, nil
+}
+
 func (m *mockPrompter) Input(prompt, defaultValue string) (string, error) {
 	if m.inputFunc != nil {
 		return m.inputFunc(prompt, defaultValue)
@@ -27,6 +37,23 @@ func (m *mockPrompter) Input(prompt, defaultValue string) (string, error) {
 	return "", nil
 }
 
+func (m *mockPrompter) Confirm(prompt string, defaultValue bool) (bool, error) {
+	if m.confirmFunc != nil {
+		return m.confirmFunc(prompt, defaultValue)
+	}
+	return defaultValue, nil
+}
+
+func (m *mockPrompter) Editor(prompt, defaultValue string) (string, error) {
+	if m.editorFunc != nil {
+		return m.editorFunc(prompt, defaultValue)
+	}
+	if defaultValue != "" {
+		return defaultValue, nil
+	}
+	return "Body from editor", nil
+}
+
 // Compile-time check: mockPrompter must implement Prompter
 var _ Prompter = (*mockPrompter)(nil)
 
@@ -276,3 +303,44 @@ func TestSelectProject(t *testing.T) {
 		})
 	}
 }
+
+func TestSelectIssues(t *testing.T) {
+	issues := []api.Issue{
+		{ID: 100, Number: 10, Title: "First issue"},
+		{ID: 200, Number: 20, Title: "Second issue"},
+		{ID: 300, Number: 30, Title: "Third issue"},
+	}
+
+	t.Run("returns selected issues", func(t *testing.T) {
+		p := &mockPrompter{
+			multiSelectFunc: func(prompt string, defaultValues, options []string) ([]int, error) {
+				if len(options) != 3 || options[1] != "#20 Second issue" {
+					t.Errorf("unexpected options: %v", options)
+				}
+				return []int{0, 2}, nil
+			},
+		}
+
+		selected, err := SelectIssues(p, "Select issues", issues)
+		if err != nil {
+			t.Fatalf("SelectIssues() error = %v", err)
+		}
+		if len(selected) != 2 || selected[0].Number != 10 || selected[1].Number != 30 {
+			t.Errorf("SelectIssues() = %v, want #10 and #30", selected)
+		}
+	})
+
+	t.Run("nothing selected returns error", func(t *testing.T) {
+		p := &mockPrompter{}
+		if _, err := SelectIssues(p, "Select issues", issues); err == nil {
+			t.Error("expected error when nothing is selected")
+		}
+	})
+
+	t.Run("empty issue list returns error", func(t *testing.T) {
+		p := &mockPrompter{}
+		if _, err := SelectIssues(p, "Select issues", nil); err == nil {
+			t.Error("expected error for empty issue list")
+		}
+	})
+}
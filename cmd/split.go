@@ -0,0 +1,235 @@
+package cmd
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+
+	"github.com/gwyn/gh-subissue/internal/api"
+	"github.com/gwyn/gh-subissue/internal/debug"
+)
+
+// SplitOptions contains the parsed command line options for the split command.
+type SplitOptions struct {
+	Parent string // parent issue number or URL (interactive if empty)
+	Repo   string
+	DryRun bool
+}
+
+// ParseSplitFlags parses command line flags for the split command.
+func ParseSplitFlags(args []string) (*SplitOptions, error) {
+	debug.Log("ParseSplitFlags", "args", args)
+
+	opts := &SplitOptions{}
+	fs := flag.NewFlagSet("split", flag.ContinueOnError)
+
+	fs.StringVar(&opts.Repo, "repo", "", "Repository (owner/repo)")
+	fs.StringVar(&opts.Repo, "R", "", "Repository (owner/repo)")
+
+	fs.BoolVar(&opts.DryRun, "dry-run", false, "Show the planned sub-issues and rewritten body without changing anything")
+
+	positional, flagArgs := splitArgs(args, "-R", "--repo", "-repo")
+	if err := fs.Parse(flagArgs); err != nil {
+		debug.Error("ParseSplitFlags", err, "stage", "fs.Parse")
+		return nil, err
+	}
+
+	if len(positional) > 1 {
+		return nil, fmt.Errorf("only one parent issue can be given, got %d", len(positional))
+	}
+	if len(positional) == 1 {
+		opts.Parent = positional[0]
+	}
+
+	debug.Log("ParseSplitFlags", "parsed", fmt.Sprintf("%+v", opts))
+	return opts, nil
+}
+
+// SplitAPIClient defines the interface for split operations.
+type SplitAPIClient interface {
+	GetIssue(owner, repo string, number int) (*api.Issue, error)
+	ListIssues(opts api.ListIssuesOptions) ([]api.Issue, error)
+	CreateIssue(opts api.CreateIssueOptions) (*api.IssueResult, error)
+	LinkSubIssue(opts api.LinkSubIssueOptions) error
+	UpdateIssue(opts api.UpdateIssueOptions) (*api.Issue, error)
+}
+
+// SplitRunner executes the split subcommand.
+type SplitRunner struct {
+	Client   SplitAPIClient
+	Owner    string
+	Repo     string
+	Out      io.Writer
+	Prompter Prompter // nil means non-interactive mode
+}
+
+// checklistItemPattern matches an unchecked task list item such as "- [ ] Write docs",
+// capturing the prefix up to and including the checkbox, and the item text.
+var checklistItemPattern = regexp.MustCompile(`^(\s*[-*+]\s+\[ \]\s+)(.*?)\s*$`)
+
+// issueRefPattern matches item text that already refers to an issue.
+var issueRefPattern = regexp.MustCompile(`^(?:[\w.-]+/[\w.-]+)?#\d+$|^https?://\S+/issues/\d+$`)
+
+// checklistItem is an unchecked item found in an issue body.
+type checklistItem struct {
+	line   int    // index into the body's lines
+	prefix string // e.g. "- [ ] "
+	title  string
+	eol    string // "\r" when the body uses CRLF line endings
+}
+
+// findChecklistItems returns the unchecked task list items in body that do
+// not already refer to an issue. Items inside fenced code blocks are ignored.
+func findChecklistItems(lines []string) []checklistItem {
+	var items []checklistItem
+	inFence := false
+	for i, raw := range lines {
+		line := strings.TrimSuffix(raw, "\r")
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~") {
+			inFence = !inFence
+			continue
+		}
+		if inFence {
+			continue
+		}
+
+		m := checklistItemPattern.FindStringSubmatch(line)
+		if m == nil || m[2] == "" || issueRefPattern.MatchString(m[2]) {
+			continue
+		}
+		items = append(items, checklistItem{
+			line:   i,
+			prefix: m[1],
+			title:  m[2],
+			eol:    strings.TrimPrefix(raw, line),
+		})
+	}
+	return items
+}
+
+// Run executes the split command.
+func (r *SplitRunner) Run(opts SplitOptions) error {
+	debug.Log("SplitRunner.Run", "parent", opts.Parent, "dry_run", opts.DryRun)
+
+	// The sub-issues are created in the parent's repository, so the rewritten
+	// "#N" items refer to them
+	owner, repo := r.Owner, r.Repo
+	var parentNumber int
+	if opts.Parent == "" {
+		if r.Prompter == nil {
+			return fmt.Errorf("issue number is required when not running interactively\nExample: gh subissue split 42 -R %s/%s", r.Owner, r.Repo)
+		}
+
+		issues, err := r.Client.ListIssues(api.ListIssuesOptions{
+			Owner: r.Owner,
+			Repo:  r.Repo,
+			State: "open",
+			Limit: pickerIssueLimit,
+		})
+		if err != nil {
+			debug.Error("SplitRunner.Run", err, "stage", "list_issues")
+			return fmt.Errorf("failed to list issues: %w", err)
+		}
+
+		selected, err := SelectParentIssue(r.Prompter, issues)
+		if err != nil {
+			debug.Error("SplitRunner.Run", err, "stage", "select_parent")
+			return err
+		}
+		parentNumber = selected
+	} else {
+		ref, err := ParseIssueRef(opts.Parent)
+		if err != nil {
+			return err
+		}
+		parentNumber = ref.Number
+		if ref.Owner != "" {
+			owner, repo = ref.Owner, ref.Repo
+		}
+	}
+
+	parent, err := r.Client.GetIssue(owner, repo, parentNumber)
+	if err != nil {
+		debug.Error("SplitRunner.Run", err, "stage", "get_parent")
+		return err
+	}
+
+	lines := strings.Split(parent.Body, "\n")
+	items := findChecklistItems(lines)
+	debug.Log("SplitRunner.Run", "items_found", len(items))
+	if len(items) == 0 {
+		fmt.Fprintf(r.Out, "No unchecked checklist items found in #%d\n", parentNumber)
+		return nil
+	}
+
+	if opts.DryRun {
+		fmt.Fprintf(r.Out, "Would create %d sub-issues of #%d:\n", len(items), parentNumber)
+		for _, item := range items {
+			fmt.Fprintf(r.Out, "  %s\n", item.title)
+		}
+		for i, item := range items {
+			lines[item.line] = fmt.Sprintf("%s#<new %d>%s", item.prefix, i+1, item.eol)
+		}
+		fmt.Fprintf(r.Out, "\nRewritten body of #%d:\n%s\n", parentNumber, strings.Join(lines, "\n"))
+		return nil
+	}
+
+	var failed, created int
+	for _, item := range items {
+		result, err := r.Client.CreateIssue(api.CreateIssueOptions{
+			Owner: owner,
+			Repo:  repo,
+			Title: item.title,
+		})
+		if err != nil {
+			// Leave the line as it was so the item is not lost
+			debug.Error("SplitRunner.Run", err, "stage", "create_issue", "title", item.title)
+			fmt.Fprintf(r.Out, "Failed to create %q: %v\n", item.title, err)
+			failed++
+			continue
+		}
+		created++
+		lines[item.line] = fmt.Sprintf("%s#%d%s", item.prefix, result.Number, item.eol)
+
+		err = r.Client.LinkSubIssue(api.LinkSubIssueOptions{
+			Owner:       owner,
+			Repo:        repo,
+			ParentIssue: parentNumber,
+			SubIssueID:  result.ID,
+		})
+		if err != nil {
+			debug.Error("SplitRunner.Run", err, "stage", "link_sub_issue", "issue", result.Number)
+			fmt.Fprintf(r.Out, "Created #%d %s but failed to link it: %v\n", result.Number, item.title, err)
+			failed++
+			continue
+		}
+		fmt.Fprintf(r.Out, "Created #%d %s\n", result.Number, item.title)
+	}
+
+	if created > 0 {
+		body := strings.Join(lines, "\n")
+		_, err := r.Client.UpdateIssue(api.UpdateIssueOptions{
+			Owner:  owner,
+			Repo:   repo,
+			Number: parentNumber,
+			Body:   &body,
+		})
+		if err != nil {
+			debug.Error("SplitRunner.Run", err, "stage", "update_parent")
+			return fmt.Errorf("created %d sub-issues but failed to update the body of #%d: %w", created, parentNumber, err)
+		}
+		fmt.Fprintf(r.Out, "Updated the checklist in #%d\n", parentNumber)
+	}
+
+	if failed > 0 {
+		err := fmt.Errorf("failed to split %d of %d checklist items", failed, len(items))
+		debug.Error("SplitRunner.Run", err)
+		return err
+	}
+
+	debug.Log("SplitRunner.Run", "result", "success", "created", created)
+	return nil
+}
@@ -0,0 +1,250 @@
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/gwyn/gh-subissue/internal/api"
+)
+
+func TestParseSplitFlags(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		want    SplitOptions
+		wantErr bool
+	}{
+		{
+			name: "parent only",
+			args: []string{"42"},
+			want: SplitOptions{Parent: "42"},
+		},
+		{
+			name: "dry run after parent",
+			args: []string{"42", "--dry-run", "-R", "owner/repo"},
+			want: SplitOptions{Parent: "42", Repo: "owner/repo", DryRun: true},
+		},
+		{
+			name:    "two parents",
+			args:    []string{"42", "43"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts, err := ParseSplitFlags(tt.args)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ParseSplitFlags() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			if *opts != tt.want {
+				t.Errorf("ParseSplitFlags() = %+v, want %+v", *opts, tt.want)
+			}
+		})
+	}
+}
+
+func TestFindChecklistItems(t *testing.T) {
+	body := "Intro\n" +
+		"- [ ] Write docs\n" +
+		"- [x] Done already\n" +
+		"  * [ ] Nested item  \r\n" +
+		"- [ ] #12\n" +
+		"- [ ] owner/repo#7\n" +
+		"```\n" +
+		"- [ ] inside code\n" +
+		"```\n" +
+		"+ [ ] Last"
+
+	items := findChecklistItems(strings.Split(body, "\n"))
+
+	var got []string
+	for _, item := range items {
+		got = append(got, fmt.Sprintf("%d:%q:%q:%q", item.line, item.prefix, item.title, item.eol))
+	}
+	want := []string{
+		`1:"- [ ] ":"Write docs":""`,
+		`3:"  * [ ] ":"Nested item":"\r"`,
+		`9:"+ [ ] ":"Last":""`,
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("findChecklistItems() =\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+}
+
+// mockSplitAPIClient implements the SplitAPIClient interface for testing.
+type mockSplitAPIClient struct {
+	parent      api.Issue
+	created     []string
+	links       []int64
+	updatedBody *string
+	createFail  string
+	updateErr   error
+	repos       []string // repository of each read and write, as "owner/repo"
+}
+
+func (m *mockSplitAPIClient) GetIssue(owner, repo string, number int) (*api.Issue, error) {
+	m.repos = append(m.repos, owner+"/"+repo)
+	if number != m.parent.Number {
+		return nil, errors.New("not found")
+	}
+	issue := m.parent
+	return &issue, nil
+}
+
+func (m *mockSplitAPIClient) ListIssues(opts api.ListIssuesOptions) ([]api.Issue, error) {
+	return []api.Issue{m.parent}, nil
+}
+
+func (m *mockSplitAPIClient) CreateIssue(opts api.CreateIssueOptions) (*api.IssueResult, error) {
+	m.repos = append(m.repos, opts.Owner+"/"+opts.Repo)
+	if opts.Title == m.createFail {
+		return nil, errors.New("validation failed")
+	}
+	m.created = append(m.created, opts.Title)
+	number := 100 + len(m.created)
+	return &api.IssueResult{ID: int64(number), Number: number}, nil
+}
+
+func (m *mockSplitAPIClient) LinkSubIssue(opts api.LinkSubIssueOptions) error {
+	m.repos = append(m.repos, opts.Owner+"/"+opts.Repo)
+	m.links = append(m.links, opts.SubIssueID)
+	return nil
+}
+
+func (m *mockSplitAPIClient) UpdateIssue(opts api.UpdateIssueOptions) (*api.Issue, error) {
+	m.repos = append(m.repos, opts.Owner+"/"+opts.Repo)
+	if m.updateErr != nil {
+		return nil, m.updateErr
+	}
+	m.updatedBody = opts.Body
+	return &api.Issue{Number: opts.Number, Body: *opts.Body}, nil
+}
+
+// Compile-time check
+var _ SplitAPIClient = (*mockSplitAPIClient)(nil)
+
+const splitBody = "Tasks:\n- [ ] Write docs\n- [x] Ship\n- [ ] Add tests"
+
+func TestSplitRunnerRun(t *testing.T) {
+	client := &mockSplitAPIClient{parent: api.Issue{Number: 42, Body: splitBody}}
+
+	var output bytes.Buffer
+	runner := &SplitRunner{Client: client, Owner: "owner", Repo: "repo", Out: &output}
+
+	if err := runner.Run(SplitOptions{Parent: "42"}); err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+
+	if strings.Join(client.created, ",") != "Write docs,Add tests" {
+		t.Errorf("created = %v", client.created)
+	}
+	if len(client.links) != 2 {
+		t.Errorf("expected 2 links, got %v", client.links)
+	}
+	wantBody := "Tasks:\n- [ ] #101\n- [x] Ship\n- [ ] #102"
+	if client.updatedBody == nil || *client.updatedBody != wantBody {
+		t.Errorf("updated body = %v, want %q", client.updatedBody, wantBody)
+	}
+	wantOutput := "Created #101 Write docs\nCreated #102 Add tests\nUpdated the checklist in #42\n"
+	if output.String() != wantOutput {
+		t.Errorf("output = %q, want %q", output.String(), wantOutput)
+	}
+}
+
+func TestSplitRunnerCrossRepoParent(t *testing.T) {
+	client := &mockSplitAPIClient{parent: api.Issue{Number: 5, Body: splitBody}}
+
+	var output bytes.Buffer
+	runner := &SplitRunner{Client: client, Owner: "owner", Repo: "repo", Out: &output}
+
+	if err := runner.Run(SplitOptions{Parent: "https://github.com/other/repo/issues/5"}); err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+
+	// get, two creates, two links and the body update
+	if len(client.repos) != 6 {
+		t.Fatalf("calls = %v, want 6", client.repos)
+	}
+	for _, repo := range client.repos {
+		if repo != "other/repo" {
+			t.Errorf("calls = %v, want all in other/repo", client.repos)
+			break
+		}
+	}
+}
+
+func TestSplitRunnerDryRun(t *testing.T) {
+	client := &mockSplitAPIClient{parent: api.Issue{Number: 42, Body: splitBody}}
+
+	var output bytes.Buffer
+	runner := &SplitRunner{Client: client, Owner: "owner", Repo: "repo", Out: &output}
+
+	if err := runner.Run(SplitOptions{Parent: "42", DryRun: true}); err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+
+	if len(client.created) != 0 || client.updatedBody != nil {
+		t.Error("dry run must not create issues or update the body")
+	}
+	want := "Would create 2 sub-issues of #42:\n" +
+		"  Write docs\n" +
+		"  Add tests\n" +
+		"\n" +
+		"Rewritten body of #42:\n" +
+		"Tasks:\n- [ ] #<new 1>\n- [x] Ship\n- [ ] #<new 2>\n"
+	if output.String() != want {
+		t.Errorf("output = %q, want %q", output.String(), want)
+	}
+}
+
+func TestSplitRunnerNoItems(t *testing.T) {
+	client := &mockSplitAPIClient{parent: api.Issue{Number: 42, Body: "- [x] done\n- [ ] #12"}}
+
+	var output bytes.Buffer
+	runner := &SplitRunner{Client: client, Owner: "owner", Repo: "repo", Out: &output}
+
+	if err := runner.Run(SplitOptions{Parent: "42"}); err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	if output.String() != "No unchecked checklist items found in #42\n" {
+		t.Errorf("output = %q", output.String())
+	}
+	if client.updatedBody != nil {
+		t.Error("body should not be updated")
+	}
+}
+
+func TestSplitRunnerCreateFailureKeepsItem(t *testing.T) {
+	client := &mockSplitAPIClient{parent: api.Issue{Number: 42, Body: splitBody}, createFail: "Write docs"}
+
+	var output bytes.Buffer
+	runner := &SplitRunner{Client: client, Owner: "owner", Repo: "repo", Out: &output}
+
+	err := runner.Run(SplitOptions{Parent: "42"})
+	if err == nil || err.Error() != "failed to split 1 of 2 checklist items" {
+		t.Fatalf("Run() error = %v", err)
+	}
+	wantBody := "Tasks:\n- [ ] Write docs\n- [x] Ship\n- [ ] #101"
+	if client.updatedBody == nil || *client.updatedBody != wantBody {
+		t.Errorf("updated body = %v, want %q", client.updatedBody, wantBody)
+	}
+}
+
+func TestSplitRunnerUpdateFailure(t *testing.T) {
+	client := &mockSplitAPIClient{parent: api.Issue{Number: 42, Body: splitBody}, updateErr: errors.New("forbidden")}
+
+	runner := &SplitRunner{Client: client, Owner: "owner", Repo: "repo", Out: &bytes.Buffer{}}
+
+	err := runner.Run(SplitOptions{Parent: "42"})
+	if err == nil || !strings.Contains(err.Error(), "created 2 sub-issues but failed to update the body of #42") {
+		t.Errorf("Run() error = %v", err)
+	}
+}
@@ -0,0 +1,279 @@
+package cmd
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/gwyn/gh-subissue/internal/api"
+	"github.com/gwyn/gh-subissue/internal/debug"
+)
+
+// StatusFields lists the field names available for JSON output of the status command.
+var StatusFields = []string{"number", "title", "url", "total", "completed", "percentCompleted", "open", "closed", "openByAssignee"}
+
+// progressBarWidth is the number of cells in the status progress bar.
+const progressBarWidth = 20
+
+// StatusOptions contains the parsed command line options for the status command.
+type StatusOptions struct {
+	Parent string // parent issue number or URL (interactive if empty)
+	Repo   string
+	Export ExportOptions
+}
+
+// ParseStatusFlags parses command line flags for the status command.
+func ParseStatusFlags(args []string) (*StatusOptions, error) {
+	debug.Log("ParseStatusFlags", "args", args)
+
+	opts := &StatusOptions{}
+	fs := flag.NewFlagSet("status", flag.ContinueOnError)
+
+	fs.StringVar(&opts.Repo, "repo", "", "Repository (owner/repo)")
+	fs.StringVar(&opts.Repo, "R", "", "Repository (owner/repo)")
+
+	registerExportFlags(fs, &opts.Export)
+
+	positional, flagArgs := splitArgs(args, append([]string{"-R", "--repo", "-repo"}, exportFlagArgs...)...)
+	if err := fs.Parse(flagArgs); err != nil {
+		debug.Error("ParseStatusFlags", err, "stage", "fs.Parse")
+		return nil, err
+	}
+
+	if len(positional) > 1 {
+		return nil, fmt.Errorf("only one parent issue can be given, got %d", len(positional))
+	}
+	if len(positional) == 1 {
+		opts.Parent = positional[0]
+	}
+
+	if err := opts.Export.validate(StatusFields); err != nil {
+		debug.Error("ParseStatusFlags", err, "stage", "validate_export")
+		return nil, err
+	}
+
+	debug.Log("ParseStatusFlags", "parsed", fmt.Sprintf("%+v", opts))
+	return opts, nil
+}
+
+// StatusAPIClient defines the interface for status operations.
+type StatusAPIClient interface {
+	GetIssue(owner, repo string, number int) (*api.Issue, error)
+	ListIssues(opts api.ListIssuesOptions) ([]api.Issue, error)
+	ListSubIssues(opts api.ListSubIssuesOptions) ([]api.Issue, error)
+}
+
+// StatusRunner executes the status subcommand.
+type StatusRunner struct {
+	Client   StatusAPIClient
+	Owner    string
+	Repo     string
+	Out      io.Writer
+	Prompter Prompter // nil means non-interactive mode
+}
+
+// assigneeGroup is the open sub-issues assigned to one user.
+// Login is empty for unassigned issues.
+type assigneeGroup struct {
+	Login  string
+	Issues []api.Issue
+}
+
+// parentStatus is the progress of a parent issue's sub-issues.
+type parentStatus struct {
+	Parent           api.Issue
+	Total            int
+	Completed        int
+	PercentCompleted int
+	Open             int
+	Closed           int
+	OpenByAssignee   []assigneeGroup
+}
+
+// ExportData returns the requested fields of the status for JSON output.
+func (s parentStatus) ExportData(fields []string) map[string]interface{} {
+	data := make(map[string]interface{}, len(fields))
+	for _, f := range fields {
+		switch f {
+		case "number":
+			data[f] = s.Parent.Number
+		case "title":
+			data[f] = s.Parent.Title
+		case "url":
+			data[f] = s.Parent.URL
+		case "total":
+			data[f] = s.Total
+		case "completed":
+			data[f] = s.Completed
+		case "percentCompleted":
+			data[f] = s.PercentCompleted
+		case "open":
+			data[f] = s.Open
+		case "closed":
+			data[f] = s.Closed
+		case "openByAssignee":
+			groups := make([]map[string]interface{}, len(s.OpenByAssignee))
+			for i, g := range s.OpenByAssignee {
+				groups[i] = map[string]interface{}{
+					"assignee": g.Login,
+					"issues":   exportList(g.Issues, []string{"number", "title", "url"}),
+				}
+			}
+			data[f] = groups
+		}
+	}
+	return data
+}
+
+// Run executes the status command.
+func (r *StatusRunner) Run(opts StatusOptions) error {
+	debug.Log("StatusRunner.Run", "parent", opts.Parent)
+
+	owner, repo := r.Owner, r.Repo
+	var parentNumber int
+	if opts.Parent == "" {
+		if r.Prompter == nil {
+			return fmt.Errorf("issue number is required when not running interactively\nExample: gh subissue status 42 -R %s/%s", r.Owner, r.Repo)
+		}
+
+		issues, err := r.Client.ListIssues(api.ListIssuesOptions{
+			Owner: r.Owner,
+			Repo:  r.Repo,
+			State: "open",
+			Limit: pickerIssueLimit,
+		})
+		if err != nil {
+			debug.Error("StatusRunner.Run", err, "stage", "list_issues")
+			return fmt.Errorf("failed to list issues: %w", err)
+		}
+
+		selected, err := SelectParentIssue(r.Prompter, issues)
+		if err != nil {
+			debug.Error("StatusRunner.Run", err, "stage", "select_parent")
+			return err
+		}
+		parentNumber = selected
+	} else {
+		ref, err := ParseIssueRef(opts.Parent)
+		if err != nil {
+			return err
+		}
+		parentNumber = ref.Number
+		if ref.Owner != "" {
+			owner, repo = ref.Owner, ref.Repo
+		}
+	}
+
+	parent, err := r.Client.GetIssue(owner, repo, parentNumber)
+	if err != nil {
+		debug.Error("StatusRunner.Run", err, "stage", "get_parent")
+		return err
+	}
+
+	children, err := r.Client.ListSubIssues(api.ListSubIssuesOptions{
+		Owner:       owner,
+		Repo:        repo,
+		ParentIssue: parentNumber,
+	})
+	if err != nil {
+		debug.Error("StatusRunner.Run", err, "stage", "list_sub_issues")
+		return err
+	}
+
+	status := summarize(*parent, children)
+
+	if opts.Export.Enabled() {
+		return opts.Export.Write(r.Out, status.ExportData(opts.Export.Fields))
+	}
+
+	fmt.Fprintf(r.Out, "#%d %s\n", status.Parent.Number, status.Parent.Title)
+	if status.Total == 0 {
+		fmt.Fprintln(r.Out, "No sub-issues")
+		return nil
+	}
+	fmt.Fprintf(r.Out, "%s %d%% (%d of %d done)\n", progressBar(status.PercentCompleted), status.PercentCompleted, status.Completed, status.Total)
+	fmt.Fprintf(r.Out, "Open: %d  Closed: %d\n", status.Open, status.Closed)
+
+	if len(status.OpenByAssignee) > 0 {
+		fmt.Fprintln(r.Out)
+		fmt.Fprintln(r.Out, "Open sub-issues by assignee:")
+		for _, group := range status.OpenByAssignee {
+			name := "@" + group.Login
+			if group.Login == "" {
+				name = "(unassigned)"
+			}
+			fmt.Fprintf(r.Out, "  %s\n", name)
+			for _, issue := range group.Issues {
+				fmt.Fprintf(r.Out, "    #%d %s\n", issue.Number, issue.Title)
+			}
+		}
+	}
+
+	debug.Log("StatusRunner.Run", "result", "success", "total", status.Total, "completed", status.Completed)
+	return nil
+}
+
+// summarize computes a parent's progress. GitHub's sub_issues_summary is
+// preferred for the totals; the counts are derived from the children when
+// the API did not include it.
+func summarize(parent api.Issue, children []api.Issue) parentStatus {
+	status := parentStatus{Parent: parent}
+
+	byLogin := map[string][]api.Issue{}
+	for _, child := range children {
+		if child.State == "closed" {
+			status.Closed++
+			continue
+		}
+		status.Open++
+		if len(child.Assignees) == 0 {
+			byLogin[""] = append(byLogin[""], child)
+		}
+		for _, user := range child.Assignees {
+			byLogin[user.Login] = append(byLogin[user.Login], child)
+		}
+	}
+
+	if summary := parent.SubIssuesSummary; summary != nil {
+		status.Total = summary.Total
+		status.Completed = summary.Completed
+		status.PercentCompleted = summary.PercentCompleted
+	} else {
+		status.Total = len(children)
+		status.Completed = status.Closed
+		if status.Total > 0 {
+			status.PercentCompleted = status.Completed * 100 / status.Total
+		}
+	}
+
+	// Assignees alphabetically, unassigned last
+	logins := make([]string, 0, len(byLogin))
+	for login := range byLogin {
+		logins = append(logins, login)
+	}
+	sort.Slice(logins, func(i, j int) bool {
+		if logins[i] == "" || logins[j] == "" {
+			return logins[j] == ""
+		}
+		return strings.ToLower(logins[i]) < strings.ToLower(logins[j])
+	})
+	for _, login := range logins {
+		status.OpenByAssignee = append(status.OpenByAssignee, assigneeGroup{Login: login, Issues: byLogin[login]})
+	}
+
+	return status
+}
+
+// progressBar draws a fixed-width bar such as "[██████░░░░░░░░░░░░░░]".
+func progressBar(percent int) string {
+	if percent < 0 {
+		percent = 0
+	}
+	if percent > 100 {
+		percent = 100
+	}
+	filled := percent * progressBarWidth / 100
+	return "[" + strings.Repeat("█", filled) + strings.Repeat("░", progressBarWidth-filled) + "]"
+}
@@ -0,0 +1,235 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/gwyn/gh-subissue/internal/api"
+)
+
+func TestParseStatusFlags(t *testing.T) {
+	tests := []struct {
+		name       string
+		args       []string
+		wantParent string
+		wantRepo   string
+		wantFields []string
+		wantErr    bool
+	}{
+		{
+			name:       "parent only",
+			args:       []string{"42"},
+			wantParent: "42",
+		},
+		{
+			name:       "json after parent",
+			args:       []string{"42", "--json", "total,completed", "-R", "owner/repo"},
+			wantParent: "42",
+			wantRepo:   "owner/repo",
+			wantFields: []string{"total", "completed"},
+		},
+		{
+			name:    "unknown json field",
+			args:    []string{"42", "--json", "labels"},
+			wantErr: true,
+		},
+		{
+			name:    "too many issues",
+			args:    []string{"42", "43"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts, err := ParseStatusFlags(tt.args)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ParseStatusFlags() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			if opts.Parent != tt.wantParent {
+				t.Errorf("Parent = %q, want %q", opts.Parent, tt.wantParent)
+			}
+			if opts.Repo != tt.wantRepo {
+				t.Errorf("Repo = %q, want %q", opts.Repo, tt.wantRepo)
+			}
+			if len(opts.Export.Fields) != len(tt.wantFields) {
+				t.Errorf("Fields = %v, want %v", opts.Export.Fields, tt.wantFields)
+			}
+		})
+	}
+}
+
+// mockStatusAPIClient implements the StatusAPIClient interface for testing.
+type mockStatusAPIClient struct {
+	parent   *api.Issue
+	children []api.Issue
+	repos    []string // repository of each request, as "owner/repo"
+}
+
+func (m *mockStatusAPIClient) GetIssue(owner, repo string, number int) (*api.Issue, error) {
+	m.repos = append(m.repos, owner+"/"+repo)
+	if m.parent == nil || m.parent.Number != number {
+		return nil, errors.New("not found")
+	}
+	return m.parent, nil
+}
+
+func (m *mockStatusAPIClient) ListIssues(opts api.ListIssuesOptions) ([]api.Issue, error) {
+	return []api.Issue{*m.parent}, nil
+}
+
+func (m *mockStatusAPIClient) ListSubIssues(opts api.ListSubIssuesOptions) ([]api.Issue, error) {
+	m.repos = append(m.repos, opts.Owner+"/"+opts.Repo)
+	return m.children, nil
+}
+
+// Compile-time check
+var _ StatusAPIClient = (*mockStatusAPIClient)(nil)
+
+func newMockStatusClient() *mockStatusAPIClient {
+	return &mockStatusAPIClient{
+		parent: &api.Issue{
+			Number:           42,
+			Title:            "Launch v2",
+			URL:              "https://github.com/owner/repo/issues/42",
+			SubIssuesSummary: &api.SubIssuesSummary{Total: 4, Completed: 1, PercentCompleted: 25},
+		},
+		children: []api.Issue{
+			{Number: 43, Title: "Backend", State: "closed", Assignees: []api.User{{Login: "octocat"}}},
+			{Number: 44, Title: "Frontend", State: "open", Assignees: []api.User{{Login: "octocat"}}},
+			{Number: 45, Title: "Docs", State: "open"},
+			{Number: 46, Title: "Release", State: "open", Assignees: []api.User{{Login: "Alice"}, {Login: "octocat"}}},
+		},
+	}
+}
+
+func TestStatusRunnerRun(t *testing.T) {
+	var output bytes.Buffer
+	runner := &StatusRunner{
+		Client: newMockStatusClient(),
+		Owner:  "owner",
+		Repo:   "repo",
+		Out:    &output,
+	}
+
+	if err := runner.Run(StatusOptions{Parent: "42"}); err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+
+	want := "#42 Launch v2\n" +
+		"[█████░░░░░░░░░░░░░░░] 25% (1 of 4 done)\n" +
+		"Open: 3  Closed: 1\n" +
+		"\n" +
+		"Open sub-issues by assignee:\n" +
+		"  @Alice\n" +
+		"    #46 Release\n" +
+		"  @octocat\n" +
+		"    #44 Frontend\n" +
+		"    #46 Release\n" +
+		"  (unassigned)\n" +
+		"    #45 Docs\n"
+	if output.String() != want {
+		t.Errorf("output = %q, want %q", output.String(), want)
+	}
+}
+
+func TestStatusRunnerCrossRepoParent(t *testing.T) {
+	client := newMockStatusClient()
+
+	var output bytes.Buffer
+	runner := &StatusRunner{Client: client, Owner: "owner", Repo: "repo", Out: &output}
+
+	if err := runner.Run(StatusOptions{Parent: "other/lib#42"}); err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	if !reflect.DeepEqual(client.repos, []string{"other/lib", "other/lib"}) {
+		t.Errorf("requests went to %v, want other/lib", client.repos)
+	}
+}
+
+func TestStatusRunnerNoSubIssues(t *testing.T) {
+	client := &mockStatusAPIClient{parent: &api.Issue{Number: 42, Title: "Lonely"}}
+
+	var output bytes.Buffer
+	runner := &StatusRunner{Client: client, Owner: "owner", Repo: "repo", Out: &output}
+
+	if err := runner.Run(StatusOptions{Parent: "42"}); err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	if output.String() != "#42 Lonely\nNo sub-issues\n" {
+		t.Errorf("output = %q", output.String())
+	}
+}
+
+func TestStatusRunnerJSON(t *testing.T) {
+	var output bytes.Buffer
+	runner := &StatusRunner{
+		Client: newMockStatusClient(),
+		Owner:  "owner",
+		Repo:   "repo",
+		Out:    &output,
+	}
+
+	opts := StatusOptions{Parent: "42", Export: ExportOptions{Fields: []string{"percentCompleted", "open", "openByAssignee"}}}
+	if err := runner.Run(opts); err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+
+	var got struct {
+		PercentCompleted int `json:"percentCompleted"`
+		Open             int `json:"open"`
+		OpenByAssignee   []struct {
+			Assignee string `json:"assignee"`
+			Issues   []struct {
+				Number int `json:"number"`
+			} `json:"issues"`
+		} `json:"openByAssignee"`
+	}
+	if err := json.Unmarshal(output.Bytes(), &got); err != nil {
+		t.Fatalf("output is not JSON: %v\n%s", err, output.String())
+	}
+	if got.PercentCompleted != 25 || got.Open != 3 {
+		t.Errorf("got percentCompleted=%d open=%d", got.PercentCompleted, got.Open)
+	}
+	if len(got.OpenByAssignee) != 3 || got.OpenByAssignee[2].Assignee != "" || got.OpenByAssignee[2].Issues[0].Number != 45 {
+		t.Errorf("unexpected openByAssignee: %+v", got.OpenByAssignee)
+	}
+}
+
+func TestSummarizeWithoutAPISummary(t *testing.T) {
+	children := []api.Issue{
+		{Number: 1, State: "closed"},
+		{Number: 2, State: "closed"},
+		{Number: 3, State: "open"},
+	}
+
+	status := summarize(api.Issue{Number: 42}, children)
+	if status.Total != 3 || status.Completed != 2 || status.PercentCompleted != 66 {
+		t.Errorf("summarize() = total %d, completed %d, percent %d", status.Total, status.Completed, status.PercentCompleted)
+	}
+}
+
+func TestProgressBar(t *testing.T) {
+	tests := []struct {
+		percent int
+		want    string
+	}{
+		{0, "[░░░░░░░░░░░░░░░░░░░░]"},
+		{50, "[██████████░░░░░░░░░░]"},
+		{100, "[████████████████████]"},
+		{150, "[████████████████████]"},
+	}
+
+	for _, tt := range tests {
+		if got := progressBar(tt.percent); got != tt.want {
+			t.Errorf("progressBar(%d) = %q, want %q", tt.percent, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,250 @@
+package cmd
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/gwyn/gh-subissue/internal/api"
+	"github.com/gwyn/gh-subissue/internal/debug"
+)
+
+// SyncOptions contains the parsed command line options for the sync command.
+type SyncOptions struct {
+	Parent string // single parent to check; empty scans the repository
+	Repo   string
+	DryRun bool
+}
+
+// ParseSyncFlags parses command line flags for the sync command.
+func ParseSyncFlags(args []string) (*SyncOptions, error) {
+	debug.Log("ParseSyncFlags", "args", args)
+
+	opts := &SyncOptions{}
+	fs := flag.NewFlagSet("sync", flag.ContinueOnError)
+
+	fs.StringVar(&opts.Repo, "repo", "", "Repository (owner/repo)")
+	fs.StringVar(&opts.Repo, "R", "", "Repository (owner/repo)")
+
+	fs.BoolVar(&opts.DryRun, "dry-run", false, "Report the parents that would be closed without closing them")
+
+	positional, flagArgs := splitArgs(args, "-R", "--repo", "-repo")
+	if err := fs.Parse(flagArgs); err != nil {
+		debug.Error("ParseSyncFlags", err, "stage", "fs.Parse")
+		return nil, err
+	}
+
+	if len(positional) > 1 {
+		return nil, fmt.Errorf("only one parent issue can be given, got %d", len(positional))
+	}
+	if len(positional) == 1 {
+		opts.Parent = positional[0]
+	}
+
+	debug.Log("ParseSyncFlags", "parsed", fmt.Sprintf("%+v", opts))
+	return opts, nil
+}
+
+// SyncAPIClient defines the interface for sync operations.
+type SyncAPIClient interface {
+	GetIssue(owner, repo string, number int) (*api.Issue, error)
+	GetParentIssue(owner, repo string, number int) (*api.Issue, error)
+	ListIssues(opts api.ListIssuesOptions) ([]api.Issue, error)
+	ListSubIssues(opts api.ListSubIssuesOptions) ([]api.Issue, error)
+	UpdateIssue(opts api.UpdateIssueOptions) (*api.Issue, error)
+	CreateComment(owner, repo string, number int, body string) error
+}
+
+// SyncRunner executes the sync subcommand. It never prompts, so it can run
+// in CI.
+type SyncRunner struct {
+	Client SyncAPIClient
+	Owner  string
+	Repo   string
+	Out    io.Writer
+}
+
+// Run executes the sync command.
+func (r *SyncRunner) Run(opts SyncOptions) error {
+	debug.Log("SyncRunner.Run", "parent", opts.Parent, "dry_run", opts.DryRun)
+
+	var queue []api.Issue
+	var ref IssueRef
+	if opts.Parent != "" {
+		var err error
+		ref, err = ParseIssueRef(opts.Parent)
+		if err != nil {
+			return err
+		}
+		if ref.Owner != "" && (!strings.EqualFold(ref.Owner, r.Owner) || !strings.EqualFold(ref.Repo, r.Repo)) {
+			return fmt.Errorf("issue %s is not in %s/%s; use --repo to sync another repository", ref, r.Owner, r.Repo)
+		}
+
+		issue, err := r.Client.GetIssue(r.Owner, r.Repo, ref.Number)
+		if err != nil {
+			debug.Error("SyncRunner.Run", err, "stage", "get_issue")
+			return err
+		}
+		if issue.State != "open" {
+			fmt.Fprintf(r.Out, "Issue #%d is already closed\n", issue.Number)
+			return nil
+		}
+		queue = append(queue, *issue)
+	} else {
+		issues, err := r.Client.ListIssues(api.ListIssuesOptions{
+			Owner: r.Owner,
+			Repo:  r.Repo,
+			State: "open",
+		})
+		if err != nil {
+			debug.Error("SyncRunner.Run", err, "stage", "list_issues")
+			return fmt.Errorf("failed to list issues: %w", err)
+		}
+
+		// The summary narrows the scan to parents that look done; the
+		// sub-issues are still checked before anything is closed
+		for _, issue := range issues {
+			s := issue.SubIssuesSummary
+			if s != nil && s.Total > 0 && s.Completed == s.Total {
+				queue = append(queue, issue)
+			}
+		}
+		debug.Log("SyncRunner.Run", "open_issues", len(issues), "candidates", len(queue))
+	}
+
+	// closed holds the issues closed by this run, or that would be in a dry
+	// run, so a parent whose last open sub-issue was itself a parent closed
+	// here is closed too
+	closed := map[int]bool{}
+	checked := map[int]bool{}
+	total, failed := 0, 0
+	for len(queue) > 0 {
+		issue := queue[0]
+		queue = queue[1:]
+		if checked[issue.Number] {
+			continue
+		}
+		checked[issue.Number] = true
+
+		children, err := r.Client.ListSubIssues(api.ListSubIssuesOptions{
+			Owner:       r.Owner,
+			Repo:        r.Repo,
+			ParentIssue: issue.Number,
+		})
+		if err != nil {
+			debug.Error("SyncRunner.Run", err, "stage", "list_sub_issues", "issue", issue.Number)
+			fmt.Fprintf(r.Out, "Failed to check #%d: %v\n", issue.Number, err)
+			failed++
+			continue
+		}
+		if !r.allClosed(children, closed) {
+			debug.Log("SyncRunner.Run", "issue", issue.Number, "result", "open_sub_issues")
+			continue
+		}
+
+		total++
+		if opts.DryRun {
+			fmt.Fprintf(r.Out, "Would close #%d %s (%d/%d sub-issues closed)\n", issue.Number, issue.Title, len(children), len(children))
+		} else {
+			if err := r.close(issue); err != nil {
+				fmt.Fprintf(r.Out, "Failed to close #%d: %v\n", issue.Number, err)
+				failed++
+				continue
+			}
+			fmt.Fprintf(r.Out, "Closed #%d %s (%d/%d sub-issues closed)\n", issue.Number, issue.Title, len(children), len(children))
+			// the parent is closed either way, so a missing comment is not a failed sync
+			if err := r.comment(issue, children); err != nil {
+				fmt.Fprintf(r.Out, "Warning: closed #%d but failed to comment: %v\n", issue.Number, err)
+			}
+		}
+		closed[issue.Number] = true
+
+		if opts.Parent != "" {
+			continue
+		}
+		parent, err := r.Client.GetParentIssue(r.Owner, r.Repo, issue.Number)
+		if err != nil {
+			debug.Error("SyncRunner.Run", err, "stage", "get_parent", "issue", issue.Number)
+			continue
+		}
+		if parent != nil && parent.State == "open" && r.inRepo(*parent) && !closed[parent.Number] {
+			// Check the parent again even if it was already checked
+			delete(checked, parent.Number)
+			queue = append(queue, *parent)
+		}
+	}
+
+	if total == 0 && failed == 0 {
+		if opts.Parent != "" {
+			fmt.Fprintf(r.Out, "Issue #%d has open sub-issues or none at all\n", ref.Number)
+		} else {
+			fmt.Fprintln(r.Out, "No open parents with all sub-issues closed")
+		}
+	}
+
+	if failed > 0 {
+		err := fmt.Errorf("failed to sync %d of %d parents", failed, failed+total)
+		debug.Error("SyncRunner.Run", err)
+		return err
+	}
+
+	debug.Log("SyncRunner.Run", "result", "success", "closed", total, "dry_run", opts.DryRun)
+	return nil
+}
+
+// allClosed reports whether a parent has sub-issues and all of them are
+// closed, counting those closed earlier in this run.
+func (r *SyncRunner) allClosed(children []api.Issue, closed map[int]bool) bool {
+	if len(children) == 0 {
+		return false
+	}
+	for _, child := range children {
+		if child.State == "closed" {
+			continue
+		}
+		if closed[child.Number] && r.inRepo(child) {
+			continue
+		}
+		return false
+	}
+	return true
+}
+
+// close closes a parent as completed.
+func (r *SyncRunner) close(issue api.Issue) error {
+	state, reason := "closed", "completed"
+	_, err := r.Client.UpdateIssue(api.UpdateIssueOptions{
+		Owner:       r.Owner,
+		Repo:        r.Repo,
+		Number:      issue.Number,
+		State:       &state,
+		StateReason: &reason,
+	})
+	if err != nil {
+		debug.Error("SyncRunner.close", err, "stage", "update_issue", "issue", issue.Number)
+		return err
+	}
+	return nil
+}
+
+// comment lists the closed sub-issues on a parent that sync has closed.
+func (r *SyncRunner) comment(issue api.Issue, children []api.Issue) error {
+	var b strings.Builder
+	b.WriteString("All sub-issues are closed, so this issue was closed by `gh subissue sync`.\n\n")
+	for _, child := range children {
+		fmt.Fprintf(&b, "- [x] %s %s\n", issueRefOf(child, r.Owner, r.Repo).RelativeTo(r.Owner, r.Repo), child.Title)
+	}
+
+	if err := r.Client.CreateComment(r.Owner, r.Repo, issue.Number, b.String()); err != nil {
+		debug.Error("SyncRunner.comment", err, "issue", issue.Number)
+		return err
+	}
+	return nil
+}
+
+// inRepo reports whether an issue returned by the API is in the runner's repository.
+func (r *SyncRunner) inRepo(issue api.Issue) bool {
+	owner, repo := issue.Repository()
+	return owner == "" || (strings.EqualFold(owner, r.Owner) && strings.EqualFold(repo, r.Repo))
+}
@@ -0,0 +1,321 @@
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/gwyn/gh-subissue/internal/api"
+)
+
+// mockSyncAPIClient implements the SyncAPIClient interface for testing.
+type mockSyncAPIClient struct {
+	getIssueFunc       func(owner, repo string, number int) (*api.Issue, error)
+	getParentIssueFunc func(owner, repo string, number int) (*api.Issue, error)
+	listIssuesFunc     func(opts api.ListIssuesOptions) ([]api.Issue, error)
+	listSubIssuesFunc  func(opts api.ListSubIssuesOptions) ([]api.Issue, error)
+	updateIssueFunc    func(opts api.UpdateIssueOptions) (*api.Issue, error)
+	createCommentFunc  func(owner, repo string, number int, body string) error
+}
+
+func (m *mockSyncAPIClient) GetIssue(owner, repo string, number int) (*api.Issue, error) {
+	if m.getIssueFunc != nil {
+		return m.getIssueFunc(owner, repo, number)
+	}
+	return &api.Issue{Number: number, Title: "Issue", State: "open"}, nil
+}
+
+func (m *mockSyncAPIClient) GetParentIssue(owner, repo string, number int) (*api.Issue, error) {
+	if m.getParentIssueFunc != nil {
+		return m.getParentIssueFunc(owner, repo, number)
+	}
+	return nil, nil
+}
+
+func (m *mockSyncAPIClient) ListIssues(opts api.ListIssuesOptions) ([]api.Issue, error) {
+	if m.listIssuesFunc != nil {
+		return m.listIssuesFunc(opts)
+	}
+	return []api.Issue{}, nil
+}
+
+func (m *mockSyncAPIClient) ListSubIssues(opts api.ListSubIssuesOptions) ([]api.Issue, error) {
+	if m.listSubIssuesFunc != nil {
+		return m.listSubIssuesFunc(opts)
+	}
+	return []api.Issue{}, nil
+}
+
+func (m *mockSyncAPIClient) UpdateIssue(opts api.UpdateIssueOptions) (*api.Issue, error) {
+	if m.updateIssueFunc != nil {
+		return m.updateIssueFunc(opts)
+	}
+	return &api.Issue{Number: opts.Number}, nil
+}
+
+func (m *mockSyncAPIClient) CreateComment(owner, repo string, number int, body string) error {
+	if m.createCommentFunc != nil {
+		return m.createCommentFunc(owner, repo, number, body)
+	}
+	return nil
+}
+
+// Compile-time check
+var _ SyncAPIClient = (*mockSyncAPIClient)(nil)
+
+// syncFixture is a repository where:
+//   - #42 is open and its sub-issues #43 and #44 are closed
+//   - #50 is open with one open sub-issue, #51
+//   - #80 is open with closed #81 and open #70, whose only sub-issue #71 is closed
+//   - #60 has no sub-issues
+func syncFixture() *mockSyncAPIClient {
+	issues := map[int]api.Issue{
+		42: {Number: 42, Title: "Epic", State: "open", SubIssuesSummary: &api.SubIssuesSummary{Total: 2, Completed: 2}},
+		43: {Number: 43, Title: "Backend", State: "closed"},
+		44: {Number: 44, Title: "Frontend", State: "closed"},
+		50: {Number: 50, Title: "In progress", State: "open", SubIssuesSummary: &api.SubIssuesSummary{Total: 1}},
+		51: {Number: 51, Title: "Open task", State: "open"},
+		60: {Number: 60, Title: "Plain issue", State: "open", SubIssuesSummary: &api.SubIssuesSummary{}},
+		70: {Number: 70, Title: "Sub-epic", State: "open", SubIssuesSummary: &api.SubIssuesSummary{Total: 1, Completed: 1}},
+		71: {Number: 71, Title: "Last task", State: "closed"},
+		80: {Number: 80, Title: "Initiative", State: "open", SubIssuesSummary: &api.SubIssuesSummary{Total: 2, Completed: 1}},
+		81: {Number: 81, Title: "Done task", State: "closed"},
+	}
+	children := map[int][]int{42: {43, 44}, 50: {51}, 70: {71}, 80: {81, 70}}
+	parents := map[int]int{43: 42, 44: 42, 51: 50, 70: 80, 71: 70, 81: 80}
+
+	return &mockSyncAPIClient{
+		getIssueFunc: func(owner, repo string, number int) (*api.Issue, error) {
+			issue := issues[number]
+			return &issue, nil
+		},
+		getParentIssueFunc: func(owner, repo string, number int) (*api.Issue, error) {
+			parent, ok := parents[number]
+			if !ok {
+				return nil, nil
+			}
+			issue := issues[parent]
+			return &issue, nil
+		},
+		listIssuesFunc: func(opts api.ListIssuesOptions) ([]api.Issue, error) {
+			var open []api.Issue
+			for _, n := range []int{42, 50, 51, 60, 70, 80} {
+				open = append(open, issues[n])
+			}
+			return open, nil
+		},
+		listSubIssuesFunc: func(opts api.ListSubIssuesOptions) ([]api.Issue, error) {
+			var result []api.Issue
+			for _, n := range children[opts.ParentIssue] {
+				result = append(result, issues[n])
+			}
+			return result, nil
+		},
+	}
+}
+
+func TestParseSyncFlags(t *testing.T) {
+	opts, err := ParseSyncFlags([]string{"42", "--dry-run", "-R", "owner/repo"})
+	if err != nil {
+		t.Fatalf("ParseSyncFlags() error = %v", err)
+	}
+	want := SyncOptions{Parent: "42", Repo: "owner/repo", DryRun: true}
+	if *opts != want {
+		t.Errorf("ParseSyncFlags() = %+v, want %+v", *opts, want)
+	}
+
+	if _, err := ParseSyncFlags([]string{"42", "43"}); err == nil {
+		t.Error("expected error for two parents")
+	}
+}
+
+func TestSyncRunnerClosesCompletedParents(t *testing.T) {
+	client := syncFixture()
+	var closed []int
+	comments := map[int]string{}
+	client.updateIssueFunc = func(opts api.UpdateIssueOptions) (*api.Issue, error) {
+		if *opts.State != "closed" || *opts.StateReason != "completed" {
+			t.Errorf("update #%d = %s/%s, want closed/completed", opts.Number, *opts.State, *opts.StateReason)
+		}
+		closed = append(closed, opts.Number)
+		return &api.Issue{Number: opts.Number}, nil
+	}
+	client.createCommentFunc = func(owner, repo string, number int, body string) error {
+		comments[number] = body
+		return nil
+	}
+
+	var output bytes.Buffer
+	runner := &SyncRunner{Client: client, Owner: "owner", Repo: "repo", Out: &output}
+	if err := runner.Run(SyncOptions{}); err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+
+	if !reflect.DeepEqual(closed, []int{42, 70, 80}) {
+		t.Errorf("closed = %v, want [42 70 80]", closed)
+	}
+	wantComment := "All sub-issues are closed, so this issue was closed by `gh subissue sync`.\n\n" +
+		"- [x] #43 Backend\n" +
+		"- [x] #44 Frontend\n"
+	if comments[42] != wantComment {
+		t.Errorf("comment on #42 =\n%s\nwant\n%s", comments[42], wantComment)
+	}
+	if !strings.Contains(comments[80], "- [x] #70 Sub-epic") {
+		t.Errorf("comment on #80 = %q, want it to list #70", comments[80])
+	}
+
+	wantOut := `Closed #42 Epic (2/2 sub-issues closed)
+Closed #70 Sub-epic (1/1 sub-issues closed)
+Closed #80 Initiative (2/2 sub-issues closed)
+`
+	if output.String() != wantOut {
+		t.Errorf("output =\n%s\nwant\n%s", output.String(), wantOut)
+	}
+}
+
+func TestSyncRunnerDryRun(t *testing.T) {
+	client := syncFixture()
+	client.updateIssueFunc = func(opts api.UpdateIssueOptions) (*api.Issue, error) {
+		t.Errorf("UpdateIssue(#%d) called in a dry run", opts.Number)
+		return nil, nil
+	}
+	client.createCommentFunc = func(owner, repo string, number int, body string) error {
+		t.Errorf("CreateComment(#%d) called in a dry run", number)
+		return nil
+	}
+
+	var output bytes.Buffer
+	runner := &SyncRunner{Client: client, Owner: "owner", Repo: "repo", Out: &output}
+	if err := runner.Run(SyncOptions{DryRun: true}); err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+
+	wantOut := `Would close #42 Epic (2/2 sub-issues closed)
+Would close #70 Sub-epic (1/1 sub-issues closed)
+Would close #80 Initiative (2/2 sub-issues closed)
+`
+	if output.String() != wantOut {
+		t.Errorf("output =\n%s\nwant\n%s", output.String(), wantOut)
+	}
+}
+
+func TestSyncRunnerSingleParent(t *testing.T) {
+	tests := []struct {
+		name       string
+		parent     string
+		wantClosed []int
+		wantOut    string
+		wantErr    bool
+	}{
+		{
+			name:       "completed parent",
+			parent:     "70",
+			wantClosed: []int{70},
+			wantOut:    "Closed #70 Sub-epic (1/1 sub-issues closed)\n",
+		},
+		{
+			name:    "parent with open sub-issues",
+			parent:  "50",
+			wantOut: "Issue #50 has open sub-issues or none at all\n",
+		},
+		{
+			name:    "closed issue",
+			parent:  "43",
+			wantOut: "Issue #43 is already closed\n",
+		},
+		{
+			name:    "issue in another repository",
+			parent:  "other/repo#70",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := syncFixture()
+			var closed []int
+			client.updateIssueFunc = func(opts api.UpdateIssueOptions) (*api.Issue, error) {
+				closed = append(closed, opts.Number)
+				return &api.Issue{Number: opts.Number}, nil
+			}
+			client.listIssuesFunc = func(opts api.ListIssuesOptions) ([]api.Issue, error) {
+				t.Error("ListIssues should not be called for a single parent")
+				return nil, nil
+			}
+
+			var output bytes.Buffer
+			runner := &SyncRunner{Client: client, Owner: "owner", Repo: "repo", Out: &output}
+			err := runner.Run(SyncOptions{Parent: tt.parent})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Run() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if !reflect.DeepEqual(closed, tt.wantClosed) {
+				t.Errorf("closed = %v, want %v", closed, tt.wantClosed)
+			}
+			if output.String() != tt.wantOut {
+				t.Errorf("output = %q, want %q", output.String(), tt.wantOut)
+			}
+		})
+	}
+}
+
+func TestSyncRunnerReportsFailures(t *testing.T) {
+	client := syncFixture()
+	client.updateIssueFunc = func(opts api.UpdateIssueOptions) (*api.Issue, error) {
+		if opts.Number == 42 {
+			return nil, errors.New("forbidden")
+		}
+		return &api.Issue{Number: opts.Number}, nil
+	}
+	client.createCommentFunc = func(owner, repo string, number int, body string) error {
+		if number == 42 {
+			t.Error("commented on #42, which was not closed")
+		}
+		return nil
+	}
+
+	var output bytes.Buffer
+	runner := &SyncRunner{Client: client, Owner: "owner", Repo: "repo", Out: &output}
+	err := runner.Run(SyncOptions{})
+	if err == nil || err.Error() != "failed to sync 1 of 4 parents" {
+		t.Errorf("error = %v, want failed to sync 1 of 4 parents", err)
+	}
+	if !strings.Contains(output.String(), "Failed to close #42: forbidden") {
+		t.Errorf("output = %q", output.String())
+	}
+	if !strings.Contains(output.String(), "Closed #80 Initiative") {
+		t.Errorf("output = %q, want the other parents closed", output.String())
+	}
+}
+
+func TestSyncRunnerCommentFailureIsWarning(t *testing.T) {
+	client := syncFixture()
+	var closed []int
+	client.updateIssueFunc = func(opts api.UpdateIssueOptions) (*api.Issue, error) {
+		closed = append(closed, opts.Number)
+		return &api.Issue{Number: opts.Number}, nil
+	}
+	client.createCommentFunc = func(owner, repo string, number int, body string) error {
+		if number == 42 {
+			return errors.New("forbidden")
+		}
+		return nil
+	}
+
+	var output bytes.Buffer
+	runner := &SyncRunner{Client: client, Owner: "owner", Repo: "repo", Out: &output}
+	if err := runner.Run(SyncOptions{}); err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	if !reflect.DeepEqual(closed, []int{42, 70, 80}) {
+		t.Errorf("closed = %v, want [42 70 80]", closed)
+	}
+	if !strings.Contains(output.String(), "Closed #42 Epic (2/2 sub-issues closed)\nWarning: closed #42 but failed to comment: forbidden\n") {
+		t.Errorf("output = %q", output.String())
+	}
+}
@@ -0,0 +1,337 @@
+package cmd
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/gwyn/gh-subissue/internal/api"
+	"github.com/gwyn/gh-subissue/internal/debug"
+	"gopkg.in/yaml.v3"
+)
+
+// noResponse is what GitHub's web form writes for fields left empty.
+const noResponse = "_No response_"
+
+// issueTemplate is a parsed Markdown issue template or YAML issue form.
+type issueTemplate struct {
+	Name      string
+	About     string
+	FileName  string
+	Title     string
+	Labels    []string
+	Assignees []string
+	Body      string        // Markdown templates only
+	Form      []formElement // issue forms only
+}
+
+// IsForm reports whether the template is a YAML issue form.
+func (t *issueTemplate) IsForm() bool {
+	return t.Form != nil
+}
+
+// stringList accepts either a YAML sequence or a comma-separated string,
+// both of which GitHub allows for labels and assignees.
+type stringList []string
+
+func (s *stringList) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.ScalarNode {
+		*s = nil
+		for _, item := range strings.Split(node.Value, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				*s = append(*s, item)
+			}
+		}
+		return nil
+	}
+	var items []string
+	if err := node.Decode(&items); err != nil {
+		return err
+	}
+	*s = items
+	return nil
+}
+
+// formElement is one entry of an issue form's body.
+type formElement struct {
+	Type        string         `yaml:"type"` // markdown, input, textarea, dropdown or checkboxes
+	ID          string         `yaml:"id"`
+	Attributes  formAttributes `yaml:"attributes"`
+	Validations struct {
+		Required bool `yaml:"required"`
+	} `yaml:"validations"`
+}
+
+type formAttributes struct {
+	Label       string       `yaml:"label"`
+	Description string       `yaml:"description"`
+	Placeholder string       `yaml:"placeholder"`
+	Value       string       `yaml:"value"`
+	Render      string       `yaml:"render"`
+	Multiple    bool         `yaml:"multiple"`
+	Default     *int         `yaml:"default"`
+	Options     []formOption `yaml:"options"`
+}
+
+// formOption is a dropdown option (a plain string) or a checkbox
+// (a mapping with a label and an optional required flag).
+type formOption struct {
+	Label    string `yaml:"label"`
+	Required bool   `yaml:"required"`
+}
+
+func (o *formOption) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.ScalarNode {
+		o.Label = node.Value
+		return nil
+	}
+	type plain formOption
+	return node.Decode((*plain)(o))
+}
+
+// parseIssueTemplate parses a template file by its extension.
+func parseIssueTemplate(file api.IssueTemplateFile) (*issueTemplate, error) {
+	debug.Log("parseIssueTemplate", "file", file.Name)
+
+	switch strings.ToLower(path.Ext(file.Name)) {
+	case ".yml", ".yaml":
+		return parseIssueForm(file)
+	default:
+		return parseMarkdownTemplate(file)
+	}
+}
+
+// parseMarkdownTemplate parses a Markdown template with YAML front matter.
+func parseMarkdownTemplate(file api.IssueTemplateFile) (*issueTemplate, error) {
+	content := strings.ReplaceAll(file.Content, "\r\n", "\n")
+	tmpl := &issueTemplate{FileName: file.Name, Body: content}
+
+	if rest, ok := strings.CutPrefix(content, "---\n"); ok {
+		header, body, found := strings.Cut(rest, "\n---")
+		if found {
+			var meta struct {
+				Name      string     `yaml:"name"`
+				About     string     `yaml:"about"`
+				Title     string     `yaml:"title"`
+				Labels    stringList `yaml:"labels"`
+				Assignees stringList `yaml:"assignees"`
+			}
+			if err := yaml.Unmarshal([]byte(header), &meta); err != nil {
+				debug.Error("parseMarkdownTemplate", err, "file", file.Name)
+				return nil, fmt.Errorf("invalid front matter in %s: %w", file.Name, err)
+			}
+			tmpl.Name = meta.Name
+			tmpl.About = meta.About
+			tmpl.Title = meta.Title
+			tmpl.Labels = meta.Labels
+			tmpl.Assignees = meta.Assignees
+			// Drop the rest of the closing "---" line.
+			if _, after, ok := strings.Cut(body, "\n"); ok {
+				body = after
+			} else {
+				body = ""
+			}
+			tmpl.Body = strings.TrimLeft(body, "\n")
+		}
+	}
+
+	if tmpl.Name == "" {
+		tmpl.Name = strings.TrimSuffix(file.Name, path.Ext(file.Name))
+	}
+	return tmpl, nil
+}
+
+// parseIssueForm parses a YAML issue form.
+func parseIssueForm(file api.IssueTemplateFile) (*issueTemplate, error) {
+	var form struct {
+		Name        string        `yaml:"name"`
+		Description string        `yaml:"description"`
+		Title       string        `yaml:"title"`
+		Labels      stringList    `yaml:"labels"`
+		Assignees   stringList    `yaml:"assignees"`
+		Body        []formElement `yaml:"body"`
+	}
+	if err := yaml.Unmarshal([]byte(file.Content), &form); err != nil {
+		debug.Error("parseIssueForm", err, "file", file.Name)
+		return nil, fmt.Errorf("invalid issue form %s: %w", file.Name, err)
+	}
+
+	tmpl := &issueTemplate{
+		Name:      form.Name,
+		About:     form.Description,
+		FileName:  file.Name,
+		Title:     form.Title,
+		Labels:    form.Labels,
+		Assignees: form.Assignees,
+		Form:      form.Body,
+	}
+	if tmpl.Form == nil {
+		tmpl.Form = []formElement{}
+	}
+	if tmpl.Name == "" {
+		tmpl.Name = strings.TrimSuffix(file.Name, path.Ext(file.Name))
+	}
+	return tmpl, nil
+}
+
+// findIssueTemplate looks a template up by its name or file name, ignoring case.
+func findIssueTemplate(templates []*issueTemplate, name string) (*issueTemplate, error) {
+	for _, tmpl := range templates {
+		base := strings.TrimSuffix(tmpl.FileName, path.Ext(tmpl.FileName))
+		if strings.EqualFold(tmpl.Name, name) || strings.EqualFold(tmpl.FileName, name) || strings.EqualFold(base, name) {
+			return tmpl, nil
+		}
+	}
+
+	if len(templates) == 0 {
+		return nil, fmt.Errorf("issue template %q not found: the repository has no templates in .github/ISSUE_TEMPLATE", name)
+	}
+	names := make([]string, len(templates))
+	for i, tmpl := range templates {
+		names[i] = fmt.Sprintf("%q", tmpl.Name)
+	}
+	return nil, fmt.Errorf("issue template %q not found\nAvailable templates: %s", name, strings.Join(names, " "))
+}
+
+// formAnswer is the response to a single form element.
+type formAnswer struct {
+	element formElement
+	values  []string // selected options or the entered text
+}
+
+// promptIssueForm asks for every field of an issue form and returns the
+// issue body as GitHub's web form would write it.
+func promptIssueForm(p Prompter, tmpl *issueTemplate) (string, error) {
+	debug.Log("promptIssueForm", "template", tmpl.Name, "elements", len(tmpl.Form))
+
+	var answers []formAnswer
+	for _, el := range tmpl.Form {
+		label := el.Attributes.Label
+		required := el.Validations.Required
+
+		switch el.Type {
+		case "markdown":
+			continue
+
+		case "input", "textarea":
+			// A textarea holds multi-line text such as logs, so it opens the editor
+			var value string
+			var err error
+			if el.Type == "textarea" {
+				value, err = p.Editor(label, el.Attributes.Value)
+			} else {
+				value, err = p.Input(label, el.Attributes.Value)
+			}
+			if err != nil {
+				debug.Error("promptIssueForm", err, "stage", el.Type, "field", label)
+				return "", err
+			}
+			value = strings.TrimSpace(value)
+			if required && value == "" {
+				return "", fmt.Errorf("%q is required", label)
+			}
+			answers = append(answers, formAnswer{element: el, values: []string{value}})
+
+		case "dropdown":
+			options := make([]string, len(el.Attributes.Options))
+			for i, opt := range el.Attributes.Options {
+				options[i] = opt.Label
+			}
+			var values []string
+			if el.Attributes.Multiple {
+				var defaults []string
+				if d := el.Attributes.Default; d != nil && *d >= 0 && *d < len(options) {
+					defaults = []string{options[*d]}
+				}
+				indexes, err := p.MultiSelect(label, defaults, options)
+				if err != nil {
+					debug.Error("promptIssueForm", err, "stage", "multiselect", "field", label)
+					return "", err
+				}
+				for _, idx := range indexes {
+					values = append(values, options[idx])
+				}
+			} else {
+				def := ""
+				if d := el.Attributes.Default; d != nil && *d >= 0 && *d < len(options) {
+					def = options[*d]
+				}
+				idx, err := p.Select(label, def, options)
+				if err != nil {
+					debug.Error("promptIssueForm", err, "stage", "select", "field", label)
+					return "", err
+				}
+				values = []string{options[idx]}
+			}
+			if required && len(values) == 0 {
+				return "", fmt.Errorf("%q is required", label)
+			}
+			answers = append(answers, formAnswer{element: el, values: values})
+
+		case "checkboxes":
+			options := make([]string, len(el.Attributes.Options))
+			for i, opt := range el.Attributes.Options {
+				options[i] = opt.Label
+			}
+			indexes, err := p.MultiSelect(label, nil, options)
+			if err != nil {
+				debug.Error("promptIssueForm", err, "stage", "checkboxes", "field", label)
+				return "", err
+			}
+			checked := make(map[int]bool, len(indexes))
+			var values []string
+			for _, idx := range indexes {
+				checked[idx] = true
+				values = append(values, options[idx])
+			}
+			for i, opt := range el.Attributes.Options {
+				if opt.Required && !checked[i] {
+					return "", fmt.Errorf("%q must be checked", opt.Label)
+				}
+			}
+			answers = append(answers, formAnswer{element: el, values: values})
+
+		default:
+			debug.Log("promptIssueForm", "skipped_element_type", el.Type)
+		}
+	}
+
+	return renderIssueForm(answers), nil
+}
+
+// renderIssueForm formats form answers as "### Label" sections, matching
+// the body GitHub writes when an issue form is submitted on the web.
+func renderIssueForm(answers []formAnswer) string {
+	sections := make([]string, 0, len(answers))
+	for _, answer := range answers {
+		el := answer.element
+		var value string
+
+		switch el.Type {
+		case "checkboxes":
+			selected := make(map[string]bool, len(answer.values))
+			for _, v := range answer.values {
+				selected[v] = true
+			}
+			lines := make([]string, len(el.Attributes.Options))
+			for i, opt := range el.Attributes.Options {
+				mark := " "
+				if selected[opt.Label] {
+					mark = "X"
+				}
+				lines[i] = fmt.Sprintf("- [%s] %s", mark, opt.Label)
+			}
+			value = strings.Join(lines, "\n")
+		default:
+			value = strings.Join(answer.values, ", ")
+			if value == "" {
+				value = noResponse
+			} else if el.Type == "textarea" && el.Attributes.Render != "" {
+				value = fmt.Sprintf("```%s\n%s\n```", el.Attributes.Render, value)
+			}
+		}
+
+		sections = append(sections, fmt.Sprintf("### %s\n\n%s", el.Attributes.Label, value))
+	}
+	return strings.Join(sections, "\n\n")
+}
@@ -0,0 +1,234 @@
+package cmd
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/gwyn/gh-subissue/internal/api"
+)
+
+const bugReportTemplate = `---
+name: Bug report
+about: Something is broken
+title: "[Bug] "
+labels: bug, triage
+assignees:
+  - octocat
+---
+
+## Steps to reproduce
+`
+
+const featureForm = `name: Feature request
+description: Suggest an idea
+title: "[Feature]: "
+labels: [enhancement]
+body:
+  - type: markdown
+    attributes:
+      value: Thanks for taking the time!
+  - type: input
+    id: summary
+    attributes:
+      label: Summary
+    validations:
+      required: true
+  - type: textarea
+    id: logs
+    attributes:
+      label: Logs
+      render: shell
+  - type: textarea
+    id: context
+    attributes:
+      label: Context
+  - type: dropdown
+    id: area
+    attributes:
+      label: Area
+      multiple: true
+      options:
+        - CLI
+        - API
+        - Docs
+  - type: checkboxes
+    id: terms
+    attributes:
+      label: Checks
+      options:
+        - label: I searched existing issues
+          required: true
+        - label: I want to work on this
+`
+
+func TestParseIssueTemplateMarkdown(t *testing.T) {
+	tmpl, err := parseIssueTemplate(api.IssueTemplateFile{Name: "bug_report.md", Content: bugReportTemplate})
+	if err != nil {
+		t.Fatalf("parseIssueTemplate() error = %v", err)
+	}
+
+	if tmpl.IsForm() {
+		t.Error("Markdown template reported as a form")
+	}
+	if tmpl.Name != "Bug report" || tmpl.About != "Something is broken" || tmpl.Title != "[Bug] " {
+		t.Errorf("unexpected metadata: %+v", tmpl)
+	}
+	if !reflect.DeepEqual(tmpl.Labels, []string{"bug", "triage"}) {
+		t.Errorf("Labels = %v, want [bug triage]", tmpl.Labels)
+	}
+	if !reflect.DeepEqual(tmpl.Assignees, []string{"octocat"}) {
+		t.Errorf("Assignees = %v, want [octocat]", tmpl.Assignees)
+	}
+	if tmpl.Body != "## Steps to reproduce\n" {
+		t.Errorf("Body = %q", tmpl.Body)
+	}
+}
+
+func TestParseIssueTemplateWithoutFrontMatter(t *testing.T) {
+	tmpl, err := parseIssueTemplate(api.IssueTemplateFile{Name: "plain.md", Content: "Just a body\n"})
+	if err != nil {
+		t.Fatalf("parseIssueTemplate() error = %v", err)
+	}
+	if tmpl.Name != "plain" || tmpl.Body != "Just a body\n" {
+		t.Errorf("unexpected template: %+v", tmpl)
+	}
+}
+
+func TestParseIssueTemplateForm(t *testing.T) {
+	tmpl, err := parseIssueTemplate(api.IssueTemplateFile{Name: "feature.yml", Content: featureForm})
+	if err != nil {
+		t.Fatalf("parseIssueTemplate() error = %v", err)
+	}
+
+	if !tmpl.IsForm() {
+		t.Fatal("issue form not reported as a form")
+	}
+	if tmpl.Name != "Feature request" || tmpl.Title != "[Feature]: " {
+		t.Errorf("unexpected metadata: %+v", tmpl)
+	}
+	if !reflect.DeepEqual(tmpl.Labels, []string{"enhancement"}) {
+		t.Errorf("Labels = %v, want [enhancement]", tmpl.Labels)
+	}
+	if len(tmpl.Form) != 6 {
+		t.Fatalf("got %d form elements, want 6", len(tmpl.Form))
+	}
+	checks := tmpl.Form[5].Attributes.Options
+	if len(checks) != 2 || !checks[0].Required || checks[1].Label != "I want to work on this" {
+		t.Errorf("unexpected checkbox options: %+v", checks)
+	}
+}
+
+func TestParseIssueTemplateInvalidForm(t *testing.T) {
+	_, err := parseIssueTemplate(api.IssueTemplateFile{Name: "broken.yml", Content: "body: [unclosed"})
+	if err == nil || !strings.Contains(err.Error(), "broken.yml") {
+		t.Errorf("expected error naming the file, got %v", err)
+	}
+}
+
+func TestFindIssueTemplate(t *testing.T) {
+	templates := []*issueTemplate{
+		{Name: "Bug report", FileName: "bug_report.md"},
+		{Name: "Feature request", FileName: "feature.yml"},
+	}
+
+	for _, name := range []string{"Bug report", "bug REPORT", "bug_report.md", "bug_report"} {
+		tmpl, err := findIssueTemplate(templates, name)
+		if err != nil || tmpl != templates[0] {
+			t.Errorf("findIssueTemplate(%q) = %v, %v", name, tmpl, err)
+		}
+	}
+
+	_, err := findIssueTemplate(templates, "question")
+	if err == nil {
+		t.Fatal("expected error for unknown template")
+	}
+	if !strings.Contains(err.Error(), `"Bug report" "Feature request"`) {
+		t.Errorf("error should list available templates, got: %v", err)
+	}
+}
+
+func TestPromptIssueForm(t *testing.T) {
+	tmpl, err := parseIssueTemplate(api.IssueTemplateFile{Name: "feature.yml", Content: featureForm})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var inputs, edited []string
+	prompter := &mockPrompterInCreate{
+		inputFunc: func(prompt, defaultValue string) (string, error) {
+			inputs = append(inputs, prompt)
+			return "Add dark mode", nil
+		},
+		editorFunc: func(prompt, defaultValue string) (string, error) {
+			edited = append(edited, prompt)
+			if prompt == "Logs" {
+				return "exit status 1\n", nil
+			}
+			return "", nil
+		},
+		multiSelectFunc: func(prompt string, defaultValues, options []string) ([]int, error) {
+			if prompt == "Area" {
+				return []int{0, 2}, nil
+			}
+			return []int{0}, nil
+		},
+	}
+
+	body, err := promptIssueForm(prompter, tmpl)
+	if err != nil {
+		t.Fatalf("promptIssueForm() error = %v", err)
+	}
+
+	want := "### Summary\n\nAdd dark mode\n\n" +
+		"### Logs\n\n```shell\nexit status 1\n```\n\n" +
+		"### Context\n\n_No response_\n\n" +
+		"### Area\n\nCLI, Docs\n\n" +
+		"### Checks\n\n- [X] I searched existing issues\n- [ ] I want to work on this"
+	if body != want {
+		t.Errorf("body =\n%s\nwant\n%s", body, want)
+	}
+	if !reflect.DeepEqual(inputs, []string{"Summary"}) {
+		t.Errorf("input prompts = %v, want [Summary]", inputs)
+	}
+	if !reflect.DeepEqual(edited, []string{"Logs", "Context"}) {
+		t.Errorf("editor prompts = %v, want the textareas [Logs Context]", edited)
+	}
+}
+
+func TestPromptIssueFormRequired(t *testing.T) {
+	tmpl, err := parseIssueTemplate(api.IssueTemplateFile{Name: "feature.yml", Content: featureForm})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		prompter *mockPrompterInCreate
+		wantErr  string
+	}{
+		{
+			name:     "empty required input",
+			prompter: &mockPrompterInCreate{},
+			wantErr:  `"Summary" is required`,
+		},
+		{
+			name: "unchecked required checkbox",
+			prompter: &mockPrompterInCreate{
+				inputFunc: func(prompt, defaultValue string) (string, error) {
+					return "text", nil
+				},
+			},
+			wantErr: `"I searched existing issues" must be checked`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := promptIssueForm(tt.prompter, tmpl)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
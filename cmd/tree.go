@@ -0,0 +1,220 @@
+package cmd
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+
+	"github.com/gwyn/gh-subissue/internal/api"
+	"github.com/gwyn/gh-subissue/internal/debug"
+)
+
+// TreeOptions contains the parsed command line options for the tree command.
+type TreeOptions struct {
+	Root  string // root issue number or URL (interactive if empty)
+	Repo  string
+	Depth int    // maximum levels below the root; 0 means unlimited
+	State string // "open", "closed" or "all"
+}
+
+// ParseTreeFlags parses command line flags for the tree command.
+func ParseTreeFlags(args []string) (*TreeOptions, error) {
+	debug.Log("ParseTreeFlags", "args", args)
+
+	opts := &TreeOptions{}
+	fs := flag.NewFlagSet("tree", flag.ContinueOnError)
+
+	fs.StringVar(&opts.Repo, "repo", "", "Repository (owner/repo)")
+	fs.StringVar(&opts.Repo, "R", "", "Repository (owner/repo)")
+
+	fs.IntVar(&opts.Depth, "depth", 0, "Maximum depth to show (0 for unlimited)")
+	fs.IntVar(&opts.Depth, "d", 0, "Maximum depth to show (0 for unlimited)")
+
+	fs.StringVar(&opts.State, "state", "all", "Show only sub-issues in this state: {open|closed|all}")
+	fs.StringVar(&opts.State, "s", "all", "Show only sub-issues in this state: {open|closed|all}")
+
+	positional, flagArgs := splitArgs(args,
+		"-R", "--repo", "-repo", "-d", "--depth", "-depth", "-s", "--state", "-state")
+	if err := fs.Parse(flagArgs); err != nil {
+		debug.Error("ParseTreeFlags", err, "stage", "fs.Parse")
+		return nil, err
+	}
+
+	if len(positional) > 1 {
+		return nil, fmt.Errorf("only one root issue can be given, got %d", len(positional))
+	}
+	if len(positional) == 1 {
+		opts.Root = positional[0]
+	}
+
+	if opts.Depth < 0 {
+		return nil, errors.New("--depth cannot be negative")
+	}
+	switch opts.State {
+	case "open", "closed", "all":
+	default:
+		return nil, fmt.Errorf("invalid state %q (expected open, closed or all)", opts.State)
+	}
+
+	debug.Log("ParseTreeFlags", "parsed", fmt.Sprintf("%+v", opts))
+	return opts, nil
+}
+
+// TreeAPIClient defines the interface for tree operations.
+type TreeAPIClient interface {
+	GetIssue(owner, repo string, number int) (*api.Issue, error)
+	ListIssues(opts api.ListIssuesOptions) ([]api.Issue, error)
+	ListSubIssues(opts api.ListSubIssuesOptions) ([]api.Issue, error)
+}
+
+// TreeRunner executes the tree subcommand.
+type TreeRunner struct {
+	Client   TreeAPIClient
+	Owner    string
+	Repo     string
+	Out      io.Writer
+	Prompter Prompter // nil means non-interactive mode
+}
+
+// treeNode is an issue together with the sub-issues that will be drawn below it.
+type treeNode struct {
+	issue     api.Issue
+	ref       IssueRef
+	children  []*treeNode
+	completed int
+	total     int
+	expanded  bool // false when the depth limit stopped the walk
+}
+
+// Run executes the tree command.
+func (r *TreeRunner) Run(opts TreeOptions) error {
+	debug.Log("TreeRunner.Run", "root", opts.Root, "depth", opts.Depth, "state", opts.State)
+
+	if opts.State == "" {
+		opts.State = "all"
+	}
+
+	var rootRef IssueRef
+	if opts.Root == "" {
+		if r.Prompter == nil {
+			return fmt.Errorf("issue number is required when not running interactively\nExample: gh subissue tree 42 -R %s/%s", r.Owner, r.Repo)
+		}
+
+		issues, err := r.Client.ListIssues(api.ListIssuesOptions{
+			Owner: r.Owner,
+			Repo:  r.Repo,
+			State: "open",
+			Limit: pickerIssueLimit,
+		})
+		if err != nil {
+			debug.Error("TreeRunner.Run", err, "stage", "list_issues")
+			return fmt.Errorf("failed to list issues: %w", err)
+		}
+
+		selected, err := SelectParentIssue(r.Prompter, issues)
+		if err != nil {
+			debug.Error("TreeRunner.Run", err, "stage", "select_parent")
+			return err
+		}
+		rootRef = IssueRef{Owner: r.Owner, Repo: r.Repo, Number: selected}
+	} else {
+		ref, err := ParseIssueRef(opts.Root)
+		if err != nil {
+			return err
+		}
+		if ref.Owner == "" {
+			ref.Owner, ref.Repo = r.Owner, r.Repo
+		}
+		rootRef = ref
+	}
+
+	root, err := r.Client.GetIssue(rootRef.Owner, rootRef.Repo, rootRef.Number)
+	if err != nil {
+		debug.Error("TreeRunner.Run", err, "stage", "get_root")
+		return err
+	}
+
+	node, err := r.build(*root, rootRef, 0, opts, map[int64]bool{})
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintln(r.Out, formatTreeNode(node, rootRef))
+	renderTree(r.Out, node.children, "", rootRef)
+
+	debug.Log("TreeRunner.Run", "result", "success")
+	return nil
+}
+
+// build walks the sub-issues of an issue recursively. ref locates the issue,
+// so sub-issues in other repositories are listed from their own repository.
+// visited guards against cycles so a malformed hierarchy cannot recurse
+// forever.
+func (r *TreeRunner) build(issue api.Issue, ref IssueRef, depth int, opts TreeOptions, visited map[int64]bool) (*treeNode, error) {
+	node := &treeNode{issue: issue, ref: ref}
+	if visited[issue.ID] {
+		return node, nil
+	}
+	visited[issue.ID] = true
+
+	if opts.Depth > 0 && depth >= opts.Depth {
+		return node, nil
+	}
+
+	children, err := r.Client.ListSubIssues(api.ListSubIssuesOptions{
+		Owner:       ref.Owner,
+		Repo:        ref.Repo,
+		ParentIssue: ref.Number,
+	})
+	if err != nil {
+		debug.Error("TreeRunner.build", err, "stage", "list_sub_issues", "issue", ref.String())
+		return nil, err
+	}
+	node.expanded = true
+	node.total = len(children)
+
+	for _, child := range children {
+		if child.State == "closed" {
+			node.completed++
+		}
+		if opts.State != "all" && child.State != opts.State {
+			continue
+		}
+		childNode, err := r.build(child, issueRefOf(child, ref.Owner, ref.Repo), depth+1, opts, visited)
+		if err != nil {
+			return nil, err
+		}
+		node.children = append(node.children, childNode)
+	}
+
+	return node, nil
+}
+
+// renderTree draws nodes with box-drawing characters below the given prefix.
+// Issues outside root's repository are labelled with their owner/repo.
+func renderTree(w io.Writer, nodes []*treeNode, prefix string, root IssueRef) {
+	for i, node := range nodes {
+		branch, indent := "├── ", "│   "
+		if i == len(nodes)-1 {
+			branch, indent = "└── ", "    "
+		}
+		fmt.Fprintf(w, "%s%s%s\n", prefix, branch, formatTreeNode(node, root))
+		renderTree(w, node.children, prefix+indent, root)
+	}
+}
+
+// formatTreeNode formats a node as "#42 [Epic] Title (open, 1/3 done)".
+// The type is shown only when the issue has one, and the count only when
+// the node's sub-issues were fetched. An issue in another repository than
+// root is shown as "owner/repo#42".
+func formatTreeNode(node *treeNode, root IssueRef) string {
+	label := node.ref.RelativeTo(root.Owner, root.Repo)
+	if t := node.issue.TypeName(); t != "" {
+		label += " [" + t + "]"
+	}
+	if node.expanded && node.total > 0 {
+		return fmt.Sprintf("%s %s (%s, %d/%d done)", label, node.issue.Title, node.issue.State, node.completed, node.total)
+	}
+	return fmt.Sprintf("%s %s (%s)", label, node.issue.Title, node.issue.State)
+}
@@ -0,0 +1,304 @@
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/gwyn/gh-subissue/internal/api"
+)
+
+func TestParseTreeFlags(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		want    TreeOptions
+		wantErr bool
+	}{
+		{
+			name: "defaults",
+			args: []string{"42"},
+			want: TreeOptions{Root: "42", State: "all"},
+		},
+		{
+			name: "depth and state after root",
+			args: []string{"42", "--depth", "2", "--state", "open"},
+			want: TreeOptions{Root: "42", Depth: 2, State: "open"},
+		},
+		{
+			name: "short flags before root",
+			args: []string{"-d", "1", "-s", "closed", "-R", "owner/repo", "42"},
+			want: TreeOptions{Root: "42", Repo: "owner/repo", Depth: 1, State: "closed"},
+		},
+		{
+			name:    "invalid state",
+			args:    []string{"42", "--state", "merged"},
+			wantErr: true,
+		},
+		{
+			name:    "negative depth",
+			args:    []string{"42", "--depth", "-1"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts, err := ParseTreeFlags(tt.args)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ParseTreeFlags() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			if *opts != tt.want {
+				t.Errorf("ParseTreeFlags() = %+v, want %+v", *opts, tt.want)
+			}
+		})
+	}
+}
+
+// mockTreeAPIClient implements the TreeAPIClient interface for testing.
+// Requests for a repository named in repos are served by that fixture.
+type mockTreeAPIClient struct {
+	issues    map[int]api.Issue
+	subIssues map[int][]api.Issue
+	listCalls []int
+	repos     map[string]*mockTreeAPIClient
+}
+
+// repo returns the fixture serving owner/repo.
+func (m *mockTreeAPIClient) repo(owner, repo string) *mockTreeAPIClient {
+	if other, ok := m.repos[owner+"/"+repo]; ok {
+		return other
+	}
+	return m
+}
+
+func (m *mockTreeAPIClient) GetIssue(owner, repo string, number int) (*api.Issue, error) {
+	issue, ok := m.repo(owner, repo).issues[number]
+	if !ok {
+		return nil, errors.New("not found")
+	}
+	return &issue, nil
+}
+
+func (m *mockTreeAPIClient) ListIssues(opts api.ListIssuesOptions) ([]api.Issue, error) {
+	var issues []api.Issue
+	for _, issue := range m.issues {
+		issues = append(issues, issue)
+	}
+	return issues, nil
+}
+
+func (m *mockTreeAPIClient) ListSubIssues(opts api.ListSubIssuesOptions) ([]api.Issue, error) {
+	m = m.repo(opts.Owner, opts.Repo)
+	m.listCalls = append(m.listCalls, opts.ParentIssue)
+	return m.subIssues[opts.ParentIssue], nil
+}
+
+// Compile-time check
+var _ TreeAPIClient = (*mockTreeAPIClient)(nil)
+
+func newTreeFixture() *mockTreeAPIClient {
+	return &mockTreeAPIClient{
+		issues: map[int]api.Issue{
+			1: {ID: 100, Number: 1, Title: "Epic", State: "open", Type: &api.IssueType{Name: "Epic"}},
+		},
+		subIssues: map[int][]api.Issue{
+			1: {
+				{ID: 200, Number: 2, Title: "Feature A", State: "open", Type: &api.IssueType{Name: "Feature"}},
+				{ID: 300, Number: 3, Title: "Feature B", State: "closed"},
+			},
+			2: {
+				{ID: 400, Number: 4, Title: "Task A1", State: "closed"},
+				{ID: 500, Number: 5, Title: "Task A2", State: "open"},
+			},
+			3: {
+				{ID: 600, Number: 6, Title: "Task B1", State: "closed"},
+			},
+			5: {
+				{ID: 700, Number: 7, Title: "Step", State: "open"},
+			},
+		},
+	}
+}
+
+func TestTreeRunnerRun(t *testing.T) {
+	tests := []struct {
+		name       string
+		opts       TreeOptions
+		wantOutput string
+	}{
+		{
+			name: "full tree",
+			opts: TreeOptions{Root: "1", State: "all"},
+			wantOutput: "#1 [Epic] Epic (open, 1/2 done)\n" +
+				"├── #2 [Feature] Feature A (open, 1/2 done)\n" +
+				"│   ├── #4 Task A1 (closed)\n" +
+				"│   └── #5 Task A2 (open, 0/1 done)\n" +
+				"│       └── #7 Step (open)\n" +
+				"└── #3 Feature B (closed, 1/1 done)\n" +
+				"    └── #6 Task B1 (closed)\n",
+		},
+		{
+			name: "depth limit",
+			opts: TreeOptions{Root: "1", Depth: 1, State: "all"},
+			wantOutput: "#1 [Epic] Epic (open, 1/2 done)\n" +
+				"├── #2 [Feature] Feature A (open)\n" +
+				"└── #3 Feature B (closed)\n",
+		},
+		{
+			name: "open only hides closed branches",
+			opts: TreeOptions{Root: "1", State: "open"},
+			wantOutput: "#1 [Epic] Epic (open, 1/2 done)\n" +
+				"└── #2 [Feature] Feature A (open, 1/2 done)\n" +
+				"    └── #5 Task A2 (open, 0/1 done)\n" +
+				"        └── #7 Step (open)\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var output bytes.Buffer
+			runner := &TreeRunner{
+				Client: newTreeFixture(),
+				Owner:  "owner",
+				Repo:   "repo",
+				Out:    &output,
+			}
+
+			if err := runner.Run(tt.opts); err != nil {
+				t.Fatalf("Run() error = %v", err)
+			}
+			if output.String() != tt.wantOutput {
+				t.Errorf("output =\n%s\nwant\n%s", output.String(), tt.wantOutput)
+			}
+		})
+	}
+}
+
+func TestTreeRunnerDepthLimitSkipsRequests(t *testing.T) {
+	client := newTreeFixture()
+	var output bytes.Buffer
+	runner := &TreeRunner{
+		Client: client,
+		Owner:  "owner",
+		Repo:   "repo",
+		Out:    &output,
+	}
+
+	if err := runner.Run(TreeOptions{Root: "1", Depth: 1}); err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	if len(client.listCalls) != 1 || client.listCalls[0] != 1 {
+		t.Errorf("ListSubIssues calls = %v, want [1]", client.listCalls)
+	}
+}
+
+func TestTreeRunnerCycle(t *testing.T) {
+	client := &mockTreeAPIClient{
+		issues: map[int]api.Issue{
+			1: {ID: 100, Number: 1, Title: "A", State: "open"},
+		},
+		subIssues: map[int][]api.Issue{
+			1: {{ID: 200, Number: 2, Title: "B", State: "open"}},
+			2: {{ID: 100, Number: 1, Title: "A", State: "open"}},
+		},
+	}
+
+	var output bytes.Buffer
+	runner := &TreeRunner{
+		Client: client,
+		Owner:  "owner",
+		Repo:   "repo",
+		Out:    &output,
+	}
+
+	if err := runner.Run(TreeOptions{Root: "1"}); err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	if len(client.listCalls) != 2 {
+		t.Errorf("expected walk to stop at the repeated issue, got calls %v", client.listCalls)
+	}
+}
+
+func TestTreeRunnerCrossRepo(t *testing.T) {
+	other := &mockTreeAPIClient{
+		issues: map[int]api.Issue{
+			9: {ID: 900, Number: 9, Title: "Shared", State: "open"},
+		},
+		subIssues: map[int][]api.Issue{
+			9: {{ID: 910, Number: 10, Title: "Shared task", State: "closed"}},
+		},
+	}
+	// #9 in owner/repo is a different issue; its sub-issues must not appear.
+	client := &mockTreeAPIClient{
+		issues: map[int]api.Issue{
+			1: {ID: 100, Number: 1, Title: "Epic", State: "open"},
+		},
+		subIssues: map[int][]api.Issue{
+			1: {
+				{ID: 900, Number: 9, Title: "Shared", State: "open", RepositoryURL: "https://api.github.com/repos/other/lib"},
+				{ID: 200, Number: 2, Title: "Local", State: "open"},
+			},
+			9: {{ID: 990, Number: 99, Title: "Wrong subtree", State: "open"}},
+		},
+		repos: map[string]*mockTreeAPIClient{"other/lib": other},
+	}
+
+	tests := []struct {
+		name       string
+		root       string
+		wantOutput string
+	}{
+		{
+			name: "cross-repo child",
+			root: "1",
+			wantOutput: "#1 Epic (open, 0/2 done)\n" +
+				"├── other/lib#9 Shared (open, 1/1 done)\n" +
+				"│   └── other/lib#10 Shared task (closed)\n" +
+				"└── #2 Local (open)\n",
+		},
+		{
+			name: "root from another repository",
+			root: "https://github.com/other/lib/issues/9",
+			wantOutput: "#9 Shared (open, 1/1 done)\n" +
+				"└── #10 Shared task (closed)\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var output bytes.Buffer
+			runner := &TreeRunner{
+				Client: client,
+				Owner:  "owner",
+				Repo:   "repo",
+				Out:    &output,
+			}
+
+			if err := runner.Run(TreeOptions{Root: tt.root}); err != nil {
+				t.Fatalf("Run() error = %v", err)
+			}
+			if output.String() != tt.wantOutput {
+				t.Errorf("output =\n%s\nwant\n%s", output.String(), tt.wantOutput)
+			}
+		})
+	}
+}
+
+func TestTreeRunnerNoPrompterRequiresRoot(t *testing.T) {
+	var output bytes.Buffer
+	runner := &TreeRunner{
+		Client: newTreeFixture(),
+		Owner:  "owner",
+		Repo:   "repo",
+		Out:    &output,
+	}
+
+	if err := runner.Run(TreeOptions{}); err == nil {
+		t.Error("expected error when no root given without prompter")
+	}
+}
@@ -0,0 +1,68 @@
+package api
+
+// IssueFields lists the field names available for JSON output of an Issue.
+var IssueFields = []string{"id", "number", "title", "state", "type", "url"}
+
+// ExportData returns the requested fields of the issue for JSON output.
+func (i Issue) ExportData(fields []string) map[string]interface{} {
+	data := make(map[string]interface{}, len(fields))
+	for _, f := range fields {
+		switch f {
+		case "id":
+			data[f] = i.ID
+		case "number":
+			data[f] = i.Number
+		case "title":
+			data[f] = i.Title
+		case "state":
+			data[f] = i.State
+		case "type":
+			data[f] = i.TypeName()
+		case "url":
+			data[f] = i.URL
+		}
+	}
+	return data
+}
+
+// RepositoryFields lists the field names available for JSON output of a Repository.
+var RepositoryFields = []string{"name", "fullName", "hasIssues", "archived", "private"}
+
+// ExportData returns the requested fields of the repository for JSON output.
+func (r Repository) ExportData(fields []string) map[string]interface{} {
+	data := make(map[string]interface{}, len(fields))
+	for _, f := range fields {
+		switch f {
+		case "name":
+			data[f] = r.Name
+		case "fullName":
+			data[f] = r.FullName
+		case "hasIssues":
+			data[f] = r.HasIssues
+		case "archived":
+			data[f] = r.Archived
+		case "private":
+			data[f] = r.Private
+		}
+	}
+	return data
+}
+
+// IssueResultFields lists the field names available for JSON output of an IssueResult.
+var IssueResultFields = []string{"id", "number", "url"}
+
+// ExportData returns the requested fields of the created issue for JSON output.
+func (r IssueResult) ExportData(fields []string) map[string]interface{} {
+	data := make(map[string]interface{}, len(fields))
+	for _, f := range fields {
+		switch f {
+		case "id":
+			data[f] = r.ID
+		case "number":
+			data[f] = r.Number
+		case "url":
+			data[f] = r.URL
+		}
+	}
+	return data
+}
@@ -0,0 +1,47 @@
+package api
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestIssueExportData(t *testing.T) {
+	issue := Issue{ID: 1, Number: 42, Title: "Parent", State: "open", URL: "https://github.com/o/r/issues/42"}
+
+	got := issue.ExportData([]string{"number", "title"})
+	want := map[string]interface{}{"number": 42, "title": "Parent"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ExportData() = %v, want %v", got, want)
+	}
+
+	// Every advertised field must be exported
+	all := issue.ExportData(IssueFields)
+	if len(all) != len(IssueFields) {
+		t.Errorf("ExportData(IssueFields) returned %d fields, want %d", len(all), len(IssueFields))
+	}
+}
+
+func TestRepositoryExportData(t *testing.T) {
+	repo := Repository{Name: "r", FullName: "o/r", HasIssues: true}
+
+	got := repo.ExportData([]string{"fullName", "hasIssues"})
+	want := map[string]interface{}{"fullName": "o/r", "hasIssues": true}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ExportData() = %v, want %v", got, want)
+	}
+
+	all := repo.ExportData(RepositoryFields)
+	if len(all) != len(RepositoryFields) {
+		t.Errorf("ExportData(RepositoryFields) returned %d fields, want %d", len(all), len(RepositoryFields))
+	}
+}
+
+func TestIssueResultExportData(t *testing.T) {
+	result := IssueResult{ID: 7, Number: 43, URL: "https://github.com/o/r/issues/43"}
+
+	all := result.ExportData(IssueResultFields)
+	want := map[string]interface{}{"id": int64(7), "number": 43, "url": "https://github.com/o/r/issues/43"}
+	if !reflect.DeepEqual(all, want) {
+		t.Errorf("ExportData() = %v, want %v", all, want)
+	}
+}
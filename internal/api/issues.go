@@ -5,6 +5,8 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"strings"
+	"time"
 
 	"github.com/gwyn/gh-subissue/internal/debug"
 )
@@ -13,6 +15,14 @@ import (
 type Client struct {
 	HTTPClient *http.Client
 	BaseURL    string
+
+	// MaxAttempts is how many times a request is sent before giving up on
+	// network errors, server errors and rate limits; 0 means
+	// DefaultMaxAttempts and 1 disables retries.
+	MaxAttempts int
+
+	// Sleep waits between attempts; nil means time.Sleep.
+	Sleep func(time.Duration)
 }
 
 // CreateIssueOptions contains parameters for creating an issue.
@@ -24,6 +34,7 @@ type CreateIssueOptions struct {
 	Labels    []string
 	Assignees []string
 	Milestone int
+	Type      string // issue type name; organizations only
 }
 
 // IssueResult contains the response from creating an issue.
@@ -35,18 +46,68 @@ type IssueResult struct {
 
 // LinkSubIssueOptions contains parameters for linking a sub-issue.
 type LinkSubIssueOptions struct {
-	Owner       string
-	Repo        string
-	ParentIssue int
-	SubIssueID  int64
+	Owner         string
+	Repo          string
+	ParentIssue   int
+	SubIssueID    int64
+	ReplaceParent bool // move the sub-issue if it already has a parent
 }
 
 // Issue represents a GitHub issue.
 type Issue struct {
-	ID     int64  `json:"id"`
+	ID            int64      `json:"id"`
+	Number        int        `json:"number"`
+	Title         string     `json:"title"`
+	Body          string     `json:"body"`
+	State         string     `json:"state"`        // "open" or "closed"
+	StateReason   string     `json:"state_reason"` // "completed", "not_planned" or "reopened"
+	URL           string     `json:"html_url"`
+	RepositoryURL string     `json:"repository_url"` // API URL, e.g. https://api.github.com/repos/owner/repo
+	Assignees     []User     `json:"assignees"`
+	Labels        []Label    `json:"labels"`
+	Milestone     *Milestone `json:"milestone"`
+	Type          *IssueType `json:"type"`
+
+	// SubIssuesSummary is nil when the API response did not include it.
+	SubIssuesSummary *SubIssuesSummary `json:"sub_issues_summary"`
+}
+
+// Label is an issue label.
+type Label struct {
+	Name  string `json:"name"`
+	Color string `json:"color,omitempty"` // hex color without the leading "#"
+}
+
+// Milestone is an issue milestone.
+type Milestone struct {
 	Number int    `json:"number"`
 	Title  string `json:"title"`
-	URL    string `json:"html_url"`
+	State  string `json:"state,omitempty"` // "open" or "closed"
+}
+
+// SubIssuesSummary is GitHub's progress summary of an issue's sub-issues.
+type SubIssuesSummary struct {
+	Total            int `json:"total"`
+	Completed        int `json:"completed"`
+	PercentCompleted int `json:"percent_completed"`
+}
+
+// Repository returns the owner and name of the repository the issue belongs to,
+// or empty strings when the API did not include repository_url.
+func (i Issue) Repository() (owner, repo string) {
+	parts := strings.Split(strings.TrimSuffix(i.RepositoryURL, "/"), "/")
+	if len(parts) < 2 || parts[len(parts)-2] == "" {
+		return "", ""
+	}
+	return parts[len(parts)-2], parts[len(parts)-1]
+}
+
+// TypeName returns the name of the issue's type, or "" when it has none.
+func (i Issue) TypeName() string {
+	if i.Type == nil {
+		return ""
+	}
+	return i.Type.Name
 }
 
 // CreateIssue creates a new issue in the specified repository.
@@ -74,6 +135,10 @@ func (c *Client) CreateIssue(opts CreateIssueOptions) (*IssueResult, error) {
 		payload["milestone"] = opts.Milestone
 		debug.Log("CreateIssue", "milestone", opts.Milestone)
 	}
+	if opts.Type != "" {
+		payload["type"] = opts.Type
+		debug.Log("CreateIssue", "type", opts.Type)
+	}
 
 	body, err := json.Marshal(payload)
 	if err != nil {
@@ -89,7 +154,7 @@ func (c *Client) CreateIssue(opts CreateIssueOptions) (*IssueResult, error) {
 	req.Header.Set("Content-Type", "application/json")
 
 	debug.Log("CreateIssue", "action", "sending_request")
-	resp, err := c.HTTPClient.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		debug.Error("CreateIssue", err, "stage", "do_request")
 		return nil, fmt.Errorf("failed to send request: %w", err)
@@ -127,7 +192,7 @@ func (c *Client) CreateIssue(opts CreateIssueOptions) (*IssueResult, error) {
 
 // LinkSubIssue links a sub-issue to a parent issue.
 func (c *Client) LinkSubIssue(opts LinkSubIssueOptions) error {
-	debug.Log("LinkSubIssue", "owner", opts.Owner, "repo", opts.Repo, "parent_issue", opts.ParentIssue, "sub_issue_id", opts.SubIssueID)
+	debug.Log("LinkSubIssue", "owner", opts.Owner, "repo", opts.Repo, "parent_issue", opts.ParentIssue, "sub_issue_id", opts.SubIssueID, "replace_parent", opts.ReplaceParent)
 
 	url := fmt.Sprintf("%s/repos/%s/%s/issues/%d/sub_issues", c.BaseURL, opts.Owner, opts.Repo, opts.ParentIssue)
 	debug.Log("LinkSubIssue", "url", url)
@@ -135,6 +200,9 @@ func (c *Client) LinkSubIssue(opts LinkSubIssueOptions) error {
 	payload := map[string]interface{}{
 		"sub_issue_id": opts.SubIssueID,
 	}
+	if opts.ReplaceParent {
+		payload["replace_parent"] = true
+	}
 
 	body, err := json.Marshal(payload)
 	if err != nil {
@@ -150,7 +218,7 @@ func (c *Client) LinkSubIssue(opts LinkSubIssueOptions) error {
 	req.Header.Set("Content-Type", "application/json")
 
 	debug.Log("LinkSubIssue", "action", "sending_request")
-	resp, err := c.HTTPClient.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		debug.Error("LinkSubIssue", err, "stage", "do_request")
 		return fmt.Errorf("failed to send request: %w", err)
@@ -172,12 +240,133 @@ func (c *Client) LinkSubIssue(opts LinkSubIssueOptions) error {
 	return nil
 }
 
+// RemoveSubIssueOptions contains parameters for unlinking a sub-issue.
+type RemoveSubIssueOptions struct {
+	Owner       string
+	Repo        string
+	ParentIssue int
+	SubIssueID  int64
+}
+
+// RemoveSubIssue unlinks a sub-issue from a parent issue.
+// The sub-issue itself is left open.
+func (c *Client) RemoveSubIssue(opts RemoveSubIssueOptions) error {
+	debug.Log("RemoveSubIssue", "owner", opts.Owner, "repo", opts.Repo, "parent_issue", opts.ParentIssue, "sub_issue_id", opts.SubIssueID)
+
+	url := fmt.Sprintf("%s/repos/%s/%s/issues/%d/sub_issue", c.BaseURL, opts.Owner, opts.Repo, opts.ParentIssue)
+	debug.Log("RemoveSubIssue", "url", url)
+
+	payload := map[string]interface{}{
+		"sub_issue_id": opts.SubIssueID,
+	}
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		debug.Error("RemoveSubIssue", err, "stage", "marshal")
+		return fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	req, err := http.NewRequest("DELETE", url, bytes.NewReader(body))
+	if err != nil {
+		debug.Error("RemoveSubIssue", err, "stage", "new_request")
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	debug.Log("RemoveSubIssue", "action", "sending_request")
+	resp, err := c.do(req)
+	if err != nil {
+		debug.Error("RemoveSubIssue", err, "stage", "do_request")
+		return fmt.Errorf("failed to send request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	debug.Log("RemoveSubIssue", "status_code", resp.StatusCode)
+	if resp.StatusCode != http.StatusOK {
+		var errResp struct {
+			Message string `json:"message"`
+		}
+		json.NewDecoder(resp.Body).Decode(&errResp)
+		apiErr := newAPIError(resp.StatusCode, errResp.Message, "remove sub-issue")
+		debug.Error("RemoveSubIssue", apiErr, "status", resp.StatusCode)
+		return apiErr
+	}
+
+	debug.Log("RemoveSubIssue", "result", "success")
+	return nil
+}
+
+// ReprioritizeSubIssueOptions contains parameters for reordering a sub-issue.
+// Set exactly one of AfterID or BeforeID to the ID of a sibling sub-issue.
+type ReprioritizeSubIssueOptions struct {
+	Owner       string
+	Repo        string
+	ParentIssue int
+	SubIssueID  int64
+	AfterID     int64
+	BeforeID    int64
+}
+
+// ReprioritizeSubIssue moves a sub-issue to a new position within its parent.
+func (c *Client) ReprioritizeSubIssue(opts ReprioritizeSubIssueOptions) error {
+	debug.Log("ReprioritizeSubIssue", "owner", opts.Owner, "repo", opts.Repo, "parent_issue", opts.ParentIssue, "sub_issue_id", opts.SubIssueID, "after_id", opts.AfterID, "before_id", opts.BeforeID)
+
+	url := fmt.Sprintf("%s/repos/%s/%s/issues/%d/sub_issues/priority", c.BaseURL, opts.Owner, opts.Repo, opts.ParentIssue)
+	debug.Log("ReprioritizeSubIssue", "url", url)
+
+	payload := map[string]interface{}{
+		"sub_issue_id": opts.SubIssueID,
+	}
+	if opts.AfterID != 0 {
+		payload["after_id"] = opts.AfterID
+	}
+	if opts.BeforeID != 0 {
+		payload["before_id"] = opts.BeforeID
+	}
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		debug.Error("ReprioritizeSubIssue", err, "stage", "marshal")
+		return fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	req, err := http.NewRequest("PATCH", url, bytes.NewReader(body))
+	if err != nil {
+		debug.Error("ReprioritizeSubIssue", err, "stage", "new_request")
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	debug.Log("ReprioritizeSubIssue", "action", "sending_request")
+	resp, err := c.do(req)
+	if err != nil {
+		debug.Error("ReprioritizeSubIssue", err, "stage", "do_request")
+		return fmt.Errorf("failed to send request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	debug.Log("ReprioritizeSubIssue", "status_code", resp.StatusCode)
+	if resp.StatusCode != http.StatusOK {
+		var errResp struct {
+			Message string `json:"message"`
+		}
+		json.NewDecoder(resp.Body).Decode(&errResp)
+		apiErr := newAPIError(resp.StatusCode, errResp.Message, "reprioritize sub-issue")
+		debug.Error("ReprioritizeSubIssue", apiErr, "status", resp.StatusCode)
+		return apiErr
+	}
+
+	debug.Log("ReprioritizeSubIssue", "result", "success")
+	return nil
+}
+
 // ListIssuesOptions contains parameters for listing issues.
 type ListIssuesOptions struct {
 	Owner   string
 	Repo    string
 	State   string // "open", "closed", "all"
 	PerPage int
+	Limit   int // maximum issues to return; 0 fetches every page
 }
 
 // ListSubIssuesOptions contains parameters for listing sub-issues.
@@ -185,132 +374,298 @@ type ListSubIssuesOptions struct {
 	Owner       string
 	Repo        string
 	ParentIssue int
+	PerPage     int
+	Limit       int // maximum sub-issues to return; 0 fetches every page
 }
 
-// ListSubIssues lists sub-issues of a parent issue.
+// ListSubIssues lists sub-issues of a parent issue, following pagination.
 func (c *Client) ListSubIssues(opts ListSubIssuesOptions) ([]Issue, error) {
-	debug.Log("ListSubIssues", "owner", opts.Owner, "repo", opts.Repo, "parent_issue", opts.ParentIssue)
+	debug.Log("ListSubIssues", "owner", opts.Owner, "repo", opts.Repo, "parent_issue", opts.ParentIssue, "limit", opts.Limit)
 
-	url := fmt.Sprintf("%s/repos/%s/%s/issues/%d/sub_issues",
-		c.BaseURL, opts.Owner, opts.Repo, opts.ParentIssue)
+	url := fmt.Sprintf("%s/repos/%s/%s/issues/%d/sub_issues?per_page=%d",
+		c.BaseURL, opts.Owner, opts.Repo, opts.ParentIssue, pageSize(opts.PerPage, opts.Limit))
 	debug.Log("ListSubIssues", "url", url)
 
+	issues, err := getPages[Issue](c, url, "list sub-issues", opts.Limit)
+	if err != nil {
+		debug.Error("ListSubIssues", err)
+		return nil, err
+	}
+
+	debug.Log("ListSubIssues", "result_count", len(issues))
+	return issues, nil
+}
+
+// ListIssues lists issues in a repository, following pagination.
+func (c *Client) ListIssues(opts ListIssuesOptions) ([]Issue, error) {
+	debug.Log("ListIssues", "owner", opts.Owner, "repo", opts.Repo, "state", opts.State, "per_page", opts.PerPage, "limit", opts.Limit)
+
+	url := fmt.Sprintf("%s/repos/%s/%s/issues?state=%s&per_page=%d",
+		c.BaseURL, opts.Owner, opts.Repo, opts.State, pageSize(opts.PerPage, opts.Limit))
+	debug.Log("ListIssues", "url", url)
+
+	issues, err := getPages[Issue](c, url, "list issues", opts.Limit)
+	if err != nil {
+		debug.Error("ListIssues", err)
+		return nil, err
+	}
+
+	debug.Log("ListIssues", "result_count", len(issues))
+	return issues, nil
+}
+
+// GetIssue retrieves an issue by number.
+func (c *Client) GetIssue(owner, repo string, number int) (*Issue, error) {
+	debug.Log("GetIssue", "owner", owner, "repo", repo, "number", number)
+
+	url := fmt.Sprintf("%s/repos/%s/%s/issues/%d", c.BaseURL, owner, repo, number)
+	debug.Log("GetIssue", "url", url)
+
 	req, err := http.NewRequest("GET", url, nil)
 	if err != nil {
-		debug.Error("ListSubIssues", err, "stage", "new_request")
+		debug.Error("GetIssue", err, "stage", "new_request")
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
 
-	debug.Log("ListSubIssues", "action", "sending_request")
-	resp, err := c.HTTPClient.Do(req)
+	debug.Log("GetIssue", "action", "sending_request")
+	resp, err := c.do(req)
 	if err != nil {
-		debug.Error("ListSubIssues", err, "stage", "do_request")
+		debug.Error("GetIssue", err, "stage", "do_request")
 		return nil, fmt.Errorf("failed to send request: %w", err)
 	}
 	defer resp.Body.Close()
 
-	debug.Log("ListSubIssues", "status_code", resp.StatusCode)
+	debug.Log("GetIssue", "status_code", resp.StatusCode)
 	if resp.StatusCode != http.StatusOK {
 		var errResp struct {
 			Message string `json:"message"`
 		}
 		json.NewDecoder(resp.Body).Decode(&errResp)
-		apiErr := newAPIError(resp.StatusCode, errResp.Message, "list sub-issues")
-		debug.Error("ListSubIssues", apiErr, "status", resp.StatusCode)
+		apiErr := newAPIError(resp.StatusCode, errResp.Message, fmt.Sprintf("get issue #%d", number))
+		debug.Error("GetIssue", apiErr, "status", resp.StatusCode)
 		return nil, apiErr
 	}
 
-	var issues []Issue
-	if err := json.NewDecoder(resp.Body).Decode(&issues); err != nil {
-		debug.Error("ListSubIssues", err, "stage", "decode_response")
+	var issue Issue
+	if err := json.NewDecoder(resp.Body).Decode(&issue); err != nil {
+		debug.Error("GetIssue", err, "stage", "decode_response")
 		return nil, fmt.Errorf("failed to decode response: %w", err)
 	}
 
-	debug.Log("ListSubIssues", "result_count", len(issues))
-	return issues, nil
+	debug.Log("GetIssue", "result_id", issue.ID, "result_number", issue.Number, "title", issue.Title)
+	return &issue, nil
 }
 
-// ListIssues lists issues in a repository.
-func (c *Client) ListIssues(opts ListIssuesOptions) ([]Issue, error) {
-	debug.Log("ListIssues", "owner", opts.Owner, "repo", opts.Repo, "state", opts.State, "per_page", opts.PerPage)
+// GetParentIssue retrieves the parent of an issue.
+// It returns nil without an error when the issue has no parent.
+func (c *Client) GetParentIssue(owner, repo string, number int) (*Issue, error) {
+	debug.Log("GetParentIssue", "owner", owner, "repo", repo, "number", number)
 
-	url := fmt.Sprintf("%s/repos/%s/%s/issues?state=%s&per_page=%d",
-		c.BaseURL, opts.Owner, opts.Repo, opts.State, opts.PerPage)
-	debug.Log("ListIssues", "url", url)
+	url := fmt.Sprintf("%s/repos/%s/%s/issues/%d/parent", c.BaseURL, owner, repo, number)
+	debug.Log("GetParentIssue", "url", url)
 
 	req, err := http.NewRequest("GET", url, nil)
 	if err != nil {
-		debug.Error("ListIssues", err, "stage", "new_request")
+		debug.Error("GetParentIssue", err, "stage", "new_request")
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
 
-	debug.Log("ListIssues", "action", "sending_request")
-	resp, err := c.HTTPClient.Do(req)
+	debug.Log("GetParentIssue", "action", "sending_request")
+	resp, err := c.do(req)
 	if err != nil {
-		debug.Error("ListIssues", err, "stage", "do_request")
+		debug.Error("GetParentIssue", err, "stage", "do_request")
 		return nil, fmt.Errorf("failed to send request: %w", err)
 	}
 	defer resp.Body.Close()
 
-	debug.Log("ListIssues", "status_code", resp.StatusCode)
+	debug.Log("GetParentIssue", "status_code", resp.StatusCode)
+	if resp.StatusCode == http.StatusNotFound {
+		// The endpoint answers 404 for issues without a parent
+		debug.Log("GetParentIssue", "result", "no_parent")
+		return nil, nil
+	}
 	if resp.StatusCode != http.StatusOK {
 		var errResp struct {
 			Message string `json:"message"`
 		}
 		json.NewDecoder(resp.Body).Decode(&errResp)
-		apiErr := newAPIError(resp.StatusCode, errResp.Message, "list issues")
-		debug.Error("ListIssues", apiErr, "status", resp.StatusCode)
+		apiErr := newAPIError(resp.StatusCode, errResp.Message, fmt.Sprintf("get parent of issue #%d", number))
+		debug.Error("GetParentIssue", apiErr, "status", resp.StatusCode)
 		return nil, apiErr
 	}
 
-	var issues []Issue
-	if err := json.NewDecoder(resp.Body).Decode(&issues); err != nil {
-		debug.Error("ListIssues", err, "stage", "decode_response")
+	var issue Issue
+	if err := json.NewDecoder(resp.Body).Decode(&issue); err != nil {
+		debug.Error("GetParentIssue", err, "stage", "decode_response")
 		return nil, fmt.Errorf("failed to decode response: %w", err)
 	}
 
-	debug.Log("ListIssues", "result_count", len(issues))
-	return issues, nil
+	debug.Log("GetParentIssue", "result_id", issue.ID, "result_number", issue.Number, "title", issue.Title)
+	return &issue, nil
 }
 
-// GetIssue retrieves an issue by number.
-func (c *Client) GetIssue(owner, repo string, number int) (*Issue, error) {
-	debug.Log("GetIssue", "owner", owner, "repo", repo, "number", number)
+// UpdateIssueOptions contains parameters for updating an issue.
+// Only non-nil fields are sent, so unset fields are left unchanged.
+type UpdateIssueOptions struct {
+	Owner       string
+	Repo        string
+	Number      int
+	Title       *string
+	Body        *string
+	State       *string   // "open" or "closed"
+	StateReason *string   // "completed", "not_planned" or "reopened"
+	Labels      *[]string // replaces all labels
+	Assignees   *[]string // replaces all assignees
+	Milestone   *int      // 0 removes the milestone
+	Type        *string   // issue type name; "" removes the type
+}
 
-	url := fmt.Sprintf("%s/repos/%s/%s/issues/%d", c.BaseURL, owner, repo, number)
-	debug.Log("GetIssue", "url", url)
+// UpdateIssue updates an issue with a single PATCH request.
+func (c *Client) UpdateIssue(opts UpdateIssueOptions) (*Issue, error) {
+	debug.Log("UpdateIssue", "owner", opts.Owner, "repo", opts.Repo, "number", opts.Number)
 
-	req, err := http.NewRequest("GET", url, nil)
+	url := fmt.Sprintf("%s/repos/%s/%s/issues/%d", c.BaseURL, opts.Owner, opts.Repo, opts.Number)
+	debug.Log("UpdateIssue", "url", url)
+
+	payload := map[string]interface{}{}
+	if opts.Title != nil {
+		payload["title"] = *opts.Title
+	}
+	if opts.Body != nil {
+		payload["body"] = *opts.Body
+		debug.Log("UpdateIssue", "body_length", len(*opts.Body))
+	}
+	if opts.State != nil {
+		payload["state"] = *opts.State
+	}
+	if opts.StateReason != nil {
+		payload["state_reason"] = *opts.StateReason
+	}
+	if opts.Labels != nil {
+		payload["labels"] = *opts.Labels
+	}
+	if opts.Assignees != nil {
+		payload["assignees"] = *opts.Assignees
+	}
+	if opts.Milestone != nil {
+		if *opts.Milestone == 0 {
+			payload["milestone"] = nil
+		} else {
+			payload["milestone"] = *opts.Milestone
+		}
+	}
+	if opts.Type != nil {
+		if *opts.Type == "" {
+			payload["type"] = nil
+		} else {
+			payload["type"] = *opts.Type
+		}
+	}
+	debug.Log("UpdateIssue", "fields", len(payload))
+
+	body, err := json.Marshal(payload)
 	if err != nil {
-		debug.Error("GetIssue", err, "stage", "new_request")
+		debug.Error("UpdateIssue", err, "stage", "marshal")
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	req, err := http.NewRequest("PATCH", url, bytes.NewReader(body))
+	if err != nil {
+		debug.Error("UpdateIssue", err, "stage", "new_request")
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
+	req.Header.Set("Content-Type", "application/json")
 
-	debug.Log("GetIssue", "action", "sending_request")
-	resp, err := c.HTTPClient.Do(req)
+	debug.Log("UpdateIssue", "action", "sending_request")
+	resp, err := c.do(req)
 	if err != nil {
-		debug.Error("GetIssue", err, "stage", "do_request")
+		debug.Error("UpdateIssue", err, "stage", "do_request")
 		return nil, fmt.Errorf("failed to send request: %w", err)
 	}
 	defer resp.Body.Close()
 
-	debug.Log("GetIssue", "status_code", resp.StatusCode)
+	debug.Log("UpdateIssue", "status_code", resp.StatusCode)
 	if resp.StatusCode != http.StatusOK {
 		var errResp struct {
 			Message string `json:"message"`
 		}
 		json.NewDecoder(resp.Body).Decode(&errResp)
-		apiErr := newAPIError(resp.StatusCode, errResp.Message, fmt.Sprintf("get issue #%d", number))
-		debug.Error("GetIssue", apiErr, "status", resp.StatusCode)
+		apiErr := newAPIError(resp.StatusCode, errResp.Message, fmt.Sprintf("update issue #%d", opts.Number))
+		debug.Error("UpdateIssue", apiErr, "status", resp.StatusCode)
 		return nil, apiErr
 	}
 
 	var issue Issue
 	if err := json.NewDecoder(resp.Body).Decode(&issue); err != nil {
-		debug.Error("GetIssue", err, "stage", "decode_response")
+		debug.Error("UpdateIssue", err, "stage", "decode_response")
 		return nil, fmt.Errorf("failed to decode response: %w", err)
 	}
 
-	debug.Log("GetIssue", "result_id", issue.ID, "result_number", issue.Number, "title", issue.Title)
+	debug.Log("UpdateIssue", "result", "success", "number", issue.Number)
 	return &issue, nil
 }
+
+// CreateComment adds a comment to an issue.
+func (c *Client) CreateComment(owner, repo string, number int, body string) error {
+	debug.Log("CreateComment", "owner", owner, "repo", repo, "number", number, "body_length", len(body))
+
+	url := fmt.Sprintf("%s/repos/%s/%s/issues/%d/comments", c.BaseURL, owner, repo, number)
+	debug.Log("CreateComment", "url", url)
+
+	payload, err := json.Marshal(map[string]string{"body": body})
+	if err != nil {
+		debug.Error("CreateComment", err, "stage", "marshal")
+		return fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	req, err := http.NewRequest("POST", url, bytes.NewReader(payload))
+	if err != nil {
+		debug.Error("CreateComment", err, "stage", "new_request")
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	debug.Log("CreateComment", "action", "sending_request")
+	resp, err := c.do(req)
+	if err != nil {
+		debug.Error("CreateComment", err, "stage", "do_request")
+		return fmt.Errorf("failed to send request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	debug.Log("CreateComment", "status_code", resp.StatusCode)
+	if resp.StatusCode != http.StatusCreated {
+		var errResp struct {
+			Message string `json:"message"`
+		}
+		json.NewDecoder(resp.Body).Decode(&errResp)
+		apiErr := newAPIError(resp.StatusCode, errResp.Message, fmt.Sprintf("comment on issue #%d", number))
+		debug.Error("CreateComment", apiErr, "status", resp.StatusCode)
+		return apiErr
+	}
+
+	debug.Log("CreateComment", "result", "success")
+	return nil
+}
+
+// DeleteIssue permanently deletes an issue through GraphQL. GitHub only
+// allows this for repository admins.
+func (c *Client) DeleteIssue(issueNodeID string) error {
+	debug.Log("DeleteIssue", "issue_id", issueNodeID)
+
+	query := `
+		mutation($issueId: ID!) {
+			deleteIssue(input: {issueId: $issueId}) {
+				clientMutationId
+			}
+		}
+	`
+
+	if _, err := c.graphqlRequest(query, map[string]interface{}{"issueId": issueNodeID}); err != nil {
+		debug.Error("DeleteIssue", err, "stage", "graphql_request")
+		return err
+	}
+
+	debug.Log("DeleteIssue", "result", "success")
+	return nil
+}
@@ -2,9 +2,12 @@ package api
 
 import (
 	"encoding/json"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
 )
 
 func TestCreateIssue(t *testing.T) {
@@ -117,6 +120,34 @@ func TestCreateIssue(t *testing.T) {
 			wantURL:    "https://github.com/testowner/testrepo/issues/44",
 			wantErr:    false,
 		},
+		{
+			name: "creates issue with type",
+			opts: CreateIssueOptions{
+				Owner: "testowner",
+				Repo:  "testrepo",
+				Title: "Typed Issue",
+				Type:  "Bug",
+			},
+			serverResponse: func(w http.ResponseWriter, r *http.Request) {
+				var body map[string]interface{}
+				json.NewDecoder(r.Body).Decode(&body)
+
+				if body["type"] != "Bug" {
+					t.Errorf("expected type Bug, got %v", body["type"])
+				}
+
+				w.WriteHeader(http.StatusCreated)
+				json.NewEncoder(w).Encode(map[string]interface{}{
+					"id":       12348,
+					"number":   45,
+					"html_url": "https://github.com/testowner/testrepo/issues/45",
+				})
+			},
+			wantID:     12348,
+			wantNumber: 45,
+			wantURL:    "https://github.com/testowner/testrepo/issues/45",
+			wantErr:    false,
+		},
 		{
 			name: "handles server error",
 			opts: CreateIssueOptions{
@@ -194,6 +225,31 @@ func TestLinkSubIssue(t *testing.T) {
 					t.Errorf("expected sub_issue_id 12345, got %v", body["sub_issue_id"])
 				}
 
+				if _, ok := body["replace_parent"]; ok {
+					t.Errorf("replace_parent should be omitted, got %v", body["replace_parent"])
+				}
+
+				w.WriteHeader(http.StatusCreated)
+				json.NewEncoder(w).Encode(map[string]interface{}{})
+			},
+			wantErr: false,
+		},
+		{
+			name: "replaces existing parent",
+			opts: LinkSubIssueOptions{
+				Owner:         "testowner",
+				Repo:          "testrepo",
+				ParentIssue:   42,
+				SubIssueID:    12345,
+				ReplaceParent: true,
+			},
+			serverResponse: func(w http.ResponseWriter, r *http.Request) {
+				var body map[string]interface{}
+				json.NewDecoder(r.Body).Decode(&body)
+				if body["replace_parent"] != true {
+					t.Errorf("expected replace_parent true, got %v", body["replace_parent"])
+				}
+
 				w.WriteHeader(http.StatusCreated)
 				json.NewEncoder(w).Encode(map[string]interface{}{})
 			},
@@ -251,6 +307,178 @@ func TestLinkSubIssue(t *testing.T) {
 	}
 }
 
+func TestRemoveSubIssue(t *testing.T) {
+	tests := []struct {
+		name           string
+		opts           RemoveSubIssueOptions
+		serverResponse func(w http.ResponseWriter, r *http.Request)
+		wantErr        bool
+	}{
+		{
+			name: "removes sub-issue from parent",
+			opts: RemoveSubIssueOptions{
+				Owner:       "testowner",
+				Repo:        "testrepo",
+				ParentIssue: 42,
+				SubIssueID:  12345,
+			},
+			serverResponse: func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != "DELETE" {
+					t.Errorf("expected DELETE, got %s", r.Method)
+				}
+				if r.URL.Path != "/repos/testowner/testrepo/issues/42/sub_issue" {
+					t.Errorf("unexpected path: %s", r.URL.Path)
+				}
+
+				var body map[string]interface{}
+				json.NewDecoder(r.Body).Decode(&body)
+				if body["sub_issue_id"].(float64) != 12345 {
+					t.Errorf("expected sub_issue_id 12345, got %v", body["sub_issue_id"])
+				}
+
+				w.WriteHeader(http.StatusOK)
+				json.NewEncoder(w).Encode(map[string]interface{}{"number": 42})
+			},
+			wantErr: false,
+		},
+		{
+			name: "handles sub-issue not linked",
+			opts: RemoveSubIssueOptions{
+				Owner:       "testowner",
+				Repo:        "testrepo",
+				ParentIssue: 42,
+				SubIssueID:  999,
+			},
+			serverResponse: func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusNotFound)
+				json.NewEncoder(w).Encode(map[string]interface{}{
+					"message": "Not Found",
+				})
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(tt.serverResponse))
+			defer server.Close()
+
+			client := &Client{
+				HTTPClient: server.Client(),
+				BaseURL:    server.URL,
+			}
+
+			err := client.RemoveSubIssue(tt.opts)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("RemoveSubIssue() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestReprioritizeSubIssue(t *testing.T) {
+	tests := []struct {
+		name           string
+		opts           ReprioritizeSubIssueOptions
+		serverResponse func(w http.ResponseWriter, r *http.Request)
+		wantErr        bool
+	}{
+		{
+			name: "moves sub-issue after sibling",
+			opts: ReprioritizeSubIssueOptions{
+				Owner:       "testowner",
+				Repo:        "testrepo",
+				ParentIssue: 42,
+				SubIssueID:  100,
+				AfterID:     200,
+			},
+			serverResponse: func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != "PATCH" {
+					t.Errorf("expected PATCH, got %s", r.Method)
+				}
+				if r.URL.Path != "/repos/testowner/testrepo/issues/42/sub_issues/priority" {
+					t.Errorf("unexpected path: %s", r.URL.Path)
+				}
+
+				var body map[string]interface{}
+				json.NewDecoder(r.Body).Decode(&body)
+				if body["sub_issue_id"].(float64) != 100 {
+					t.Errorf("expected sub_issue_id 100, got %v", body["sub_issue_id"])
+				}
+				if body["after_id"].(float64) != 200 {
+					t.Errorf("expected after_id 200, got %v", body["after_id"])
+				}
+				if _, ok := body["before_id"]; ok {
+					t.Errorf("before_id should not be sent, got %v", body["before_id"])
+				}
+
+				w.WriteHeader(http.StatusOK)
+				json.NewEncoder(w).Encode(map[string]interface{}{"number": 42})
+			},
+			wantErr: false,
+		},
+		{
+			name: "moves sub-issue before sibling",
+			opts: ReprioritizeSubIssueOptions{
+				Owner:       "testowner",
+				Repo:        "testrepo",
+				ParentIssue: 42,
+				SubIssueID:  100,
+				BeforeID:    300,
+			},
+			serverResponse: func(w http.ResponseWriter, r *http.Request) {
+				var body map[string]interface{}
+				json.NewDecoder(r.Body).Decode(&body)
+				if body["before_id"].(float64) != 300 {
+					t.Errorf("expected before_id 300, got %v", body["before_id"])
+				}
+				if _, ok := body["after_id"]; ok {
+					t.Errorf("after_id should not be sent, got %v", body["after_id"])
+				}
+
+				w.WriteHeader(http.StatusOK)
+				json.NewEncoder(w).Encode(map[string]interface{}{"number": 42})
+			},
+			wantErr: false,
+		},
+		{
+			name: "handles validation error",
+			opts: ReprioritizeSubIssueOptions{
+				Owner:       "testowner",
+				Repo:        "testrepo",
+				ParentIssue: 42,
+				SubIssueID:  100,
+				AfterID:     999,
+			},
+			serverResponse: func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusUnprocessableEntity)
+				json.NewEncoder(w).Encode(map[string]interface{}{
+					"message": "Validation Failed",
+				})
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(tt.serverResponse))
+			defer server.Close()
+
+			client := &Client{
+				HTTPClient: server.Client(),
+				BaseURL:    server.URL,
+			}
+
+			err := client.ReprioritizeSubIssue(tt.opts)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ReprioritizeSubIssue() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
 func TestListIssues(t *testing.T) {
 	tests := []struct {
 		name           string
@@ -432,6 +660,103 @@ func TestListSubIssues(t *testing.T) {
 	}
 }
 
+func TestListSubIssuesPaginates(t *testing.T) {
+	var server *httptest.Server
+	requests := 0
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.URL.Query().Get("per_page") != "100" {
+			t.Errorf("expected per_page=100, got %s", r.URL.Query().Get("per_page"))
+		}
+
+		switch r.URL.Query().Get("page") {
+		case "":
+			w.Header().Set("Link", fmt.Sprintf(`<%s/repos/o/r/issues/42/sub_issues?per_page=100&page=2>; rel="next", <%s/repos/o/r/issues/42/sub_issues?per_page=100&page=2>; rel="last"`, server.URL, server.URL))
+			json.NewEncoder(w).Encode([]map[string]interface{}{
+				{"id": 1, "number": 43},
+				{"id": 2, "number": 44},
+			})
+		case "2":
+			w.Header().Set("Link", fmt.Sprintf(`<%s/repos/o/r/issues/42/sub_issues?per_page=100&page=1>; rel="first"`, server.URL))
+			json.NewEncoder(w).Encode([]map[string]interface{}{
+				{"id": 3, "number": 45},
+			})
+		default:
+			t.Errorf("unexpected page %q", r.URL.Query().Get("page"))
+		}
+	}))
+	defer server.Close()
+
+	client := &Client{HTTPClient: server.Client(), BaseURL: server.URL}
+
+	issues, err := client.ListSubIssues(ListSubIssuesOptions{Owner: "o", Repo: "r", ParentIssue: 42})
+	if err != nil {
+		t.Fatalf("ListSubIssues() error = %v", err)
+	}
+	if requests != 2 {
+		t.Errorf("expected 2 requests, got %d", requests)
+	}
+	if len(issues) != 3 || issues[2].Number != 45 {
+		t.Errorf("ListSubIssues() = %+v, want 3 issues ending with #45", issues)
+	}
+}
+
+func TestListIssuesStopsAtLimit(t *testing.T) {
+	var server *httptest.Server
+	requests := 0
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.URL.Query().Get("per_page") != "2" {
+			t.Errorf("expected per_page=2, got %s", r.URL.Query().Get("per_page"))
+		}
+
+		page := r.URL.Query().Get("page")
+		if page == "" {
+			page = "1"
+		}
+		// Every page claims there is another one
+		w.Header().Set("Link", fmt.Sprintf(`<%s/repos/o/r/issues?state=open&per_page=2&page=%s0>; rel="next"`, server.URL, page))
+		json.NewEncoder(w).Encode([]map[string]interface{}{
+			{"id": 1, "number": 1},
+			{"id": 2, "number": 2},
+		})
+	}))
+	defer server.Close()
+
+	client := &Client{HTTPClient: server.Client(), BaseURL: server.URL}
+
+	issues, err := client.ListIssues(ListIssuesOptions{Owner: "o", Repo: "r", State: "open", Limit: 3, PerPage: 2})
+	if err != nil {
+		t.Fatalf("ListIssues() error = %v", err)
+	}
+	if len(issues) != 3 {
+		t.Errorf("ListIssues() returned %d issues, want 3", len(issues))
+	}
+	if requests != 2 {
+		t.Errorf("expected 2 requests, got %d", requests)
+	}
+}
+
+func TestListIssuesPageError(t *testing.T) {
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("page") == "2" {
+			w.WriteHeader(http.StatusInternalServerError)
+			json.NewEncoder(w).Encode(map[string]interface{}{"message": "Server Error"})
+			return
+		}
+		w.Header().Set("Link", fmt.Sprintf(`<%s/repos/o/r/issues?state=open&page=2>; rel="next"`, server.URL))
+		json.NewEncoder(w).Encode([]map[string]interface{}{{"id": 1, "number": 1}})
+	}))
+	defer server.Close()
+
+	client := &Client{HTTPClient: server.Client(), BaseURL: server.URL, Sleep: func(time.Duration) {}}
+
+	if _, err := client.ListIssues(ListIssuesOptions{Owner: "o", Repo: "r", State: "open"}); err == nil {
+		t.Error("ListIssues() expected error when a later page fails")
+	}
+}
+
 func TestGetIssue(t *testing.T) {
 	tests := []struct {
 		name           string
@@ -495,3 +820,337 @@ func TestGetIssue(t *testing.T) {
 		})
 	}
 }
+
+func TestGetIssueDecodesSubIssuesSummary(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"id":        1,
+			"number":    42,
+			"title":     "Epic",
+			"assignees": []map[string]interface{}{{"login": "octocat"}},
+			"sub_issues_summary": map[string]interface{}{
+				"total":             4,
+				"completed":         3,
+				"percent_completed": 75,
+			},
+		})
+	}))
+	defer server.Close()
+
+	client := &Client{HTTPClient: server.Client(), BaseURL: server.URL}
+
+	issue, err := client.GetIssue("testowner", "testrepo", 42)
+	if err != nil {
+		t.Fatalf("GetIssue() error = %v", err)
+	}
+	if issue.SubIssuesSummary == nil {
+		t.Fatal("SubIssuesSummary was not decoded")
+	}
+	if got := *issue.SubIssuesSummary; got != (SubIssuesSummary{Total: 4, Completed: 3, PercentCompleted: 75}) {
+		t.Errorf("SubIssuesSummary = %+v", got)
+	}
+	if len(issue.Assignees) != 1 || issue.Assignees[0].Login != "octocat" {
+		t.Errorf("Assignees = %+v", issue.Assignees)
+	}
+}
+
+func TestGetParentIssue(t *testing.T) {
+	tests := []struct {
+		name           string
+		serverResponse func(w http.ResponseWriter, r *http.Request)
+		wantNumber     int
+		wantNil        bool
+		wantErr        bool
+	}{
+		{
+			name: "returns parent",
+			serverResponse: func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != "GET" {
+					t.Errorf("expected GET, got %s", r.Method)
+				}
+				if r.URL.Path != "/repos/testowner/testrepo/issues/43/parent" {
+					t.Errorf("unexpected path: %s", r.URL.Path)
+				}
+
+				w.WriteHeader(http.StatusOK)
+				json.NewEncoder(w).Encode(map[string]interface{}{
+					"id":             12345,
+					"number":         42,
+					"title":          "Epic",
+					"html_url":       "https://github.com/other/repo/issues/42",
+					"repository_url": "https://api.github.com/repos/other/repo",
+				})
+			},
+			wantNumber: 42,
+		},
+		{
+			name: "no parent",
+			serverResponse: func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusNotFound)
+				json.NewEncoder(w).Encode(map[string]interface{}{"message": "Not Found"})
+			},
+			wantNil: true,
+		},
+		{
+			name: "API error",
+			serverResponse: func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusForbidden)
+				json.NewEncoder(w).Encode(map[string]interface{}{"message": "Forbidden"})
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(tt.serverResponse))
+			defer server.Close()
+
+			client := &Client{
+				HTTPClient: server.Client(),
+				BaseURL:    server.URL,
+			}
+
+			parent, err := client.GetParentIssue("testowner", "testrepo", 43)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("GetParentIssue() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			if tt.wantNil {
+				if parent != nil {
+					t.Errorf("GetParentIssue() = %+v, want nil", parent)
+				}
+				return
+			}
+			if parent.Number != tt.wantNumber {
+				t.Errorf("Number = %d, want %d", parent.Number, tt.wantNumber)
+			}
+			if owner, repo := parent.Repository(); owner != "other" || repo != "repo" {
+				t.Errorf("Repository() = %s/%s, want other/repo", owner, repo)
+			}
+		})
+	}
+}
+
+func TestUpdateIssue(t *testing.T) {
+	newBody := "- [ ] #43"
+
+	tests := []struct {
+		name           string
+		opts           UpdateIssueOptions
+		serverResponse func(w http.ResponseWriter, r *http.Request)
+		wantErr        bool
+	}{
+		{
+			name: "updates body",
+			opts: UpdateIssueOptions{Owner: "testowner", Repo: "testrepo", Number: 42, Body: &newBody},
+			serverResponse: func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != "PATCH" {
+					t.Errorf("expected PATCH, got %s", r.Method)
+				}
+				if r.URL.Path != "/repos/testowner/testrepo/issues/42" {
+					t.Errorf("unexpected path: %s", r.URL.Path)
+				}
+
+				var body map[string]interface{}
+				json.NewDecoder(r.Body).Decode(&body)
+				if body["body"] != newBody {
+					t.Errorf("expected body %q, got %v", newBody, body["body"])
+				}
+				if len(body) != 1 {
+					t.Errorf("expected only the body to be sent, got %v", body)
+				}
+
+				w.WriteHeader(http.StatusOK)
+				json.NewEncoder(w).Encode(map[string]interface{}{"id": 1, "number": 42, "body": newBody})
+			},
+		},
+		{
+			name: "API error",
+			opts: UpdateIssueOptions{Owner: "testowner", Repo: "testrepo", Number: 42, Body: &newBody},
+			serverResponse: func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusForbidden)
+				json.NewEncoder(w).Encode(map[string]interface{}{"message": "Forbidden"})
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(tt.serverResponse))
+			defer server.Close()
+
+			client := &Client{
+				HTTPClient: server.Client(),
+				BaseURL:    server.URL,
+			}
+
+			issue, err := client.UpdateIssue(tt.opts)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("UpdateIssue() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && issue.Body != newBody {
+				t.Errorf("Body = %q, want %q", issue.Body, newBody)
+			}
+		})
+	}
+}
+
+func TestUpdateIssueSendsAllChangesAtOnce(t *testing.T) {
+	title, state, reason := "New title", "closed", "not_planned"
+	labels, assignees := []string{"bug", "ui"}, []string{}
+	clearMilestone, clearType := 0, ""
+
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		var body map[string]interface{}
+		json.NewDecoder(r.Body).Decode(&body)
+
+		want := map[string]interface{}{
+			"title":        "New title",
+			"state":        "closed",
+			"state_reason": "not_planned",
+			"labels":       []interface{}{"bug", "ui"},
+			"assignees":    []interface{}{},
+			"milestone":    nil,
+			"type":         nil,
+		}
+		if fmt.Sprint(body) != fmt.Sprint(want) {
+			t.Errorf("payload = %v, want %v", body, want)
+		}
+		if _, ok := body["milestone"]; !ok {
+			t.Error("milestone should be sent as null to clear it")
+		}
+
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"number":       42,
+			"title":        title,
+			"state":        state,
+			"state_reason": reason,
+			"labels":       []map[string]string{{"name": "bug"}, {"name": "ui"}},
+			"milestone":    nil,
+		})
+	}))
+	defer server.Close()
+
+	client := &Client{HTTPClient: server.Client(), BaseURL: server.URL}
+	issue, err := client.UpdateIssue(UpdateIssueOptions{
+		Owner: "o", Repo: "r", Number: 42,
+		Title: &title, State: &state, StateReason: &reason,
+		Labels: &labels, Assignees: &assignees, Milestone: &clearMilestone, Type: &clearType,
+	})
+	if err != nil {
+		t.Fatalf("UpdateIssue() error = %v", err)
+	}
+	if requests != 1 {
+		t.Errorf("sent %d requests, want 1", requests)
+	}
+	if issue.StateReason != "not_planned" || len(issue.Labels) != 2 || issue.Milestone != nil {
+		t.Errorf("unexpected issue: %+v", issue)
+	}
+}
+
+func TestCreateComment(t *testing.T) {
+	tests := []struct {
+		name           string
+		serverResponse func(w http.ResponseWriter, r *http.Request)
+		wantErr        bool
+	}{
+		{
+			name: "posts the comment",
+			serverResponse: func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != "POST" {
+					t.Errorf("expected POST, got %s", r.Method)
+				}
+				if r.URL.Path != "/repos/testowner/testrepo/issues/42/comments" {
+					t.Errorf("unexpected path: %s", r.URL.Path)
+				}
+
+				var body map[string]interface{}
+				json.NewDecoder(r.Body).Decode(&body)
+				if body["body"] != "All done" {
+					t.Errorf("expected body %q, got %v", "All done", body["body"])
+				}
+
+				w.WriteHeader(http.StatusCreated)
+				json.NewEncoder(w).Encode(map[string]interface{}{"id": 1})
+			},
+		},
+		{
+			name: "API error",
+			serverResponse: func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusForbidden)
+				json.NewEncoder(w).Encode(map[string]interface{}{"message": "Forbidden"})
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(tt.serverResponse))
+			defer server.Close()
+
+			client := &Client{
+				HTTPClient: server.Client(),
+				BaseURL:    server.URL,
+			}
+
+			err := client.CreateComment("testowner", "testrepo", 42, "All done")
+			if (err != nil) != tt.wantErr {
+				t.Errorf("CreateComment() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestDeleteIssue(t *testing.T) {
+	tests := []struct {
+		name     string
+		response string
+		wantErr  bool
+	}{
+		{
+			name:     "deletes the issue",
+			response: `{"data": {"deleteIssue": {"clientMutationId": null}}}`,
+		},
+		{
+			name:     "not an admin",
+			response: `{"data": {"deleteIssue": null}, "errors": [{"message": "viewer does not have permission to delete issues"}]}`,
+			wantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				var req struct {
+					Query     string                 `json:"query"`
+					Variables map[string]interface{} `json:"variables"`
+				}
+				json.NewDecoder(r.Body).Decode(&req)
+				if !strings.Contains(req.Query, "deleteIssue") {
+					t.Errorf("query does not delete the issue: %s", req.Query)
+				}
+				if req.Variables["issueId"] != "I_57" {
+					t.Errorf("issueId = %v, want I_57", req.Variables["issueId"])
+				}
+				w.Write([]byte(tt.response))
+			}))
+			defer server.Close()
+
+			client := &Client{HTTPClient: server.Client(), BaseURL: server.URL}
+			err := client.DeleteIssue("I_57")
+			if (err != nil) != tt.wantErr {
+				t.Errorf("DeleteIssue() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
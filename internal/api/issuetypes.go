@@ -0,0 +1,74 @@
+package api
+
+import (
+	"fmt"
+
+	"github.com/gwyn/gh-subissue/internal/debug"
+)
+
+// IssueType is an organization's issue type, such as Bug or Task.
+type IssueType struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	Color       string `json:"color,omitempty"` // a color name such as "red", not a hex color
+}
+
+// ListIssueTypes returns the enabled issue types of an organization. Issue
+// types only exist for organizations, so a user owner has none.
+func (c *Client) ListIssueTypes(owner string) ([]IssueType, error) {
+	debug.Log("ListIssueTypes", "owner", owner)
+
+	query := `
+		query($owner: String!) {
+			repositoryOwner(login: $owner) {
+				... on Organization {
+					issueTypes(first: 100) {
+						nodes { name description color isEnabled }
+					}
+				}
+			}
+		}
+	`
+
+	result, err := c.graphqlRequest(query, map[string]interface{}{"owner": owner})
+	if err != nil {
+		debug.Error("ListIssueTypes", err, "stage", "graphql_request")
+		return nil, err
+	}
+
+	data, ok := result["data"].(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("unexpected response format")
+	}
+
+	repositoryOwner, ok := data["repositoryOwner"].(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("owner %q not found", owner)
+	}
+
+	issueTypes, ok := repositoryOwner["issueTypes"].(map[string]interface{})
+	if !ok {
+		debug.Log("ListIssueTypes", "result", "not_an_organization")
+		return nil, nil
+	}
+
+	nodes, _ := issueTypes["nodes"].([]interface{})
+	var types []IssueType
+	for _, n := range nodes {
+		node, ok := n.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if enabled, ok := node["isEnabled"].(bool); ok && !enabled {
+			continue
+		}
+		var t IssueType
+		t.Name, _ = node["name"].(string)
+		t.Description, _ = node["description"].(string)
+		t.Color, _ = node["color"].(string)
+		types = append(types, t)
+	}
+
+	debug.Log("ListIssueTypes", "result_count", len(types))
+	return types, nil
+}
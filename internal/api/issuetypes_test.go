@@ -0,0 +1,64 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func TestListIssueTypes(t *testing.T) {
+	tests := []struct {
+		name     string
+		response string
+		want     []IssueType
+		wantErr  bool
+	}{
+		{
+			name: "organization",
+			response: `{"data": {"repositoryOwner": {"issueTypes": {"nodes": [
+				{"name": "Bug", "description": "An unexpected problem", "color": "RED", "isEnabled": true},
+				{"name": "Legacy", "description": "", "color": "GRAY", "isEnabled": false},
+				{"name": "Task", "description": "A specific piece of work", "color": "YELLOW", "isEnabled": true}]}}}}`,
+			want: []IssueType{
+				{Name: "Bug", Description: "An unexpected problem", Color: "RED"},
+				{Name: "Task", Description: "A specific piece of work", Color: "YELLOW"},
+			},
+		},
+		{
+			name:     "user",
+			response: `{"data": {"repositoryOwner": {}}}`,
+		},
+		{
+			name:     "unknown owner",
+			response: `{"data": {"repositoryOwner": null}}`,
+			wantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				var req struct {
+					Variables map[string]interface{} `json:"variables"`
+				}
+				json.NewDecoder(r.Body).Decode(&req)
+				if req.Variables["owner"] != "org" {
+					t.Errorf("owner = %v, want org", req.Variables["owner"])
+				}
+				w.Write([]byte(tt.response))
+			}))
+			defer server.Close()
+
+			client := &Client{HTTPClient: server.Client(), BaseURL: server.URL}
+			types, err := client.ListIssueTypes("org")
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ListIssueTypes() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(types, tt.want) {
+				t.Errorf("ListIssueTypes() = %+v, want %+v", types, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,110 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/gwyn/gh-subissue/internal/debug"
+)
+
+// ListLabels lists every label in a repository.
+func (c *Client) ListLabels(owner, repo string) ([]Label, error) {
+	debug.Log("ListLabels", "owner", owner, "repo", repo)
+
+	url := fmt.Sprintf("%s/repos/%s/%s/labels?per_page=%d", c.BaseURL, owner, repo, defaultPerPage)
+	debug.Log("ListLabels", "url", url)
+
+	labels, err := getPages[Label](c, url, "list labels", 0)
+	if err != nil {
+		debug.Error("ListLabels", err)
+		return nil, err
+	}
+
+	debug.Log("ListLabels", "result_count", len(labels))
+	return labels, nil
+}
+
+// CreateLabel creates a label. Color is a hex color without the leading "#".
+func (c *Client) CreateLabel(owner, repo, name, color string) (*Label, error) {
+	debug.Log("CreateLabel", "owner", owner, "repo", repo, "name", name, "color", color)
+
+	url := fmt.Sprintf("%s/repos/%s/%s/labels", c.BaseURL, owner, repo)
+	debug.Log("CreateLabel", "url", url)
+
+	body, err := json.Marshal(map[string]string{"name": name, "color": color})
+	if err != nil {
+		debug.Error("CreateLabel", err, "stage", "marshal")
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	req, err := http.NewRequest("POST", url, bytes.NewReader(body))
+	if err != nil {
+		debug.Error("CreateLabel", err, "stage", "new_request")
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	debug.Log("CreateLabel", "action", "sending_request")
+	resp, err := c.do(req)
+	if err != nil {
+		debug.Error("CreateLabel", err, "stage", "do_request")
+		return nil, fmt.Errorf("failed to send request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	debug.Log("CreateLabel", "status_code", resp.StatusCode)
+	if resp.StatusCode != http.StatusCreated {
+		var errResp struct {
+			Message string `json:"message"`
+		}
+		json.NewDecoder(resp.Body).Decode(&errResp)
+		apiErr := newAPIError(resp.StatusCode, errResp.Message, fmt.Sprintf("create label %q", name))
+		debug.Error("CreateLabel", apiErr, "status", resp.StatusCode)
+		return nil, apiErr
+	}
+
+	var label Label
+	if err := json.NewDecoder(resp.Body).Decode(&label); err != nil {
+		debug.Error("CreateLabel", err, "stage", "decode_response")
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	debug.Log("CreateLabel", "result", "success", "name", label.Name)
+	return &label, nil
+}
+
+// ListMilestones lists the open and closed milestones of a repository.
+func (c *Client) ListMilestones(owner, repo string) ([]Milestone, error) {
+	debug.Log("ListMilestones", "owner", owner, "repo", repo)
+
+	url := fmt.Sprintf("%s/repos/%s/%s/milestones?state=all&per_page=%d", c.BaseURL, owner, repo, defaultPerPage)
+	debug.Log("ListMilestones", "url", url)
+
+	milestones, err := getPages[Milestone](c, url, "list milestones", 0)
+	if err != nil {
+		debug.Error("ListMilestones", err)
+		return nil, err
+	}
+
+	debug.Log("ListMilestones", "result_count", len(milestones))
+	return milestones, nil
+}
+
+// ListAssignees lists the users that can be assigned to issues in a repository.
+func (c *Client) ListAssignees(owner, repo string) ([]User, error) {
+	debug.Log("ListAssignees", "owner", owner, "repo", repo)
+
+	url := fmt.Sprintf("%s/repos/%s/%s/assignees?per_page=%d", c.BaseURL, owner, repo, defaultPerPage)
+	debug.Log("ListAssignees", "url", url)
+
+	users, err := getPages[User](c, url, "list assignees", 0)
+	if err != nil {
+		debug.Error("ListAssignees", err)
+		return nil, err
+	}
+
+	debug.Log("ListAssignees", "result_count", len(users))
+	return users, nil
+}
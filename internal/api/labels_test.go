@@ -0,0 +1,133 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func TestListLabels(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/repos/owner/repo/labels" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+		if r.URL.Query().Get("page") == "" {
+			w.Header().Set("Link", `<`+"http://"+r.Host+r.URL.Path+`?page=2>; rel="next"`)
+			json.NewEncoder(w).Encode([]map[string]string{{"name": "bug", "color": "d73a4a"}})
+			return
+		}
+		json.NewEncoder(w).Encode([]map[string]string{{"name": "enhancement", "color": "a2eeef"}})
+	}))
+	defer server.Close()
+
+	client := &Client{HTTPClient: server.Client(), BaseURL: server.URL}
+	labels, err := client.ListLabels("owner", "repo")
+	if err != nil {
+		t.Fatalf("ListLabels() error = %v", err)
+	}
+	want := []Label{{Name: "bug", Color: "d73a4a"}, {Name: "enhancement", Color: "a2eeef"}}
+	if !reflect.DeepEqual(labels, want) {
+		t.Errorf("ListLabels() = %v, want %v", labels, want)
+	}
+}
+
+func TestCreateLabel(t *testing.T) {
+	tests := []struct {
+		name           string
+		serverResponse func(w http.ResponseWriter, r *http.Request)
+		wantErr        bool
+	}{
+		{
+			name: "creates the label",
+			serverResponse: func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != "POST" {
+					t.Errorf("expected POST, got %s", r.Method)
+				}
+				if r.URL.Path != "/repos/owner/repo/labels" {
+					t.Errorf("unexpected path: %s", r.URL.Path)
+				}
+
+				var body map[string]string
+				json.NewDecoder(r.Body).Decode(&body)
+				if body["name"] != "needs-docs" || body["color"] != "ededed" {
+					t.Errorf("unexpected body: %v", body)
+				}
+
+				w.WriteHeader(http.StatusCreated)
+				json.NewEncoder(w).Encode(map[string]string{"name": "needs-docs", "color": "ededed"})
+			},
+		},
+		{
+			name: "already exists",
+			serverResponse: func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusUnprocessableEntity)
+				json.NewEncoder(w).Encode(map[string]string{"message": "Validation Failed"})
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(tt.serverResponse))
+			defer server.Close()
+
+			client := &Client{HTTPClient: server.Client(), BaseURL: server.URL}
+			label, err := client.CreateLabel("owner", "repo", "needs-docs", "ededed")
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("CreateLabel() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && label.Name != "needs-docs" {
+				t.Errorf("Name = %q, want %q", label.Name, "needs-docs")
+			}
+		})
+	}
+}
+
+func TestListMilestones(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/repos/owner/repo/milestones" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+		if r.URL.Query().Get("state") != "all" {
+			t.Errorf("state = %q, want all", r.URL.Query().Get("state"))
+		}
+		json.NewEncoder(w).Encode([]map[string]interface{}{
+			{"number": 1, "title": "v1.0", "state": "closed"},
+			{"number": 2, "title": "v2.0", "state": "open"},
+		})
+	}))
+	defer server.Close()
+
+	client := &Client{HTTPClient: server.Client(), BaseURL: server.URL}
+	milestones, err := client.ListMilestones("owner", "repo")
+	if err != nil {
+		t.Fatalf("ListMilestones() error = %v", err)
+	}
+	want := []Milestone{{Number: 1, Title: "v1.0", State: "closed"}, {Number: 2, Title: "v2.0", State: "open"}}
+	if !reflect.DeepEqual(milestones, want) {
+		t.Errorf("ListMilestones() = %v, want %v", milestones, want)
+	}
+}
+
+func TestListAssignees(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/repos/owner/repo/assignees" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+		json.NewEncoder(w).Encode([]map[string]string{{"login": "octocat"}, {"login": "hubot"}})
+	}))
+	defer server.Close()
+
+	client := &Client{HTTPClient: server.Client(), BaseURL: server.URL}
+	users, err := client.ListAssignees("owner", "repo")
+	if err != nil {
+		t.Fatalf("ListAssignees() error = %v", err)
+	}
+	want := []User{{Login: "octocat"}, {Login: "hubot"}}
+	if !reflect.DeepEqual(users, want) {
+		t.Errorf("ListAssignees() = %v, want %v", users, want)
+	}
+}
@@ -0,0 +1,97 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/gwyn/gh-subissue/internal/debug"
+)
+
+// defaultPerPage is the page size used when a list call does not set one.
+// 100 is the maximum the REST API accepts.
+const defaultPerPage = 100
+
+// pageSize returns the per_page value to request for a list call, never
+// asking for more than the limit needs.
+func pageSize(perPage, limit int) int {
+	if perPage <= 0 {
+		perPage = defaultPerPage
+	}
+	if limit > 0 && limit < perPage {
+		perPage = limit
+	}
+	return perPage
+}
+
+// getPages fetches a JSON array from url and follows the Link rel="next"
+// headers until there are no more pages or limit items were collected.
+// A limit of 0 fetches every page.
+func getPages[T any](c *Client, url, operation string, limit int) ([]T, error) {
+	var items []T
+
+	for page := 1; url != ""; page++ {
+		debug.Log("getPages", "operation", operation, "page", page, "url", url)
+
+		req, err := http.NewRequest("GET", url, nil)
+		if err != nil {
+			debug.Error("getPages", err, "stage", "new_request")
+			return nil, fmt.Errorf("failed to create request: %w", err)
+		}
+
+		resp, err := c.do(req)
+		if err != nil {
+			debug.Error("getPages", err, "stage", "do_request")
+			return nil, fmt.Errorf("failed to send request: %w", err)
+		}
+
+		debug.Log("getPages", "status_code", resp.StatusCode)
+		if resp.StatusCode != http.StatusOK {
+			var errResp struct {
+				Message string `json:"message"`
+			}
+			json.NewDecoder(resp.Body).Decode(&errResp)
+			resp.Body.Close()
+			apiErr := newAPIError(resp.StatusCode, errResp.Message, operation)
+			debug.Error("getPages", apiErr, "status", resp.StatusCode)
+			return nil, apiErr
+		}
+
+		var pageItems []T
+		err = json.NewDecoder(resp.Body).Decode(&pageItems)
+		resp.Body.Close()
+		if err != nil {
+			debug.Error("getPages", err, "stage", "decode_response")
+			return nil, fmt.Errorf("failed to decode response: %w", err)
+		}
+
+		items = append(items, pageItems...)
+		if limit > 0 && len(items) >= limit {
+			items = items[:limit]
+			break
+		}
+
+		url = nextPageURL(resp.Header.Get("Link"))
+	}
+
+	debug.Log("getPages", "operation", operation, "result_count", len(items))
+	return items, nil
+}
+
+// nextPageURL extracts the rel="next" URL from a Link header, e.g.
+// <https://api.github.com/repositories/1/issues?page=2>; rel="next", <...>; rel="last"
+func nextPageURL(link string) string {
+	for _, part := range strings.Split(link, ",") {
+		segments := strings.Split(part, ";")
+		if len(segments) < 2 {
+			continue
+		}
+		for _, param := range segments[1:] {
+			if strings.TrimSpace(param) == `rel="next"` {
+				return strings.Trim(strings.TrimSpace(segments[0]), "<>")
+			}
+		}
+	}
+	return ""
+}
@@ -0,0 +1,57 @@
+package api
+
+import "testing"
+
+func TestNextPageURL(t *testing.T) {
+	tests := []struct {
+		name string
+		link string
+		want string
+	}{
+		{
+			name: "next and last",
+			link: `<https://api.github.com/repositories/1/issues?page=2>; rel="next", <https://api.github.com/repositories/1/issues?page=5>; rel="last"`,
+			want: "https://api.github.com/repositories/1/issues?page=2",
+		},
+		{
+			name: "next after prev",
+			link: `<https://api.github.com/repositories/1/issues?page=1>; rel="prev", <https://api.github.com/repositories/1/issues?page=3>; rel="next"`,
+			want: "https://api.github.com/repositories/1/issues?page=3",
+		},
+		{
+			name: "last page has no next",
+			link: `<https://api.github.com/repositories/1/issues?page=4>; rel="prev", <https://api.github.com/repositories/1/issues?page=1>; rel="first"`,
+			want: "",
+		},
+		{
+			name: "no header",
+			link: "",
+			want: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := nextPageURL(tt.link); got != tt.want {
+				t.Errorf("nextPageURL() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPageSize(t *testing.T) {
+	tests := []struct {
+		perPage, limit, want int
+	}{
+		{0, 0, 100},
+		{30, 0, 30},
+		{0, 10, 10},
+		{30, 50, 30},
+	}
+
+	for _, tt := range tests {
+		if got := pageSize(tt.perPage, tt.limit); got != tt.want {
+			t.Errorf("pageSize(%d, %d) = %d, want %d", tt.perPage, tt.limit, got, tt.want)
+		}
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"strings"
 
 	"github.com/gwyn/gh-subissue/internal/debug"
 )
@@ -14,6 +15,7 @@ type Project struct {
 	ID     string `json:"id"`
 	Title  string `json:"title"`
 	Number int    `json:"number"`
+	Owner  string `json:"owner"` // login of the organization or user that owns the project
 }
 
 // graphqlRequest executes a GraphQL query against the GitHub API.
@@ -41,7 +43,8 @@ func (c *Client) graphqlRequest(query string, variables map[string]interface{})
 	}
 	req.Header.Set("Content-Type", "application/json")
 
-	resp, err := c.HTTPClient.Do(req)
+	// Queries are safe to repeat; mutations are not
+	resp, err := c.doRetry(req, !isMutation(query))
 	if err != nil {
 		return nil, fmt.Errorf("failed to send GraphQL request: %w", err)
 	}
@@ -65,32 +68,204 @@ func (c *Client) graphqlRequest(query string, variables map[string]interface{})
 	return result, nil
 }
 
-// ListProjects returns projects associated with a repository.
+// isMutation reports whether a GraphQL document is a mutation.
+func isMutation(query string) bool {
+	return strings.HasPrefix(strings.TrimSpace(query), "mutation")
+}
+
+// projectFields is the GraphQL selection for a project and its owner.
+const projectFields = `
+	id
+	title
+	number
+	owner {
+		... on Organization { login }
+		... on User { login }
+	}
+`
+
+// ListProjects returns the projects linked to a repository, the projects of
+// the organization or user that owns it, and the viewer's own projects.
+// Every source is paged through, and projects found more than once are
+// returned once, repository projects first. Only a failure to list the
+// repository's projects is an error.
 func (c *Client) ListProjects(owner, repo string) ([]Project, error) {
 	debug.Log("ListProjects", "owner", owner, "repo", repo)
 
-	query := `
-		query($owner: String!, $repo: String!) {
+	repoQuery := `
+		query($owner: String!, $repo: String!, $cursor: String) {
 			repository(owner: $owner, name: $repo) {
-				projectsV2(first: 20) {
-					nodes {
-						id
-						title
-						number
+				projectsV2(first: 100, after: $cursor) {
+					nodes {` + projectFields + `}
+					pageInfo { hasNextPage endCursor }
+				}
+			}
+		}
+	`
+	projects, err := c.listProjectPages("ListProjects", repoQuery, map[string]interface{}{
+		"owner": owner,
+		"repo":  repo,
+	}, true, "repository")
+	if err != nil {
+		debug.Error("ListProjects", err, "stage", "repository_projects")
+		return nil, err
+	}
+
+	// Projects of the owning organization or user that are not linked to the
+	// repository; tokens that can read the repository but not the owner's
+	// projects (such as in SAML-enforced organizations) fail this query,
+	// which is not fatal
+	ownerQuery := `
+		query($owner: String!, $cursor: String) {
+			repositoryOwner(login: $owner) {
+				... on ProjectV2Owner {
+					projectsV2(first: 100, after: $cursor) {
+						nodes {` + projectFields + `}
+						pageInfo { hasNextPage endCursor }
 					}
 				}
 			}
 		}
 	`
+	ownerProjects, err := c.listProjectPages("ListProjects", ownerQuery, map[string]interface{}{
+		"owner": owner,
+	}, false, "repositoryOwner")
+	if err != nil {
+		debug.Error("ListProjects", err, "stage", "owner_projects")
+		ownerProjects = nil
+	}
+
+	// The viewer's projects; tokens without access to them (such as
+	// GITHUB_TOKEN in Actions) fail this query, which is not fatal
+	viewerQuery := `
+		query($cursor: String) {
+			viewer {
+				projectsV2(first: 100, after: $cursor) {
+					nodes {` + projectFields + `}
+					pageInfo { hasNextPage endCursor }
+				}
+			}
+		}
+	`
+	viewerProjects, err := c.listProjectPages("ListProjects", viewerQuery, map[string]interface{}{}, false, "viewer")
+	if err != nil {
+		debug.Error("ListProjects", err, "stage", "viewer_projects")
+		viewerProjects = nil
+	}
+
+	seen := make(map[string]bool, len(projects))
+	for _, p := range projects {
+		seen[p.ID] = true
+	}
+	for _, p := range append(ownerProjects, viewerProjects...) {
+		if !seen[p.ID] {
+			seen[p.ID] = true
+			projects = append(projects, p)
+		}
+	}
+
+	debug.Log("ListProjects", "result_count", len(projects))
+	return projects, nil
+}
+
+// listProjectPages runs a projectsV2 query found under the given top-level
+// field, following endCursor until every page has been read. When required
+// is false, a missing or null field means there are no projects.
+func (c *Client) listProjectPages(fn, query string, variables map[string]interface{}, required bool, field string) ([]Project, error) {
+	var projects []Project
+	for page := 1; ; page++ {
+		debug.Log(fn, "field", field, "page", page)
+
+		result, err := c.graphqlRequest(query, variables)
+		if err != nil {
+			return nil, err
+		}
+
+		// Parse the response
+		data, ok := result["data"].(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("unexpected response format")
+		}
+
+		parent, ok := data[field].(map[string]interface{})
+		if !ok {
+			if required {
+				return nil, fmt.Errorf("%s not found in response", field)
+			}
+			return projects, nil
+		}
+
+		projectsV2, ok := parent["projectsV2"].(map[string]interface{})
+		if !ok {
+			if required {
+				return nil, fmt.Errorf("projectsV2 not found in response")
+			}
+			return projects, nil
+		}
+
+		nodes, ok := projectsV2["nodes"].([]interface{})
+		if !ok {
+			return nil, fmt.Errorf("nodes not found in response")
+		}
+
+		for _, node := range nodes {
+			if n, ok := node.(map[string]interface{}); ok {
+				projects = append(projects, parseProject(n))
+			}
+		}
+
+		pageInfo, _ := projectsV2["pageInfo"].(map[string]interface{})
+		hasNext, _ := pageInfo["hasNextPage"].(bool)
+		cursor, _ := pageInfo["endCursor"].(string)
+		if !hasNext || cursor == "" {
+			return projects, nil
+		}
+		variables["cursor"] = cursor
+	}
+}
+
+// parseProject converts a GraphQL project node into a Project.
+func parseProject(n map[string]interface{}) Project {
+	p := Project{}
+	if id, ok := n["id"].(string); ok {
+		p.ID = id
+	}
+	if title, ok := n["title"].(string); ok {
+		p.Title = title
+	}
+	if number, ok := n["number"].(float64); ok {
+		p.Number = int(number)
+	}
+	if owner, ok := n["owner"].(map[string]interface{}); ok {
+		if login, ok := owner["login"].(string); ok {
+			p.Owner = login
+		}
+	}
+	return p
+}
+
+// GetProject looks up a project by its owner (organization or user) and number.
+func (c *Client) GetProject(owner string, number int) (*Project, error) {
+	debug.Log("GetProject", "owner", owner, "number", number)
+
+	query := `
+		query($owner: String!, $number: Int!) {
+			repositoryOwner(login: $owner) {
+				... on ProjectV2Owner {
+					projectV2(number: $number) {` + projectFields + `}
+				}
+			}
+		}
+	`
 
 	variables := map[string]interface{}{
-		"owner": owner,
-		"repo":  repo,
+		"owner":  owner,
+		"number": number,
 	}
 
 	result, err := c.graphqlRequest(query, variables)
 	if err != nil {
-		debug.Error("ListProjects", err, "stage", "graphql_request")
+		debug.Error("GetProject", err, "stage", "graphql_request")
 		return nil, err
 	}
 
@@ -100,40 +275,19 @@ func (c *Client) ListProjects(owner, repo string) ([]Project, error) {
 		return nil, fmt.Errorf("unexpected response format")
 	}
 
-	repository, ok := data["repository"].(map[string]interface{})
-	if !ok {
-		return nil, fmt.Errorf("repository not found in response")
-	}
-
-	projectsV2, ok := repository["projectsV2"].(map[string]interface{})
+	repositoryOwner, ok := data["repositoryOwner"].(map[string]interface{})
 	if !ok {
-		return nil, fmt.Errorf("projectsV2 not found in response")
+		return nil, fmt.Errorf("owner %q not found", owner)
 	}
 
-	nodes, ok := projectsV2["nodes"].([]interface{})
+	node, ok := repositoryOwner["projectV2"].(map[string]interface{})
 	if !ok {
-		return nil, fmt.Errorf("nodes not found in response")
-	}
-
-	projects := make([]Project, 0, len(nodes))
-	for _, node := range nodes {
-		if n, ok := node.(map[string]interface{}); ok {
-			p := Project{}
-			if id, ok := n["id"].(string); ok {
-				p.ID = id
-			}
-			if title, ok := n["title"].(string); ok {
-				p.Title = title
-			}
-			if number, ok := n["number"].(float64); ok {
-				p.Number = int(number)
-			}
-			projects = append(projects, p)
-		}
+		return nil, fmt.Errorf("project %s/%d not found", owner, number)
 	}
 
-	debug.Log("ListProjects", "result_count", len(projects))
-	return projects, nil
+	project := parseProject(node)
+	debug.Log("GetProject", "result_title", project.Title)
+	return &project, nil
 }
 
 // GetIssueNodeID retrieves the GraphQL node ID for an issue.
@@ -188,7 +342,8 @@ func (c *Client) GetIssueNodeID(owner, repo string, number int) (string, error)
 }
 
 // AddIssueToProject adds an issue to a project using GraphQL mutation.
-func (c *Client) AddIssueToProject(projectID, issueNodeID string) error {
+// Returns the ID of the new project item.
+func (c *Client) AddIssueToProject(projectID, issueNodeID string) (string, error) {
 	debug.Log("AddIssueToProject", "project_id", projectID, "issue_node_id", issueNodeID)
 
 	query := `
@@ -206,12 +361,380 @@ func (c *Client) AddIssueToProject(projectID, issueNodeID string) error {
 		"contentId": issueNodeID,
 	}
 
-	_, err := c.graphqlRequest(query, variables)
+	result, err := c.graphqlRequest(query, variables)
 	if err != nil {
 		debug.Error("AddIssueToProject", err, "stage", "graphql_request")
+		return "", err
+	}
+
+	// Parse the response
+	data, ok := result["data"].(map[string]interface{})
+	if !ok {
+		return "", fmt.Errorf("unexpected response format")
+	}
+
+	added, ok := data["addProjectV2ItemById"].(map[string]interface{})
+	if !ok {
+		return "", fmt.Errorf("addProjectV2ItemById not found in response")
+	}
+
+	item, ok := added["item"].(map[string]interface{})
+	if !ok {
+		return "", fmt.Errorf("item not found in response")
+	}
+
+	itemID, ok := item["id"].(string)
+	if !ok {
+		return "", fmt.Errorf("item id not found in response")
+	}
+
+	debug.Log("AddIssueToProject", "result", "success", "item_id", itemID)
+	return itemID, nil
+}
+
+// Project field data types that can be set on an item.
+const (
+	ProjectFieldText         = "TEXT"
+	ProjectFieldNumber       = "NUMBER"
+	ProjectFieldDate         = "DATE"
+	ProjectFieldSingleSelect = "SINGLE_SELECT"
+	ProjectFieldIteration    = "ITERATION"
+)
+
+// ProjectField is a field of a project (v2), such as Status or Iteration.
+type ProjectField struct {
+	ID       string
+	Name     string
+	DataType string               // e.g. "SINGLE_SELECT", "ITERATION", "TEXT"
+	Options  []ProjectFieldOption // single-select options or iterations
+}
+
+// ProjectFieldOption is a single-select option or an iteration of a field.
+type ProjectFieldOption struct {
+	ID        string
+	Name      string
+	StartDate string // iterations only, YYYY-MM-DD
+}
+
+// ListProjectFields returns the fields of a project with their options.
+func (c *Client) ListProjectFields(projectID string) ([]ProjectField, error) {
+	debug.Log("ListProjectFields", "project_id", projectID)
+
+	query := `
+		query($projectId: ID!) {
+			node(id: $projectId) {
+				... on ProjectV2 {
+					fields(first: 100) {
+						nodes {
+							... on ProjectV2FieldCommon {
+								id
+								name
+								dataType
+							}
+							... on ProjectV2SingleSelectField {
+								options {
+									id
+									name
+								}
+							}
+							... on ProjectV2IterationField {
+								configuration {
+									iterations {
+										id
+										title
+										startDate
+									}
+								}
+							}
+						}
+					}
+				}
+			}
+		}
+	`
+
+	variables := map[string]interface{}{
+		"projectId": projectID,
+	}
+
+	result, err := c.graphqlRequest(query, variables)
+	if err != nil {
+		debug.Error("ListProjectFields", err, "stage", "graphql_request")
+		return nil, err
+	}
+
+	// Parse the response
+	data, ok := result["data"].(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("unexpected response format")
+	}
+
+	node, ok := data["node"].(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("project not found in response")
+	}
+
+	fields, ok := node["fields"].(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("fields not found in response")
+	}
+
+	nodes, ok := fields["nodes"].([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("nodes not found in response")
+	}
+
+	fieldList := make([]ProjectField, 0, len(nodes))
+	for _, n := range nodes {
+		f, ok := n.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		field := ProjectField{}
+		field.ID, _ = f["id"].(string)
+		field.Name, _ = f["name"].(string)
+		field.DataType, _ = f["dataType"].(string)
+
+		if options, ok := f["options"].([]interface{}); ok {
+			for _, o := range options {
+				if opt, ok := o.(map[string]interface{}); ok {
+					option := ProjectFieldOption{}
+					option.ID, _ = opt["id"].(string)
+					option.Name, _ = opt["name"].(string)
+					field.Options = append(field.Options, option)
+				}
+			}
+		}
+		if config, ok := f["configuration"].(map[string]interface{}); ok {
+			iterations, _ := config["iterations"].([]interface{})
+			for _, it := range iterations {
+				if iter, ok := it.(map[string]interface{}); ok {
+					option := ProjectFieldOption{}
+					option.ID, _ = iter["id"].(string)
+					option.Name, _ = iter["title"].(string)
+					option.StartDate, _ = iter["startDate"].(string)
+					field.Options = append(field.Options, option)
+				}
+			}
+		}
+
+		if field.ID != "" {
+			fieldList = append(fieldList, field)
+		}
+	}
+
+	debug.Log("ListProjectFields", "result_count", len(fieldList))
+	return fieldList, nil
+}
+
+// ProjectFieldValue is the value to set on a project item. Exactly one
+// member should be set, matching the field's data type.
+type ProjectFieldValue struct {
+	Text                 string
+	Number               *float64
+	Date                 string // YYYY-MM-DD
+	SingleSelectOptionID string
+	IterationID          string
+}
+
+// input returns the value as a ProjectV2FieldValue input object.
+func (v ProjectFieldValue) input() map[string]interface{} {
+	switch {
+	case v.Number != nil:
+		return map[string]interface{}{"number": *v.Number}
+	case v.Date != "":
+		return map[string]interface{}{"date": v.Date}
+	case v.SingleSelectOptionID != "":
+		return map[string]interface{}{"singleSelectOptionId": v.SingleSelectOptionID}
+	case v.IterationID != "":
+		return map[string]interface{}{"iterationId": v.IterationID}
+	default:
+		return map[string]interface{}{"text": v.Text}
+	}
+}
+
+// SetProjectFieldValueOptions contains options for setting a field on a project item.
+type SetProjectFieldValueOptions struct {
+	ProjectID string
+	ItemID    string
+	FieldID   string
+	Value     ProjectFieldValue
+}
+
+// SetProjectFieldValue sets a field value on a project item.
+func (c *Client) SetProjectFieldValue(opts SetProjectFieldValueOptions) error {
+	debug.Log("SetProjectFieldValue", "project_id", opts.ProjectID, "item_id", opts.ItemID, "field_id", opts.FieldID)
+
+	query := `
+		mutation($projectId: ID!, $itemId: ID!, $fieldId: ID!, $value: ProjectV2FieldValue!) {
+			updateProjectV2ItemFieldValue(input: {projectId: $projectId, itemId: $itemId, fieldId: $fieldId, value: $value}) {
+				projectV2Item {
+					id
+				}
+			}
+		}
+	`
+
+	variables := map[string]interface{}{
+		"projectId": opts.ProjectID,
+		"itemId":    opts.ItemID,
+		"fieldId":   opts.FieldID,
+		"value":     opts.Value.input(),
+	}
+
+	_, err := c.graphqlRequest(query, variables)
+	if err != nil {
+		debug.Error("SetProjectFieldValue", err, "stage", "graphql_request")
+		return err
+	}
+
+	debug.Log("SetProjectFieldValue", "result", "success")
+	return nil
+}
+
+// ProjectItem is an issue's entry in a project.
+type ProjectItem struct {
+	ID      string
+	Project Project
+}
+
+// ListIssueProjectItems returns the project items of an issue, one per
+// project the issue belongs to.
+func (c *Client) ListIssueProjectItems(owner, repo string, number int) ([]ProjectItem, error) {
+	debug.Log("ListIssueProjectItems", "owner", owner, "repo", repo, "number", number)
+
+	query := `
+		query($owner: String!, $repo: String!, $number: Int!, $cursor: String) {
+			repository(owner: $owner, name: $repo) {
+				issue(number: $number) {
+					projectItems(first: 100, after: $cursor, includeArchived: false) {
+						nodes {
+							id
+							project {` + projectFields + `}
+						}
+						pageInfo { hasNextPage endCursor }
+					}
+				}
+			}
+		}
+	`
+
+	variables := map[string]interface{}{
+		"owner":  owner,
+		"repo":   repo,
+		"number": number,
+	}
+
+	var items []ProjectItem
+	for {
+		result, err := c.graphqlRequest(query, variables)
+		if err != nil {
+			debug.Error("ListIssueProjectItems", err, "stage", "graphql_request")
+			return nil, err
+		}
+
+		// Parse the response
+		data, ok := result["data"].(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("unexpected response format")
+		}
+
+		repository, ok := data["repository"].(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("repository not found in response")
+		}
+
+		issue, ok := repository["issue"].(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("issue not found in response")
+		}
+
+		projectItems, ok := issue["projectItems"].(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("projectItems not found in response")
+		}
+
+		nodes, ok := projectItems["nodes"].([]interface{})
+		if !ok {
+			return nil, fmt.Errorf("nodes not found in response")
+		}
+
+		for _, node := range nodes {
+			n, ok := node.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			item := ProjectItem{}
+			item.ID, _ = n["id"].(string)
+			if project, ok := n["project"].(map[string]interface{}); ok {
+				item.Project = parseProject(project)
+			}
+			items = append(items, item)
+		}
+
+		pageInfo, _ := projectItems["pageInfo"].(map[string]interface{})
+		hasNext, _ := pageInfo["hasNextPage"].(bool)
+		cursor, _ := pageInfo["endCursor"].(string)
+		if !hasNext || cursor == "" {
+			break
+		}
+		variables["cursor"] = cursor
+	}
+
+	debug.Log("ListIssueProjectItems", "result_count", len(items))
+	return items, nil
+}
+
+// DeleteProjectItem removes an item from a project.
+func (c *Client) DeleteProjectItem(projectID, itemID string) error {
+	debug.Log("DeleteProjectItem", "project_id", projectID, "item_id", itemID)
+
+	query := `
+		mutation($projectId: ID!, $itemId: ID!) {
+			deleteProjectV2Item(input: {projectId: $projectId, itemId: $itemId}) {
+				deletedItemId
+			}
+		}
+	`
+
+	variables := map[string]interface{}{
+		"projectId": projectID,
+		"itemId":    itemID,
+	}
+
+	if _, err := c.graphqlRequest(query, variables); err != nil {
+		debug.Error("DeleteProjectItem", err, "stage", "graphql_request")
+		return err
+	}
+
+	debug.Log("DeleteProjectItem", "result", "success")
+	return nil
+}
+
+// ArchiveProjectItem archives an item in a project.
+func (c *Client) ArchiveProjectItem(projectID, itemID string) error {
+	debug.Log("ArchiveProjectItem", "project_id", projectID, "item_id", itemID)
+
+	query := `
+		mutation($projectId: ID!, $itemId: ID!) {
+			archiveProjectV2Item(input: {projectId: $projectId, itemId: $itemId}) {
+				item {
+					id
+				}
+			}
+		}
+	`
+
+	variables := map[string]interface{}{
+		"projectId": projectID,
+		"itemId":    itemID,
+	}
+
+	if _, err := c.graphqlRequest(query, variables); err != nil {
+		debug.Error("ArchiveProjectItem", err, "stage", "graphql_request")
 		return err
 	}
 
-	debug.Log("AddIssueToProject", "result", "success")
+	debug.Log("ArchiveProjectItem", "result", "success")
 	return nil
 }
@@ -1,8 +1,11 @@
 package api
 
 import (
+	"encoding/json"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 )
 
@@ -82,6 +85,7 @@ func TestAddIssueToProject(t *testing.T) {
 		name       string
 		response   string
 		statusCode int
+		wantItemID string
 		wantErr    bool
 	}{
 		{
@@ -96,6 +100,7 @@ func TestAddIssueToProject(t *testing.T) {
 				}
 			}`,
 			statusCode: http.StatusOK,
+			wantItemID: "PVTI_123",
 			wantErr:    false,
 		},
 		{
@@ -123,10 +128,13 @@ func TestAddIssueToProject(t *testing.T) {
 				BaseURL:    server.URL,
 			}
 
-			err := client.AddIssueToProject("PVT_123", "I_abc123")
+			itemID, err := client.AddIssueToProject("PVT_123", "I_abc123")
 			if (err != nil) != tt.wantErr {
 				t.Errorf("AddIssueToProject() error = %v, wantErr %v", err, tt.wantErr)
 			}
+			if itemID != tt.wantItemID {
+				t.Errorf("AddIssueToProject() item ID = %q, want %q", itemID, tt.wantItemID)
+			}
 		})
 	}
 }
@@ -190,3 +198,289 @@ func TestGetIssueNodeID(t *testing.T) {
 		})
 	}
 }
+
+func TestListProjectFields(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{
+			"data": {
+				"node": {
+					"fields": {
+						"nodes": [
+							{"id": "F_title", "name": "Title", "dataType": "TITLE"},
+							{"id": "F_status", "name": "Status", "dataType": "SINGLE_SELECT",
+							 "options": [{"id": "opt_todo", "name": "Todo"}, {"id": "opt_wip", "name": "In progress"}]},
+							{"id": "F_iter", "name": "Sprint", "dataType": "ITERATION",
+							 "configuration": {"iterations": [{"id": "it_1", "title": "Sprint 1", "startDate": "2026-10-05"}]}},
+							{"id": "F_est", "name": "Estimate", "dataType": "NUMBER"}
+						]
+					}
+				}
+			}
+		}`))
+	}))
+	defer server.Close()
+
+	client := &Client{HTTPClient: server.Client(), BaseURL: server.URL}
+	fields, err := client.ListProjectFields("PVT_1")
+	if err != nil {
+		t.Fatalf("ListProjectFields() error = %v", err)
+	}
+
+	if len(fields) != 4 {
+		t.Fatalf("got %d fields, want 4", len(fields))
+	}
+	status := fields[1]
+	if status.DataType != ProjectFieldSingleSelect || len(status.Options) != 2 || status.Options[1].ID != "opt_wip" {
+		t.Errorf("unexpected status field: %+v", status)
+	}
+	sprint := fields[2]
+	if len(sprint.Options) != 1 || sprint.Options[0].Name != "Sprint 1" || sprint.Options[0].StartDate != "2026-10-05" {
+		t.Errorf("unexpected iteration field: %+v", sprint)
+	}
+}
+
+func TestSetProjectFieldValue(t *testing.T) {
+	three := 3.0
+	tests := []struct {
+		name  string
+		value ProjectFieldValue
+		want  string
+	}{
+		{name: "text", value: ProjectFieldValue{Text: "hello"}, want: `{"text":"hello"}`},
+		{name: "number", value: ProjectFieldValue{Number: &three}, want: `{"number":3}`},
+		{name: "date", value: ProjectFieldValue{Date: "2026-10-16"}, want: `{"date":"2026-10-16"}`},
+		{name: "single select", value: ProjectFieldValue{SingleSelectOptionID: "opt_1"}, want: `{"singleSelectOptionId":"opt_1"}`},
+		{name: "iteration", value: ProjectFieldValue{IterationID: "it_1"}, want: `{"iterationId":"it_1"}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				var req struct {
+					Variables map[string]json.RawMessage `json:"variables"`
+				}
+				json.NewDecoder(r.Body).Decode(&req)
+				if got := string(req.Variables["value"]); got != tt.want {
+					t.Errorf("value = %s, want %s", got, tt.want)
+				}
+				if got := string(req.Variables["itemId"]); got != `"PVTI_1"` {
+					t.Errorf("itemId = %s", got)
+				}
+				w.Write([]byte(`{"data": {"updateProjectV2ItemFieldValue": {"projectV2Item": {"id": "PVTI_1"}}}}`))
+			}))
+			defer server.Close()
+
+			client := &Client{HTTPClient: server.Client(), BaseURL: server.URL}
+			err := client.SetProjectFieldValue(SetProjectFieldValueOptions{
+				ProjectID: "PVT_1",
+				ItemID:    "PVTI_1",
+				FieldID:   "F_1",
+				Value:     tt.value,
+			})
+			if err != nil {
+				t.Errorf("SetProjectFieldValue() error = %v", err)
+			}
+		})
+	}
+}
+
+func TestListProjectsPagesAndMergesOwners(t *testing.T) {
+	var viewerCalls int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Query     string                 `json:"query"`
+			Variables map[string]interface{} `json:"variables"`
+		}
+		json.NewDecoder(r.Body).Decode(&req)
+		cursor, _ := req.Variables["cursor"].(string)
+
+		switch {
+		case strings.Contains(req.Query, "repository(") && cursor == "":
+			w.Write([]byte(`{"data": {"repository": {"projectsV2": {
+				"nodes": [{"id": "PVT_1", "title": "Roadmap", "number": 1, "owner": {"login": "org"}}],
+				"pageInfo": {"hasNextPage": true, "endCursor": "c1"}}}}}`))
+		case strings.Contains(req.Query, "repository("):
+			if cursor != "c1" {
+				t.Errorf("cursor = %q, want c1", cursor)
+			}
+			w.Write([]byte(`{"data": {"repository": {"projectsV2": {
+				"nodes": [{"id": "PVT_2", "title": "Sprint", "number": 2, "owner": {"login": "org"}}],
+				"pageInfo": {"hasNextPage": false, "endCursor": "c2"}}}}}`))
+		case strings.Contains(req.Query, "repositoryOwner("):
+			w.Write([]byte(`{"data": {"repositoryOwner": {"projectsV2": {
+				"nodes": [
+					{"id": "PVT_1", "title": "Roadmap", "number": 1, "owner": {"login": "org"}},
+					{"id": "PVT_7", "title": "Planning", "number": 7, "owner": {"login": "org"}}
+				],
+				"pageInfo": {"hasNextPage": false}}}}}`))
+		case strings.Contains(req.Query, "viewer"):
+			viewerCalls++
+			w.Write([]byte(`{"data": {"viewer": {"projectsV2": {
+				"nodes": [{"id": "PVT_9", "title": "Personal", "number": 3, "owner": {"login": "octocat"}}],
+				"pageInfo": {"hasNextPage": false}}}}}`))
+		default:
+			t.Errorf("unexpected query: %s", req.Query)
+		}
+	}))
+	defer server.Close()
+
+	client := &Client{HTTPClient: server.Client(), BaseURL: server.URL}
+	projects, err := client.ListProjects("org", "repo")
+	if err != nil {
+		t.Fatalf("ListProjects() error = %v", err)
+	}
+
+	var got []string
+	for _, p := range projects {
+		got = append(got, fmt.Sprintf("%s/%d %s", p.Owner, p.Number, p.Title))
+	}
+	want := []string{"org/1 Roadmap", "org/2 Sprint", "org/7 Planning", "octocat/3 Personal"}
+	if strings.Join(got, ", ") != strings.Join(want, ", ") {
+		t.Errorf("projects = %v, want %v", got, want)
+	}
+	if viewerCalls != 1 {
+		t.Errorf("viewer queried %d times, want 1", viewerCalls)
+	}
+}
+
+func TestListProjectsOwnerAndViewerErrorsAreNotFatal(t *testing.T) {
+	tests := []struct {
+		name  string
+		field string // query that fails
+	}{
+		{name: "owner projects", field: "repositoryOwner("},
+		{name: "viewer projects", field: "viewer"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				var req struct {
+					Query string `json:"query"`
+				}
+				json.NewDecoder(r.Body).Decode(&req)
+				if strings.Contains(req.Query, tt.field) {
+					w.Write([]byte(`{"errors": [{"message": "Resource not accessible by integration"}]}`))
+					return
+				}
+				w.Write([]byte(`{"data": {"repository": {"projectsV2": {
+					"nodes": [{"id": "PVT_1", "title": "Roadmap", "number": 1}]}}}}`))
+			}))
+			defer server.Close()
+
+			client := &Client{HTTPClient: server.Client(), BaseURL: server.URL}
+			projects, err := client.ListProjects("org", "repo")
+			if err != nil {
+				t.Fatalf("ListProjects() error = %v", err)
+			}
+			if len(projects) != 1 || projects[0].ID != "PVT_1" {
+				t.Errorf("projects = %+v, want the repository project", projects)
+			}
+		})
+	}
+}
+
+func TestGetProject(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Variables map[string]interface{} `json:"variables"`
+		}
+		json.NewDecoder(r.Body).Decode(&req)
+		if req.Variables["owner"] != "org" || req.Variables["number"] != float64(7) {
+			t.Errorf("unexpected variables: %v", req.Variables)
+		}
+		w.Write([]byte(`{"data": {"repositoryOwner": {"projectV2":
+			{"id": "PVT_7", "title": "Planning", "number": 7, "owner": {"login": "org"}}}}}`))
+	}))
+	defer server.Close()
+
+	client := &Client{HTTPClient: server.Client(), BaseURL: server.URL}
+	project, err := client.GetProject("org", 7)
+	if err != nil {
+		t.Fatalf("GetProject() error = %v", err)
+	}
+	if project.ID != "PVT_7" || project.Owner != "org" {
+		t.Errorf("unexpected project: %+v", project)
+	}
+}
+
+func TestListIssueProjectItems(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Variables map[string]interface{} `json:"variables"`
+		}
+		json.NewDecoder(r.Body).Decode(&req)
+		if req.Variables["number"] != float64(43) {
+			t.Errorf("number = %v, want 43", req.Variables["number"])
+		}
+		if req.Variables["cursor"] == nil {
+			w.Write([]byte(`{"data": {"repository": {"issue": {"projectItems": {
+				"nodes": [{"id": "PVTI_1", "project": {"id": "PVT_1", "title": "Roadmap", "number": 1, "owner": {"login": "org"}}}],
+				"pageInfo": {"hasNextPage": true, "endCursor": "c1"}}}}}}`))
+			return
+		}
+		w.Write([]byte(`{"data": {"repository": {"issue": {"projectItems": {
+			"nodes": [{"id": "PVTI_2", "project": {"id": "PVT_2", "title": "Sprint", "number": 2}}],
+			"pageInfo": {"hasNextPage": false}}}}}}`))
+	}))
+	defer server.Close()
+
+	client := &Client{HTTPClient: server.Client(), BaseURL: server.URL}
+	items, err := client.ListIssueProjectItems("org", "repo", 43)
+	if err != nil {
+		t.Fatalf("ListIssueProjectItems() error = %v", err)
+	}
+	if len(items) != 2 {
+		t.Fatalf("got %d items, want 2", len(items))
+	}
+	if items[0].ID != "PVTI_1" || items[0].Project.Title != "Roadmap" || items[0].Project.Owner != "org" {
+		t.Errorf("unexpected first item: %+v", items[0])
+	}
+	if items[1].ID != "PVTI_2" || items[1].Project.ID != "PVT_2" {
+		t.Errorf("unexpected second item: %+v", items[1])
+	}
+}
+
+func TestDeleteAndArchiveProjectItem(t *testing.T) {
+	tests := []struct {
+		name     string
+		call     func(c *Client) error
+		mutation string
+	}{
+		{
+			name:     "delete",
+			call:     func(c *Client) error { return c.DeleteProjectItem("PVT_1", "PVTI_1") },
+			mutation: "deleteProjectV2Item",
+		},
+		{
+			name:     "archive",
+			call:     func(c *Client) error { return c.ArchiveProjectItem("PVT_1", "PVTI_1") },
+			mutation: "archiveProjectV2Item",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				var req struct {
+					Query     string                 `json:"query"`
+					Variables map[string]interface{} `json:"variables"`
+				}
+				json.NewDecoder(r.Body).Decode(&req)
+				if !strings.Contains(req.Query, tt.mutation) {
+					t.Errorf("query does not use %s: %s", tt.mutation, req.Query)
+				}
+				if req.Variables["projectId"] != "PVT_1" || req.Variables["itemId"] != "PVTI_1" {
+					t.Errorf("unexpected variables: %v", req.Variables)
+				}
+				w.Write([]byte(`{"data": {}}`))
+			}))
+			defer server.Close()
+
+			client := &Client{HTTPClient: server.Client(), BaseURL: server.URL}
+			if err := tt.call(client); err != nil {
+				t.Errorf("error = %v", err)
+			}
+		})
+	}
+}
@@ -21,7 +21,8 @@ type Repository struct {
 type ListRepositoriesOptions struct {
 	Owner   string
 	PerPage int
-	Page    int
+	Page    int // first page to fetch; 0 starts at the beginning
+	Limit   int // maximum repositories to return; 0 fetches every page
 }
 
 // User represents a GitHub user.
@@ -32,7 +33,7 @@ type User struct {
 // ListRepositories lists repositories for an owner (user or organization).
 // It tries the org endpoint first, then falls back to the user endpoint.
 func (c *Client) ListRepositories(opts ListRepositoriesOptions) ([]Repository, error) {
-	debug.Log("ListRepositories", "owner", opts.Owner, "per_page", opts.PerPage, "page", opts.Page)
+	debug.Log("ListRepositories", "owner", opts.Owner, "per_page", opts.PerPage, "page", opts.Page, "limit", opts.Limit)
 
 	// Try org endpoint first
 	repos, err := c.listOrgRepositories(opts)
@@ -50,59 +51,23 @@ func (c *Client) ListRepositories(opts ListRepositoriesOptions) ([]Repository, e
 }
 
 func (c *Client) listOrgRepositories(opts ListRepositoriesOptions) ([]Repository, error) {
-	url := fmt.Sprintf("%s/orgs/%s/repos?per_page=%d", c.BaseURL, opts.Owner, opts.PerPage)
+	url := fmt.Sprintf("%s/orgs/%s/repos?per_page=%d", c.BaseURL, opts.Owner, pageSize(opts.PerPage, opts.Limit))
 	if opts.Page > 0 {
 		url = fmt.Sprintf("%s&page=%d", url, opts.Page)
 	}
 	debug.Log("listOrgRepositories", "url", url)
 
-	return c.fetchRepositories(url, "list org repositories")
+	return getPages[Repository](c, url, "list org repositories", opts.Limit)
 }
 
 func (c *Client) listUserRepositories(opts ListRepositoriesOptions) ([]Repository, error) {
-	url := fmt.Sprintf("%s/users/%s/repos?per_page=%d", c.BaseURL, opts.Owner, opts.PerPage)
+	url := fmt.Sprintf("%s/users/%s/repos?per_page=%d", c.BaseURL, opts.Owner, pageSize(opts.PerPage, opts.Limit))
 	if opts.Page > 0 {
 		url = fmt.Sprintf("%s&page=%d", url, opts.Page)
 	}
 	debug.Log("listUserRepositories", "url", url)
 
-	return c.fetchRepositories(url, "list user repositories")
-}
-
-func (c *Client) fetchRepositories(url, operation string) ([]Repository, error) {
-	req, err := http.NewRequest("GET", url, nil)
-	if err != nil {
-		debug.Error("fetchRepositories", err, "stage", "new_request")
-		return nil, fmt.Errorf("failed to create request: %w", err)
-	}
-
-	debug.Log("fetchRepositories", "action", "sending_request")
-	resp, err := c.HTTPClient.Do(req)
-	if err != nil {
-		debug.Error("fetchRepositories", err, "stage", "do_request")
-		return nil, fmt.Errorf("failed to send request: %w", err)
-	}
-	defer resp.Body.Close()
-
-	debug.Log("fetchRepositories", "status_code", resp.StatusCode)
-	if resp.StatusCode != http.StatusOK {
-		var errResp struct {
-			Message string `json:"message"`
-		}
-		json.NewDecoder(resp.Body).Decode(&errResp)
-		apiErr := newAPIError(resp.StatusCode, errResp.Message, operation)
-		debug.Error("fetchRepositories", apiErr, "status", resp.StatusCode)
-		return nil, apiErr
-	}
-
-	var repos []Repository
-	if err := json.NewDecoder(resp.Body).Decode(&repos); err != nil {
-		debug.Error("fetchRepositories", err, "stage", "decode_response")
-		return nil, fmt.Errorf("failed to decode response: %w", err)
-	}
-
-	debug.Log("fetchRepositories", "result_count", len(repos))
-	return repos, nil
+	return getPages[Repository](c, url, "list user repositories", opts.Limit)
 }
 
 // GetAuthenticatedUser returns the currently authenticated user.
@@ -119,7 +84,7 @@ func (c *Client) GetAuthenticatedUser() (*User, error) {
 	}
 
 	debug.Log("GetAuthenticatedUser", "action", "sending_request")
-	resp, err := c.HTTPClient.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		debug.Error("GetAuthenticatedUser", err, "stage", "do_request")
 		return nil, fmt.Errorf("failed to send request: %w", err)
@@ -2,6 +2,7 @@ package api
 
 import (
 	"encoding/json"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -263,3 +264,31 @@ func TestGetAuthenticatedUser(t *testing.T) {
 		})
 	}
 }
+
+func TestListRepositoriesFollowsLinkHeader(t *testing.T) {
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("page") == "" {
+			w.Header().Set("Link", fmt.Sprintf(`<%s/orgs/testorg/repos?per_page=2&page=2>; rel="next"`, server.URL))
+			json.NewEncoder(w).Encode([]map[string]interface{}{
+				{"name": "repo1", "full_name": "testorg/repo1"},
+				{"name": "repo2", "full_name": "testorg/repo2"},
+			})
+			return
+		}
+		json.NewEncoder(w).Encode([]map[string]interface{}{
+			{"name": "repo3", "full_name": "testorg/repo3"},
+		})
+	}))
+	defer server.Close()
+
+	client := &Client{HTTPClient: server.Client(), BaseURL: server.URL}
+
+	repos, err := client.ListRepositories(ListRepositoriesOptions{Owner: "testorg", PerPage: 2})
+	if err != nil {
+		t.Fatalf("ListRepositories() error = %v", err)
+	}
+	if len(repos) != 3 {
+		t.Errorf("ListRepositories() returned %d repos, want 3", len(repos))
+	}
+}
@@ -0,0 +1,169 @@
+package api
+
+import (
+	"bytes"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gwyn/gh-subissue/internal/debug"
+)
+
+// DefaultMaxAttempts is the number of times a request is sent when
+// Client.MaxAttempts is not set.
+const DefaultMaxAttempts = 3
+
+const (
+	// retryBaseDelay is the backoff before the second attempt; it doubles
+	// with each further attempt.
+	retryBaseDelay = time.Second
+
+	// maxRetryWait is the longest the client waits before retrying. A rate
+	// limit that resets later than this is returned as an error instead.
+	maxRetryWait = time.Minute
+)
+
+// do sends req, retrying server errors only when it is idempotent.
+func (c *Client) do(req *http.Request) (*http.Response, error) {
+	return c.doRetry(req, isIdempotent(req.Method))
+}
+
+// doRetry sends req up to MaxAttempts times. Rate-limited responses are
+// always retried after the time GitHub asks for in Retry-After or
+// X-RateLimit-Reset, since GitHub rejected the request without applying it.
+// Network errors and 5xx responses are retried with jittered exponential
+// backoff only when retryable is true: a POST that failed with a 5xx may
+// still have been applied.
+func (c *Client) doRetry(req *http.Request, retryable bool) (*http.Response, error) {
+	attempts := c.MaxAttempts
+	if attempts <= 0 {
+		attempts = DefaultMaxAttempts
+	}
+	if req.Body != nil && req.GetBody == nil {
+		attempts = 1
+	}
+
+	for attempt := 1; ; attempt++ {
+		if attempt > 1 && req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			req.Body = body
+		}
+
+		resp, err := c.HTTPClient.Do(req)
+		if attempt >= attempts {
+			return resp, err
+		}
+
+		wait, reason := retryDelay(resp, err, attempt, retryable)
+		if reason == "" {
+			return resp, err
+		}
+		if wait > maxRetryWait {
+			debug.Log("Client.do", "method", req.Method, "url", req.URL.String(), "reason", reason,
+				"wait", wait.String(), "result", "wait_too_long")
+			return resp, err
+		}
+		if resp != nil {
+			io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+		}
+
+		debug.Log("Client.do", "method", req.Method, "url", req.URL.String(), "attempt", attempt,
+			"max_attempts", attempts, "reason", reason, "wait", wait.String())
+		c.sleep(wait)
+	}
+}
+
+func (c *Client) sleep(d time.Duration) {
+	if c.Sleep != nil {
+		c.Sleep(d)
+		return
+	}
+	time.Sleep(d)
+}
+
+// retryDelay decides whether a request that got resp or err should be sent
+// again. It returns how long to wait and why, or an empty reason when the
+// outcome is final. Network errors and server errors are final unless
+// retryable is true.
+func retryDelay(resp *http.Response, err error, attempt int, retryable bool) (time.Duration, string) {
+	if err != nil {
+		if !retryable {
+			return 0, ""
+		}
+		return backoff(attempt), "network_error"
+	}
+
+	switch {
+	case isRateLimited(resp):
+		if d, ok := retryAfter(resp.Header); ok {
+			return d, "rate_limited"
+		}
+		if resp.Header.Get("X-RateLimit-Remaining") == "0" {
+			if reset, err := strconv.ParseInt(resp.Header.Get("X-RateLimit-Reset"), 10, 64); err == nil {
+				return max(time.Until(time.Unix(reset, 0))+time.Second, 0), "rate_limit_reset"
+			}
+		}
+		// Secondary rate limits without a hint: back off and try again
+		return backoff(attempt), "secondary_rate_limit"
+	case resp.StatusCode >= 500 && retryable:
+		if d, ok := retryAfter(resp.Header); ok {
+			return d, "server_error"
+		}
+		return backoff(attempt), "server_error"
+	}
+	return 0, ""
+}
+
+// isRateLimited reports whether resp is a primary or secondary rate limit.
+// GitHub answers with 429, or with 403 and either rate limit headers or a
+// message mentioning the rate limit. The body is restored after reading it.
+func isRateLimited(resp *http.Response) bool {
+	if resp.StatusCode == http.StatusTooManyRequests {
+		return true
+	}
+	if resp.StatusCode != http.StatusForbidden {
+		return false
+	}
+	if resp.Header.Get("Retry-After") != "" || resp.Header.Get("X-RateLimit-Remaining") == "0" {
+		return true
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	return err == nil && strings.Contains(strings.ToLower(string(body)), "rate limit")
+}
+
+// retryAfter parses a Retry-After header given in seconds.
+func retryAfter(h http.Header) (time.Duration, bool) {
+	seconds, err := strconv.Atoi(h.Get("Retry-After"))
+	if err != nil || seconds < 0 {
+		return 0, false
+	}
+	return time.Duration(seconds) * time.Second, true
+}
+
+// backoff returns the wait before the attempt after the given one: the base
+// delay doubled for each earlier attempt, with the upper half jittered so
+// concurrent clients do not retry in lockstep.
+func backoff(attempt int) time.Duration {
+	d := retryBaseDelay << (attempt - 1)
+	return d/2 + rand.N(d/2+1)
+}
+
+// isIdempotent reports whether a request with the given method can be sent
+// twice without changing the outcome.
+func isIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,280 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+)
+
+// recordSleeps returns a Sleep func that records the waits instead of sleeping.
+func recordSleeps(waits *[]time.Duration) func(time.Duration) {
+	return func(d time.Duration) {
+		*waits = append(*waits, d)
+	}
+}
+
+func TestClientRetries(t *testing.T) {
+	tests := []struct {
+		name         string
+		maxAttempts  int
+		failures     int // responses that fail before the request succeeds
+		fail         func(w http.ResponseWriter)
+		wantRequests int
+		wantErr      bool
+		checkWaits   func(t *testing.T, waits []time.Duration)
+	}{
+		{
+			name:     "server error then success",
+			failures: 2,
+			fail: func(w http.ResponseWriter) {
+				w.WriteHeader(http.StatusBadGateway)
+			},
+			wantRequests: 3,
+			checkWaits: func(t *testing.T, waits []time.Duration) {
+				if len(waits) != 2 {
+					t.Fatalf("waits = %v, want 2", waits)
+				}
+				if waits[0] < 500*time.Millisecond || waits[0] > time.Second {
+					t.Errorf("first wait = %v, want 0.5s-1s", waits[0])
+				}
+				if waits[1] < time.Second || waits[1] > 2*time.Second {
+					t.Errorf("second wait = %v, want 1s-2s", waits[1])
+				}
+			},
+		},
+		{
+			name:     "gives up after max attempts",
+			failures: 5,
+			fail: func(w http.ResponseWriter) {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				json.NewEncoder(w).Encode(map[string]string{"message": "Service Unavailable"})
+			},
+			wantRequests: 3,
+			wantErr:      true,
+		},
+		{
+			name:        "max attempts of one disables retries",
+			maxAttempts: 1,
+			failures:    1,
+			fail: func(w http.ResponseWriter) {
+				w.WriteHeader(http.StatusInternalServerError)
+			},
+			wantRequests: 1,
+			wantErr:      true,
+		},
+		{
+			name:     "honors Retry-After",
+			failures: 1,
+			fail: func(w http.ResponseWriter) {
+				w.Header().Set("Retry-After", "7")
+				w.WriteHeader(http.StatusTooManyRequests)
+			},
+			wantRequests: 2,
+			checkWaits: func(t *testing.T, waits []time.Duration) {
+				if len(waits) != 1 || waits[0] != 7*time.Second {
+					t.Errorf("waits = %v, want [7s]", waits)
+				}
+			},
+		},
+		{
+			name:     "honors X-RateLimit-Reset",
+			failures: 1,
+			fail: func(w http.ResponseWriter) {
+				w.Header().Set("X-RateLimit-Remaining", "0")
+				w.Header().Set("X-RateLimit-Reset", strconv.FormatInt(time.Now().Add(10*time.Second).Unix(), 10))
+				w.WriteHeader(http.StatusForbidden)
+			},
+			wantRequests: 2,
+			checkWaits: func(t *testing.T, waits []time.Duration) {
+				if len(waits) != 1 || waits[0] < 9*time.Second || waits[0] > 12*time.Second {
+					t.Errorf("waits = %v, want about 11s", waits)
+				}
+			},
+		},
+		{
+			name:     "does not wait for a distant reset",
+			failures: 1,
+			fail: func(w http.ResponseWriter) {
+				w.Header().Set("X-RateLimit-Remaining", "0")
+				w.Header().Set("X-RateLimit-Reset", strconv.FormatInt(time.Now().Add(time.Hour).Unix(), 10))
+				w.WriteHeader(http.StatusForbidden)
+				json.NewEncoder(w).Encode(map[string]string{"message": "API rate limit exceeded"})
+			},
+			wantRequests: 1,
+			wantErr:      true,
+		},
+		{
+			name:     "secondary rate limit",
+			failures: 1,
+			fail: func(w http.ResponseWriter) {
+				w.WriteHeader(http.StatusForbidden)
+				json.NewEncoder(w).Encode(map[string]string{"message": "You have exceeded a secondary rate limit."})
+			},
+			wantRequests: 2,
+		},
+		{
+			name:     "other forbidden responses are final",
+			failures: 1,
+			fail: func(w http.ResponseWriter) {
+				w.WriteHeader(http.StatusForbidden)
+				json.NewEncoder(w).Encode(map[string]string{"message": "Resource not accessible by integration"})
+			},
+			wantRequests: 1,
+			wantErr:      true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			requests := 0
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				requests++
+				if requests <= tt.failures {
+					tt.fail(w)
+					return
+				}
+				json.NewEncoder(w).Encode(map[string]interface{}{"id": 1, "number": 42})
+			}))
+			defer server.Close()
+
+			var waits []time.Duration
+			client := &Client{HTTPClient: server.Client(), BaseURL: server.URL, MaxAttempts: tt.maxAttempts, Sleep: recordSleeps(&waits)}
+			_, err := client.GetIssue("owner", "repo", 42)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("GetIssue() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if requests != tt.wantRequests {
+				t.Errorf("requests = %d, want %d", requests, tt.wantRequests)
+			}
+			if tt.checkWaits != nil {
+				tt.checkWaits(t, waits)
+			}
+		})
+	}
+}
+
+func TestClientRetriesOnlyIdempotentRequests(t *testing.T) {
+	tests := []struct {
+		name         string
+		fail         func(w http.ResponseWriter)
+		wantRequests int
+		wantErr      bool
+	}{
+		{
+			name: "server error is not retried",
+			fail: func(w http.ResponseWriter) {
+				w.WriteHeader(http.StatusBadGateway)
+			},
+			wantRequests: 1,
+			wantErr:      true,
+		},
+		{
+			name: "rate limit is retried",
+			fail: func(w http.ResponseWriter) {
+				w.Header().Set("Retry-After", "1")
+				w.WriteHeader(http.StatusTooManyRequests)
+			},
+			wantRequests: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			requests := 0
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				requests++
+				var req CreateIssueOptions
+				if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.Title != "Task" {
+					t.Errorf("request %d body: title = %q, err = %v", requests, req.Title, err)
+				}
+				if requests == 1 {
+					tt.fail(w)
+					return
+				}
+				w.WriteHeader(http.StatusCreated)
+				json.NewEncoder(w).Encode(map[string]interface{}{"id": 1, "number": 42})
+			}))
+			defer server.Close()
+
+			client := &Client{HTTPClient: server.Client(), BaseURL: server.URL, Sleep: func(time.Duration) {}}
+			_, err := client.CreateIssue(CreateIssueOptions{Owner: "owner", Repo: "repo", Title: "Task"})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("CreateIssue() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if requests != tt.wantRequests {
+				t.Errorf("POST sent %d times, want %d", requests, tt.wantRequests)
+			}
+		})
+	}
+}
+
+func TestClientRetriesGraphQLQueriesOnly(t *testing.T) {
+	tests := []struct {
+		name         string
+		call         func(c *Client) error
+		status       int // status of the first response; 502 when unset
+		wantRequests int
+	}{
+		{
+			name: "query",
+			call: func(c *Client) error {
+				_, err := c.GetIssueNodeID("owner", "repo", 42)
+				return err
+			},
+			wantRequests: 2,
+		},
+		{
+			name: "mutation",
+			call: func(c *Client) error {
+				return c.DeleteIssue("I_42")
+			},
+			wantRequests: 1,
+		},
+		{
+			name: "rate-limited mutation",
+			call: func(c *Client) error {
+				return c.DeleteIssue("I_42")
+			},
+			status:       http.StatusTooManyRequests,
+			wantRequests: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			requests := 0
+			bodies := map[string]bool{}
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				requests++
+				var req struct {
+					Query string `json:"query"`
+				}
+				json.NewDecoder(r.Body).Decode(&req)
+				bodies[req.Query] = true
+				if requests == 1 {
+					status := tt.status
+					if status == 0 {
+						status = http.StatusBadGateway
+					}
+					w.WriteHeader(status)
+					return
+				}
+				fmt.Fprint(w, `{"data": {"repository": {"issue": {"id": "I_42"}}}}`)
+			}))
+			defer server.Close()
+
+			client := &Client{HTTPClient: server.Client(), BaseURL: server.URL, Sleep: func(time.Duration) {}}
+			tt.call(client)
+			if requests != tt.wantRequests {
+				t.Errorf("requests = %d, want %d", requests, tt.wantRequests)
+			}
+			if _, ok := bodies[""]; ok {
+				t.Error("a retry was sent without the request body")
+			}
+		})
+	}
+}
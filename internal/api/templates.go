@@ -0,0 +1,112 @@
+package api
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/gwyn/gh-subissue/internal/debug"
+)
+
+// issueTemplateDir is where GitHub looks for issue templates and forms.
+const issueTemplateDir = ".github/ISSUE_TEMPLATE"
+
+// IssueTemplateFile is a file from a repository's issue template directory.
+type IssueTemplateFile struct {
+	Name    string // file name, e.g. "bug_report.yml"
+	Content string
+}
+
+// ListIssueTemplates returns the Markdown templates and YAML issue forms in
+// .github/ISSUE_TEMPLATE. The template chooser's config.yml is skipped.
+// A repository without templates returns an empty list.
+func (c *Client) ListIssueTemplates(owner, repo string) ([]IssueTemplateFile, error) {
+	debug.Log("ListIssueTemplates", "owner", owner, "repo", repo)
+
+	var entries []struct {
+		Name string `json:"name"`
+		Path string `json:"path"`
+		Type string `json:"type"`
+	}
+	err := c.getContents(owner, repo, issueTemplateDir, &entries)
+	if IsNotFound(err) {
+		debug.Log("ListIssueTemplates", "result", "no_template_dir")
+		return nil, nil
+	}
+	if err != nil {
+		debug.Error("ListIssueTemplates", err, "stage", "list_dir")
+		return nil, err
+	}
+
+	var files []IssueTemplateFile
+	for _, entry := range entries {
+		name := strings.ToLower(entry.Name)
+		if entry.Type != "file" || name == "config.yml" || name == "config.yaml" {
+			continue
+		}
+		if !strings.HasSuffix(name, ".md") && !strings.HasSuffix(name, ".yml") && !strings.HasSuffix(name, ".yaml") {
+			continue
+		}
+
+		var file struct {
+			Content  string `json:"content"`
+			Encoding string `json:"encoding"`
+		}
+		if err := c.getContents(owner, repo, entry.Path, &file); err != nil {
+			debug.Error("ListIssueTemplates", err, "stage", "get_file", "path", entry.Path)
+			return nil, err
+		}
+
+		content := file.Content
+		if file.Encoding == "base64" {
+			data, err := base64.StdEncoding.DecodeString(strings.ReplaceAll(file.Content, "\n", ""))
+			if err != nil {
+				debug.Error("ListIssueTemplates", err, "stage", "decode_content", "path", entry.Path)
+				return nil, fmt.Errorf("failed to decode %s: %w", entry.Path, err)
+			}
+			content = string(data)
+		}
+		files = append(files, IssueTemplateFile{Name: entry.Name, Content: content})
+	}
+
+	debug.Log("ListIssueTemplates", "result_count", len(files))
+	return files, nil
+}
+
+// getContents fetches a path from the repository contents API and decodes the JSON into v.
+func (c *Client) getContents(owner, repo, path string, v interface{}) error {
+	url := fmt.Sprintf("%s/repos/%s/%s/contents/%s", c.BaseURL, owner, repo, path)
+	debug.Log("getContents", "url", url)
+
+	req, err := http.NewRequest("GET", url, nil)
+	if err != nil {
+		debug.Error("getContents", err, "stage", "new_request")
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := c.do(req)
+	if err != nil {
+		debug.Error("getContents", err, "stage", "do_request")
+		return fmt.Errorf("failed to send request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	debug.Log("getContents", "status_code", resp.StatusCode)
+	if resp.StatusCode != http.StatusOK {
+		var errResp struct {
+			Message string `json:"message"`
+		}
+		json.NewDecoder(resp.Body).Decode(&errResp)
+		apiErr := newAPIError(resp.StatusCode, errResp.Message, fmt.Sprintf("get %s", path))
+		debug.Error("getContents", apiErr, "status", resp.StatusCode)
+		return apiErr
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+		debug.Error("getContents", err, "stage", "decode_response")
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,76 @@
+package api
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestListIssueTemplates(t *testing.T) {
+	files := map[string]string{
+		"bug_report.md": "---\nname: Bug report\n---\nDescribe the bug\n",
+		"feature.yml":   "name: Feature\nbody: []\n",
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		const dir = "/repos/owner/repo/contents/.github/ISSUE_TEMPLATE"
+		if r.URL.Path == dir {
+			json.NewEncoder(w).Encode([]map[string]string{
+				{"name": "bug_report.md", "path": ".github/ISSUE_TEMPLATE/bug_report.md", "type": "file"},
+				{"name": "config.yml", "path": ".github/ISSUE_TEMPLATE/config.yml", "type": "file"},
+				{"name": "feature.yml", "path": ".github/ISSUE_TEMPLATE/feature.yml", "type": "file"},
+				{"name": "README.txt", "path": ".github/ISSUE_TEMPLATE/README.txt", "type": "file"},
+				{"name": "nested", "path": ".github/ISSUE_TEMPLATE/nested", "type": "dir"},
+			})
+			return
+		}
+		name := r.URL.Path[len(dir)+1:]
+		content, ok := files[name]
+		if !ok {
+			t.Errorf("unexpected request: %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		encoded := base64.StdEncoding.EncodeToString([]byte(content))
+		json.NewEncoder(w).Encode(map[string]string{
+			// The API wraps base64 content at 60 characters.
+			"content":  encoded[:10] + "\n" + encoded[10:],
+			"encoding": "base64",
+		})
+	}))
+	defer server.Close()
+
+	client := &Client{HTTPClient: server.Client(), BaseURL: server.URL}
+	templates, err := client.ListIssueTemplates("owner", "repo")
+	if err != nil {
+		t.Fatalf("ListIssueTemplates() error = %v", err)
+	}
+
+	if len(templates) != 2 {
+		t.Fatalf("got %d templates, want 2: %+v", len(templates), templates)
+	}
+	for _, tmpl := range templates {
+		if tmpl.Content != files[tmpl.Name] {
+			t.Errorf("content of %s = %q, want %q", tmpl.Name, tmpl.Content, files[tmpl.Name])
+		}
+	}
+}
+
+func TestListIssueTemplatesNoDirectory(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode(map[string]string{"message": "Not Found"})
+	}))
+	defer server.Close()
+
+	client := &Client{HTTPClient: server.Client(), BaseURL: server.URL}
+	templates, err := client.ListIssueTemplates("owner", "repo")
+	if err != nil {
+		t.Fatalf("ListIssueTemplates() error = %v", err)
+	}
+	if len(templates) != 0 {
+		t.Errorf("got %d templates, want none", len(templates))
+	}
+}
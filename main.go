@@ -1,12 +1,13 @@
 package main
 
 import (
+	"errors"
 	"fmt"
 	"os"
+	"strconv"
 
 	"github.com/cli/go-gh/v2/pkg/api"
 	"github.com/cli/go-gh/v2/pkg/browser"
-	"github.com/cli/go-gh/v2/pkg/prompter"
 	"github.com/cli/go-gh/v2/pkg/repository"
 	"github.com/cli/go-gh/v2/pkg/term"
 
@@ -15,6 +16,10 @@ import (
 	"github.com/gwyn/gh-subissue/internal/debug"
 )
 
+// exitNoParent is the exit status when `parent` is asked about an issue
+// that has no parent, so scripts can tell it apart from real failures.
+const exitNoParent = 3
+
 func main() {
 	debug.Init()
 	debug.Log("main", "version", "0.1.0", "args", os.Args)
@@ -22,6 +27,11 @@ func main() {
 	if err := run(); err != nil {
 		debug.Error("main", err)
 		fmt.Fprintf(os.Stderr, "error: %v\n", err)
+
+		var noParent *cmd.NoParentError
+		if errors.As(err, &noParent) {
+			os.Exit(exitNoParent)
+		}
 		os.Exit(1)
 	}
 }
@@ -48,6 +58,39 @@ func run() error {
 	case "repos":
 		debug.Log("run", "action", "runRepos", "repos_args", args[1:])
 		return runRepos(args[1:])
+	case "add":
+		debug.Log("run", "action", "runAdd", "add_args", args[1:])
+		return runAdd(args[1:])
+	case "remove":
+		debug.Log("run", "action", "runRemove", "remove_args", args[1:])
+		return runRemove(args[1:])
+	case "move":
+		debug.Log("run", "action", "runMove", "move_args", args[1:])
+		return runMove(args[1:])
+	case "tree":
+		debug.Log("run", "action", "runTree", "tree_args", args[1:])
+		return runTree(args[1:])
+	case "parent":
+		debug.Log("run", "action", "runParent", "parent_args", args[1:])
+		return runParent(args[1:])
+	case "status":
+		debug.Log("run", "action", "runStatus", "status_args", args[1:])
+		return runStatus(args[1:])
+	case "import":
+		debug.Log("run", "action", "runImport", "import_args", args[1:])
+		return runImport(args[1:])
+	case "split":
+		debug.Log("run", "action", "runSplit", "split_args", args[1:])
+		return runSplit(args[1:])
+	case "close":
+		debug.Log("run", "action", "runClose", "close_args", args[1:])
+		return runClose(args[1:])
+	case "reopen":
+		debug.Log("run", "action", "runReopen", "reopen_args", args[1:])
+		return runReopen(args[1:])
+	case "sync":
+		debug.Log("run", "action", "runSync", "sync_args", args[1:])
+		return runSync(args[1:])
 	case "help", "--help", "-h":
 		debug.Log("run", "action", "printUsage", "reason", "help_flag")
 		return printUsage()
@@ -72,84 +115,16 @@ func runCreate(args []string) error {
 	debug.Log("runCreate", "parsed_opts", fmt.Sprintf("%+v", opts))
 
 	// Set up prompter for interactive mode first (needed for repo resolution)
-	// Use term.FromEnv() to respect GH_FORCE_TTY and other env vars
-	var p cmd.Prompter
-	t := term.FromEnv()
-	isStdinTerminal := term.IsTerminal(os.Stdin)
-	isOutputTerminal := t.IsTerminalOutput()
-	debug.Log("runCreate", "stdin_is_terminal", isStdinTerminal, "output_is_terminal", isOutputTerminal)
+	p := newPrompter("runCreate")
 
-	if isStdinTerminal && isOutputTerminal {
-		p = prompter.New(os.Stdin, os.Stdout, os.Stderr)
-		debug.Log("runCreate", "prompter", "enabled")
-	} else {
-		debug.Log("runCreate", "prompter", "disabled")
-	}
-
-	// Resolve repository
-	var owner, repoName, host string
-	if opts.Repo != "" {
-		debug.Log("runCreate", "repo_source", "flag", "repo_flag", opts.Repo)
-		owner, repoName, err = cmd.ParseRepo(opts.Repo)
-		if err != nil {
-			debug.Error("runCreate", err, "stage", "ParseRepo")
-			return err
-		}
-		// When using --repo flag, try to detect host from current repo, fallback to github.com
-		if repo, err := repository.Current(); err == nil {
-			host = repo.Host
-			debug.Log("runCreate", "host_source", "current_repo", "host", host)
-		} else {
-			host = "github.com"
-			debug.Log("runCreate", "host_source", "fallback", "host", host)
-		}
-	} else {
-		debug.Log("runCreate", "repo_source", "current_directory")
-		repo, repoErr := repository.Current()
-		if repoErr != nil {
-			debug.Log("runCreate", "repo_lookup_failed", repoErr.Error())
-			// Try interactive prompt if available
-			if p != nil {
-				debug.Log("runCreate", "action", "prompting_for_repo")
-				owner, repoName, err = cmd.PromptRepository(p)
-				if err != nil {
-					debug.Error("runCreate", err, "stage", "PromptRepository")
-					return err
-				}
-				host = "github.com"
-			} else {
-				debug.Error("runCreate", repoErr, "stage", "repository.Current")
-				return fmt.Errorf("could not determine repository: %w\n\nTo list your repositories:\n  gh repo list\n\nThen specify with --repo:\n  gh subissue create --repo owner/repo", repoErr)
-			}
-		} else {
-			owner = repo.Owner
-			repoName = repo.Name
-			host = repo.Host
-			debug.Log("runCreate", "resolved_repo", owner+"/"+repoName, "host", host)
-		}
-	}
-
-	// Determine API base URL based on host
-	var baseURL string
-	if host == "github.com" || host == "" {
-		baseURL = "https://api.github.com"
-	} else {
-		baseURL = fmt.Sprintf("https://%s/api/v3", host)
-	}
-	debug.Log("runCreate", "base_url", baseURL, "owner", owner, "repo", repoName)
-
-	// Create authenticated HTTP client from go-gh
-	httpClient, err := api.DefaultHTTPClient()
+	owner, repoName, host, err := resolveRepo("runCreate", opts.Repo, "gh subissue create --repo owner/repo", p)
 	if err != nil {
-		debug.Error("runCreate", err, "stage", "DefaultHTTPClient")
-		return fmt.Errorf("failed to create HTTP client: %w", err)
+		return err
 	}
-	debug.Log("runCreate", "http_client", "created")
 
-	// Use the tested internal/api.Client
-	client := &internalapi.Client{
-		HTTPClient: httpClient,
-		BaseURL:    baseURL,
+	client, err := newAPIClient("runCreate", host)
+	if err != nil {
+		return err
 	}
 
 	// Set up browser opener
@@ -160,6 +135,7 @@ func runCreate(args []string) error {
 		Owner:          owner,
 		Repo:           repoName,
 		Out:            os.Stdout,
+		ErrOut:         os.Stderr,
 		Stdin:          os.Stdin,
 		ValidateParent: false,
 		OpenBrowser:    b.Browse,
@@ -181,76 +157,16 @@ func runList(args []string) error {
 	debug.Log("runList", "parsed_opts", fmt.Sprintf("%+v", opts))
 
 	// Set up prompter for interactive mode first (needed for repo resolution)
-	var p cmd.Prompter
-	t := term.FromEnv()
-	isStdinTerminal := term.IsTerminal(os.Stdin)
-	isOutputTerminal := t.IsTerminalOutput()
-	debug.Log("runList", "stdin_is_terminal", isStdinTerminal, "output_is_terminal", isOutputTerminal)
+	p := newPrompter("runList")
 
-	if isStdinTerminal && isOutputTerminal {
-		p = prompter.New(os.Stdin, os.Stdout, os.Stderr)
-		debug.Log("runList", "prompter", "enabled")
-	} else {
-		debug.Log("runList", "prompter", "disabled")
-	}
-
-	// Resolve repository
-	var owner, repoName, host string
-	if opts.Repo != "" {
-		debug.Log("runList", "repo_source", "flag", "repo_flag", opts.Repo)
-		owner, repoName, err = cmd.ParseRepo(opts.Repo)
-		if err != nil {
-			debug.Error("runList", err, "stage", "ParseRepo")
-			return err
-		}
-		if repo, err := repository.Current(); err == nil {
-			host = repo.Host
-		} else {
-			host = "github.com"
-		}
-	} else {
-		debug.Log("runList", "repo_source", "current_directory")
-		repo, repoErr := repository.Current()
-		if repoErr != nil {
-			debug.Log("runList", "repo_lookup_failed", repoErr.Error())
-			// Try interactive prompt if available
-			if p != nil {
-				debug.Log("runList", "action", "prompting_for_repo")
-				owner, repoName, err = cmd.PromptRepository(p)
-				if err != nil {
-					debug.Error("runList", err, "stage", "PromptRepository")
-					return err
-				}
-				host = "github.com"
-			} else {
-				debug.Error("runList", repoErr, "stage", "repository.Current")
-				return fmt.Errorf("could not determine repository: %w\n\nTo list your repositories:\n  gh repo list\n\nThen specify with --repo:\n  gh subissue list --repo owner/repo", repoErr)
-			}
-		} else {
-			owner = repo.Owner
-			repoName = repo.Name
-			host = repo.Host
-		}
-	}
-
-	// Determine API base URL based on host
-	var baseURL string
-	if host == "github.com" || host == "" {
-		baseURL = "https://api.github.com"
-	} else {
-		baseURL = fmt.Sprintf("https://%s/api/v3", host)
-	}
-
-	// Create authenticated HTTP client
-	httpClient, err := api.DefaultHTTPClient()
+	owner, repoName, host, err := resolveRepo("runList", opts.Repo, "gh subissue list --repo owner/repo", p)
 	if err != nil {
-		debug.Error("runList", err, "stage", "DefaultHTTPClient")
-		return fmt.Errorf("failed to create HTTP client: %w", err)
+		return err
 	}
 
-	client := &internalapi.Client{
-		HTTPClient: httpClient,
-		BaseURL:    baseURL,
+	client, err := newAPIClient("runList", host)
+	if err != nil {
+		return err
 	}
 
 	runner := &cmd.ListRunner{
@@ -275,76 +191,16 @@ func runEdit(args []string) error {
 	debug.Log("runEdit", "parsed_opts", fmt.Sprintf("%+v", opts))
 
 	// Set up prompter for interactive mode first (needed for repo resolution)
-	var p cmd.Prompter
-	t := term.FromEnv()
-	isStdinTerminal := term.IsTerminal(os.Stdin)
-	isOutputTerminal := t.IsTerminalOutput()
-	debug.Log("runEdit", "stdin_is_terminal", isStdinTerminal, "output_is_terminal", isOutputTerminal)
-
-	if isStdinTerminal && isOutputTerminal {
-		p = prompter.New(os.Stdin, os.Stdout, os.Stderr)
-		debug.Log("runEdit", "prompter", "enabled")
-	} else {
-		debug.Log("runEdit", "prompter", "disabled")
-	}
-
-	// Resolve repository
-	var owner, repoName, host string
-	if opts.Repo != "" {
-		debug.Log("runEdit", "repo_source", "flag", "repo_flag", opts.Repo)
-		owner, repoName, err = cmd.ParseRepo(opts.Repo)
-		if err != nil {
-			debug.Error("runEdit", err, "stage", "ParseRepo")
-			return err
-		}
-		if repo, err := repository.Current(); err == nil {
-			host = repo.Host
-		} else {
-			host = "github.com"
-		}
-	} else {
-		debug.Log("runEdit", "repo_source", "current_directory")
-		repo, repoErr := repository.Current()
-		if repoErr != nil {
-			debug.Log("runEdit", "repo_lookup_failed", repoErr.Error())
-			// Try interactive prompt if available
-			if p != nil {
-				debug.Log("runEdit", "action", "prompting_for_repo")
-				owner, repoName, err = cmd.PromptRepository(p)
-				if err != nil {
-					debug.Error("runEdit", err, "stage", "PromptRepository")
-					return err
-				}
-				host = "github.com"
-			} else {
-				debug.Error("runEdit", repoErr, "stage", "repository.Current")
-				return fmt.Errorf("could not determine repository: %w\n\nTo list your repositories:\n  gh repo list\n\nThen specify with --repo:\n  gh subissue edit <issue-number> --repo owner/repo", repoErr)
-			}
-		} else {
-			owner = repo.Owner
-			repoName = repo.Name
-			host = repo.Host
-		}
-	}
-
-	// Determine API base URL based on host
-	var baseURL string
-	if host == "github.com" || host == "" {
-		baseURL = "https://api.github.com"
-	} else {
-		baseURL = fmt.Sprintf("https://%s/api/v3", host)
-	}
+	p := newPrompter("runEdit")
 
-	// Create authenticated HTTP client
-	httpClient, err := api.DefaultHTTPClient()
+	owner, repoName, host, err := resolveRepo("runEdit", opts.Repo, "gh subissue edit <issue-number> --repo owner/repo", p)
 	if err != nil {
-		debug.Error("runEdit", err, "stage", "DefaultHTTPClient")
-		return fmt.Errorf("failed to create HTTP client: %w", err)
+		return err
 	}
 
-	client := &internalapi.Client{
-		HTTPClient: httpClient,
-		BaseURL:    baseURL,
+	client, err := newAPIClient("runEdit", host)
+	if err != nil {
+		return err
 	}
 
 	runner := &cmd.EditRunner{
@@ -352,6 +208,7 @@ func runEdit(args []string) error {
 		Owner:    owner,
 		Repo:     repoName,
 		Out:      os.Stdout,
+		Stdin:    os.Stdin,
 		Prompter: p,
 	}
 
@@ -368,29 +225,479 @@ func runRepos(args []string) error {
 	}
 	debug.Log("runRepos", "parsed_opts", fmt.Sprintf("%+v", opts))
 
-	// Create authenticated HTTP client
-	httpClient, err := api.DefaultHTTPClient()
+	// Use github.com as the default host for repos command
+	client, err := newAPIClient("runRepos", "github.com")
+	if err != nil {
+		return err
+	}
+
+	runner := &cmd.ReposRunner{
+		Client: client,
+		Out:    os.Stdout,
+	}
+
+	return runner.Run(*opts)
+}
+
+func runAdd(args []string) error {
+	debug.Log("runAdd", "args", args)
+
+	opts, err := cmd.ParseAddFlags(args)
 	if err != nil {
-		debug.Error("runRepos", err, "stage", "DefaultHTTPClient")
-		return fmt.Errorf("failed to create HTTP client: %w", err)
+		debug.Error("runAdd", err, "stage", "ParseAddFlags")
+		return err
 	}
+	debug.Log("runAdd", "parsed_opts", fmt.Sprintf("%+v", opts))
 
-	// Use github.com as the default host for repos command
-	baseURL := "https://api.github.com"
+	p := newPrompter("runAdd")
 
-	client := &internalapi.Client{
-		HTTPClient: httpClient,
-		BaseURL:    baseURL,
+	owner, repoName, host, err := resolveRepo("runAdd", opts.Repo, "gh subissue add <issue-number>... --parent <number> --repo owner/repo", p)
+	if err != nil {
+		return err
 	}
 
-	runner := &cmd.ReposRunner{
+	client, err := newAPIClient("runAdd", host)
+	if err != nil {
+		return err
+	}
+
+	runner := &cmd.AddRunner{
+		Client:   client,
+		Owner:    owner,
+		Repo:     repoName,
+		Out:      os.Stdout,
+		Prompter: p,
+	}
+
+	return runner.Run(*opts)
+}
+
+func runRemove(args []string) error {
+	debug.Log("runRemove", "args", args)
+
+	opts, err := cmd.ParseRemoveFlags(args)
+	if err != nil {
+		debug.Error("runRemove", err, "stage", "ParseRemoveFlags")
+		return err
+	}
+	debug.Log("runRemove", "parsed_opts", fmt.Sprintf("%+v", opts))
+
+	p := newPrompter("runRemove")
+
+	owner, repoName, host, err := resolveRepo("runRemove", opts.Repo, "gh subissue remove <issue-number>... --parent <number> --repo owner/repo", p)
+	if err != nil {
+		return err
+	}
+
+	client, err := newAPIClient("runRemove", host)
+	if err != nil {
+		return err
+	}
+
+	runner := &cmd.RemoveRunner{
+		Client:   client,
+		Owner:    owner,
+		Repo:     repoName,
+		Out:      os.Stdout,
+		Prompter: p,
+	}
+
+	return runner.Run(*opts)
+}
+
+func runMove(args []string) error {
+	debug.Log("runMove", "args", args)
+
+	opts, err := cmd.ParseMoveFlags(args)
+	if err != nil {
+		debug.Error("runMove", err, "stage", "ParseMoveFlags")
+		return err
+	}
+	debug.Log("runMove", "parsed_opts", fmt.Sprintf("%+v", opts))
+
+	p := newPrompter("runMove")
+
+	owner, repoName, host, err := resolveRepo("runMove", opts.Repo, "gh subissue move <issue-number> --parent <number> --top --repo owner/repo", p)
+	if err != nil {
+		return err
+	}
+
+	client, err := newAPIClient("runMove", host)
+	if err != nil {
+		return err
+	}
+
+	runner := &cmd.MoveRunner{
+		Client:   client,
+		Owner:    owner,
+		Repo:     repoName,
+		Out:      os.Stdout,
+		Prompter: p,
+	}
+
+	return runner.Run(*opts)
+}
+
+func runTree(args []string) error {
+	debug.Log("runTree", "args", args)
+
+	opts, err := cmd.ParseTreeFlags(args)
+	if err != nil {
+		debug.Error("runTree", err, "stage", "ParseTreeFlags")
+		return err
+	}
+	debug.Log("runTree", "parsed_opts", fmt.Sprintf("%+v", opts))
+
+	p := newPrompter("runTree")
+
+	owner, repoName, host, err := resolveRepo("runTree", opts.Repo, "gh subissue tree <issue-number> --repo owner/repo", p)
+	if err != nil {
+		return err
+	}
+
+	client, err := newAPIClient("runTree", host)
+	if err != nil {
+		return err
+	}
+
+	runner := &cmd.TreeRunner{
+		Client:   client,
+		Owner:    owner,
+		Repo:     repoName,
+		Out:      os.Stdout,
+		Prompter: p,
+	}
+
+	return runner.Run(*opts)
+}
+
+func runParent(args []string) error {
+	debug.Log("runParent", "args", args)
+
+	opts, err := cmd.ParseParentFlags(args)
+	if err != nil {
+		debug.Error("runParent", err, "stage", "ParseParentFlags")
+		return err
+	}
+	debug.Log("runParent", "parsed_opts", fmt.Sprintf("%+v", opts))
+
+	p := newPrompter("runParent")
+
+	owner, repoName, host, err := resolveRepo("runParent", opts.Repo, "gh subissue parent 43 --repo owner/repo", p)
+	if err != nil {
+		return err
+	}
+
+	client, err := newAPIClient("runParent", host)
+	if err != nil {
+		return err
+	}
+
+	runner := &cmd.ParentRunner{
+		Client:   client,
+		Owner:    owner,
+		Repo:     repoName,
+		Out:      os.Stdout,
+		Prompter: p,
+	}
+
+	return runner.Run(*opts)
+}
+
+func runStatus(args []string) error {
+	debug.Log("runStatus", "args", args)
+
+	opts, err := cmd.ParseStatusFlags(args)
+	if err != nil {
+		debug.Error("runStatus", err, "stage", "ParseStatusFlags")
+		return err
+	}
+	debug.Log("runStatus", "parsed_opts", fmt.Sprintf("%+v", opts))
+
+	p := newPrompter("runStatus")
+
+	owner, repoName, host, err := resolveRepo("runStatus", opts.Repo, "gh subissue status 42 --repo owner/repo", p)
+	if err != nil {
+		return err
+	}
+
+	client, err := newAPIClient("runStatus", host)
+	if err != nil {
+		return err
+	}
+
+	runner := &cmd.StatusRunner{
+		Client:   client,
+		Owner:    owner,
+		Repo:     repoName,
+		Out:      os.Stdout,
+		Prompter: p,
+	}
+
+	return runner.Run(*opts)
+}
+
+func runImport(args []string) error {
+	debug.Log("runImport", "args", args)
+
+	opts, err := cmd.ParseImportFlags(args)
+	if err != nil {
+		debug.Error("runImport", err, "stage", "ParseImportFlags")
+		return err
+	}
+	debug.Log("runImport", "parsed_opts", fmt.Sprintf("%+v", opts))
+
+	p := newPrompter("runImport")
+
+	owner, repoName, host, err := resolveRepo("runImport", opts.Repo, "gh subissue import plan.yaml --repo owner/repo", p)
+	if err != nil {
+		return err
+	}
+
+	client, err := newAPIClient("runImport", host)
+	if err != nil {
+		return err
+	}
+
+	runner := &cmd.ImportRunner{
+		Client: client,
+		Owner:  owner,
+		Repo:   repoName,
+		Out:    os.Stdout,
+	}
+
+	return runner.Run(*opts)
+}
+
+func runSplit(args []string) error {
+	debug.Log("runSplit", "args", args)
+
+	opts, err := cmd.ParseSplitFlags(args)
+	if err != nil {
+		debug.Error("runSplit", err, "stage", "ParseSplitFlags")
+		return err
+	}
+	debug.Log("runSplit", "parsed_opts", fmt.Sprintf("%+v", opts))
+
+	p := newPrompter("runSplit")
+
+	owner, repoName, host, err := resolveRepo("runSplit", opts.Repo, "gh subissue split 42 --repo owner/repo", p)
+	if err != nil {
+		return err
+	}
+
+	client, err := newAPIClient("runSplit", host)
+	if err != nil {
+		return err
+	}
+
+	runner := &cmd.SplitRunner{
+		Client:   client,
+		Owner:    owner,
+		Repo:     repoName,
+		Out:      os.Stdout,
+		Prompter: p,
+	}
+
+	return runner.Run(*opts)
+}
+
+func runClose(args []string) error {
+	debug.Log("runClose", "args", args)
+
+	opts, err := cmd.ParseCloseFlags(args)
+	if err != nil {
+		debug.Error("runClose", err, "stage", "ParseCloseFlags")
+		return err
+	}
+	debug.Log("runClose", "parsed_opts", fmt.Sprintf("%+v", opts))
+
+	p := newPrompter("runClose")
+
+	owner, repoName, host, err := resolveRepo("runClose", opts.Repo, "gh subissue close <issue-number> --repo owner/repo", p)
+	if err != nil {
+		return err
+	}
+
+	client, err := newAPIClient("runClose", host)
+	if err != nil {
+		return err
+	}
+
+	runner := &cmd.CloseRunner{
+		Client:   client,
+		Owner:    owner,
+		Repo:     repoName,
+		Out:      os.Stdout,
+		Prompter: p,
+	}
+
+	return runner.Run(*opts)
+}
+
+func runReopen(args []string) error {
+	debug.Log("runReopen", "args", args)
+
+	opts, err := cmd.ParseReopenFlags(args)
+	if err != nil {
+		debug.Error("runReopen", err, "stage", "ParseReopenFlags")
+		return err
+	}
+	debug.Log("runReopen", "parsed_opts", fmt.Sprintf("%+v", opts))
+
+	p := newPrompter("runReopen")
+
+	owner, repoName, host, err := resolveRepo("runReopen", opts.Repo, "gh subissue reopen <issue-number> --repo owner/repo", p)
+	if err != nil {
+		return err
+	}
+
+	client, err := newAPIClient("runReopen", host)
+	if err != nil {
+		return err
+	}
+
+	runner := &cmd.ReopenRunner{
+		Client:   client,
+		Owner:    owner,
+		Repo:     repoName,
+		Out:      os.Stdout,
+		Prompter: p,
+	}
+
+	return runner.Run(*opts)
+}
+
+func runSync(args []string) error {
+	debug.Log("runSync", "args", args)
+
+	opts, err := cmd.ParseSyncFlags(args)
+	if err != nil {
+		debug.Error("runSync", err, "stage", "ParseSyncFlags")
+		return err
+	}
+	debug.Log("runSync", "parsed_opts", fmt.Sprintf("%+v", opts))
+
+	// sync never prompts, so it behaves the same in CI as in a terminal
+	owner, repoName, host, err := resolveRepo("runSync", opts.Repo, "gh subissue sync --repo owner/repo", nil)
+	if err != nil {
+		return err
+	}
+
+	client, err := newAPIClient("runSync", host)
+	if err != nil {
+		return err
+	}
+
+	runner := &cmd.SyncRunner{
 		Client: client,
+		Owner:  owner,
+		Repo:   repoName,
 		Out:    os.Stdout,
 	}
 
 	return runner.Run(*opts)
 }
 
+// newPrompter returns a terminal prompter when both stdin and stdout are
+// terminals, or nil for non-interactive mode.
+// Uses term.FromEnv() to respect GH_FORCE_TTY and other env vars.
+func newPrompter(fn string) cmd.Prompter {
+	t := term.FromEnv()
+	isStdinTerminal := term.IsTerminal(os.Stdin)
+	isOutputTerminal := t.IsTerminalOutput()
+	debug.Log(fn, "stdin_is_terminal", isStdinTerminal, "output_is_terminal", isOutputTerminal)
+
+	if isStdinTerminal && isOutputTerminal {
+		debug.Log(fn, "prompter", "enabled")
+		return cmd.NewTerminalPrompter(os.Stdin, os.Stdout, os.Stderr)
+	}
+
+	debug.Log(fn, "prompter", "disabled")
+	return nil
+}
+
+// resolveRepo determines the target repository from the --repo flag, the
+// current directory, or an interactive prompt, in that order.
+// example is the command shown in the hint when no repository can be found.
+func resolveRepo(fn, repoFlag, example string, p cmd.Prompter) (owner, repoName, host string, err error) {
+	if repoFlag != "" {
+		debug.Log(fn, "repo_source", "flag", "repo_flag", repoFlag)
+		owner, repoName, err = cmd.ParseRepo(repoFlag)
+		if err != nil {
+			debug.Error(fn, err, "stage", "ParseRepo")
+			return "", "", "", err
+		}
+		// When using --repo flag, try to detect host from current repo, fallback to github.com
+		if repo, err := repository.Current(); err == nil {
+			host = repo.Host
+			debug.Log(fn, "host_source", "current_repo", "host", host)
+		} else {
+			host = "github.com"
+			debug.Log(fn, "host_source", "fallback", "host", host)
+		}
+		return owner, repoName, host, nil
+	}
+
+	debug.Log(fn, "repo_source", "current_directory")
+	repo, repoErr := repository.Current()
+	if repoErr == nil {
+		debug.Log(fn, "resolved_repo", repo.Owner+"/"+repo.Name, "host", repo.Host)
+		return repo.Owner, repo.Name, repo.Host, nil
+	}
+
+	debug.Log(fn, "repo_lookup_failed", repoErr.Error())
+	// Try interactive prompt if available
+	if p == nil {
+		debug.Error(fn, repoErr, "stage", "repository.Current")
+		return "", "", "", fmt.Errorf("could not determine repository: %w\n\nTo list your repositories:\n  gh repo list\n\nThen specify with --repo:\n  %s", repoErr, example)
+	}
+
+	debug.Log(fn, "action", "prompting_for_repo")
+	owner, repoName, err = cmd.PromptRepository(p)
+	if err != nil {
+		debug.Error(fn, err, "stage", "PromptRepository")
+		return "", "", "", err
+	}
+	return owner, repoName, "github.com", nil
+}
+
+// newAPIClient creates an authenticated API client for the given host.
+func newAPIClient(fn, host string) (*internalapi.Client, error) {
+	// Determine API base URL based on host
+	var baseURL string
+	if host == "github.com" || host == "" {
+		baseURL = "https://api.github.com"
+	} else {
+		baseURL = fmt.Sprintf("https://%s/api/v3", host)
+	}
+	debug.Log(fn, "base_url", baseURL)
+
+	// Create authenticated HTTP client from go-gh
+	httpClient, err := api.DefaultHTTPClient()
+	if err != nil {
+		debug.Error(fn, err, "stage", "DefaultHTTPClient")
+		return nil, fmt.Errorf("failed to create HTTP client: %w", err)
+	}
+
+	client := &internalapi.Client{
+		HTTPClient: httpClient,
+		BaseURL:    baseURL,
+	}
+
+	// Allow tuning how often failed requests are retried
+	if value := os.Getenv("GH_SUBISSUE_MAX_ATTEMPTS"); value != "" {
+		attempts, err := strconv.Atoi(value)
+		if err != nil || attempts < 1 {
+			return nil, fmt.Errorf("invalid GH_SUBISSUE_MAX_ATTEMPTS %q: must be a positive number", value)
+		}
+		client.MaxAttempts = attempts
+	}
+	debug.Log(fn, "max_attempts", client.MaxAttempts)
+
+	return client, nil
+}
+
 func printUsage() error {
 	usage := `gh-subissue - Create and manage sub-issues
 
@@ -400,29 +707,67 @@ USAGE
 COMMANDS
   create    Create a new issue and link it to a parent in one step
   list      List all sub-issues under a parent issue
-  edit      Modify a sub-issue (e.g., add to a project)
+  edit      Modify a sub-issue (e.g., add to or remove from a project)
   repos     List repositories with their sub-issues status (enabled/disabled)
+  add       Link existing issues as sub-issues of a parent
+  remove    Unlink sub-issues from a parent (without closing them)
+  move      Reorder a sub-issue within its parent
+  tree      Show the full sub-issue hierarchy below an issue
+  parent    Show an issue's parent, or its whole chain of ancestors
+  status    Show a parent's progress and open sub-issues by assignee
+  import    Create a whole hierarchy of sub-issues from a YAML, JSON or Markdown plan
+  split     Turn a parent's unchecked checklist items into sub-issues
+  close     Close an issue, refusing while its sub-issues are still open
+  reopen    Reopen an issue, and with --cascade its closed sub-issues
+  sync      Close open parents whose sub-issues are all closed
 
 CREATE FLAGS
-  -p, --parent <number>    Parent issue number (interactive if omitted)
+  -p, --parent <issue>     Parent issue: number, OWNER/REPO#NUMBER or URL (interactive if omitted)
   -t, --title <string>     Issue title
   -b, --body <string>      Issue body
       --body-file <file>   Read body from file (use - for stdin)
   -R, --repo <owner/repo>  Repository (defaults to current)
-  -a, --assignee <user>    Assign users (can repeat)
+  -a, --assignee <user>    Assign users; @me for yourself, @parent for the parent's assignees (can repeat)
   -l, --label <name>       Add labels (can repeat)
-  -m, --milestone <number> Milestone number
-  -P, --project <name>     Add to project (interactive if empty)
+  -m, --milestone <milestone> Milestone number or title
+      --create-missing-labels[=<color>] Create labels that do not exist (default color ededed)
+      --type <name>        Issue type, such as Bug or Task (interactive if omitted)
+      --atomic             Delete or close the new issue if linking or adding to the project fails
+  -P, --project <project>  Add to project: title, number or OWNER/NUMBER (interactive if empty)
+      --field <name=value> Set a project field (can repeat; prompts if value omitted)
+  -T, --issue-template <name> Start from an issue template or issue form
   -w, --web                Open in browser after creation
+      --json <fields>      Output JSON with the specified fields
+  -q, --jq <expression>    Filter JSON output using a jq expression
+      --template <string>  Format JSON output using a Go template
 
 LIST FLAGS
   -p, --parent <number>    Parent issue number (interactive if omitted)
   -R, --repo <owner/repo>  Repository (defaults to current)
+  -L, --limit <int>        Maximum sub-issues to list (default: all)
       --no-header          Omit table header from output
+      --json <fields>      Output JSON with the specified fields
+  -q, --jq <expression>    Filter JSON output using a jq expression
+      --template <string>  Format JSON output using a Go template
 
 EDIT FLAGS
   <issue-number>           Issue number to edit (required)
-  -P, --project <name>     Add to project (interactive if empty)
+  -t, --title <string>     Set the title
+  -b, --body <string>      Set the body
+      --body-file <file>   Read body from file (use "-" for stdin)
+      --add-label <name>   Add a label (can repeat)
+      --remove-label <name> Remove a label (can repeat)
+      --add-assignee <login> Add an assignee (can repeat)
+      --remove-assignee <login> Remove an assignee (can repeat)
+  -m, --milestone <milestone> Set the milestone by number or title ("none" removes it)
+      --type <name>        Set the issue type ("none" removes it, interactive if empty)
+      --state <state>      Set the state: {open|closed}
+      --reason <reason>    Reason for closing: {completed|not_planned}
+  -p, --parent <issue>     Move under a new parent: number, OWNER/REPO#NUMBER or URL
+  -P, --project <project>  Add to project: title, number or OWNER/NUMBER (interactive if empty)
+      --field <name=value> Set a project field (can repeat; prompts if value omitted)
+      --remove-project <project>     Remove from project (interactive if empty)
+      --archive-in-project <project> Archive the issue's item in project (interactive if empty)
   -R, --repo <owner/repo>  Repository (defaults to current)
 
 REPOS FLAGS
@@ -431,18 +776,122 @@ REPOS FLAGS
       --enabled            Show only repos where sub-issues work
       --disabled           Show only repos where sub-issues don't work
       --no-header          Omit table header from output
+      --json <fields>      Output JSON with the specified fields
+  -q, --jq <expression>    Filter JSON output using a jq expression
+      --template <string>  Format JSON output using a Go template
+
+ADD FLAGS
+  [<issue>...]             Issue numbers or URLs to link (interactive if omitted)
+  -p, --parent <number>    Parent issue number (interactive if omitted)
+  -R, --repo <owner/repo>  Repository (defaults to current)
+
+REMOVE FLAGS
+  [<issue>...]             Sub-issue numbers or URLs to unlink (interactive if omitted)
+  -p, --parent <number>    Parent issue number (interactive if omitted)
+  -y, --yes                Skip the confirmation prompt (required when not interactive)
+  -R, --repo <owner/repo>  Repository (defaults to current)
+
+MOVE FLAGS
+  [<issue>]                Sub-issue number or URL to move (interactive if omitted)
+  -p, --parent <number>    Parent issue number (interactive if omitted)
+      --before <number>    Place before this sub-issue
+      --after <number>     Place after this sub-issue
+      --top                Move to the top
+      --bottom             Move to the bottom
+  -R, --repo <owner/repo>  Repository (defaults to current)
+
+TREE FLAGS
+  [<issue>]                Root issue number or URL (interactive if omitted)
+  -d, --depth <int>        Maximum depth to show (default: unlimited)
+  -s, --state <string>     Show only sub-issues in this state: {open|closed|all} (default: all)
+  -R, --repo <owner/repo>  Repository (defaults to current)
+
+PARENT FLAGS
+  [<issue>]                Issue number or URL (interactive if omitted)
+      --ancestors          Show the full chain of parents up to the root
+  -R, --repo <owner/repo>  Repository (defaults to current)
+
+  Exits with status 3 when the issue has no parent.
+
+STATUS FLAGS
+  [<issue>]                Parent issue number or URL (interactive if omitted)
+  -R, --repo <owner/repo>  Repository (defaults to current)
+      --json <fields>      Output JSON with the specified fields
+  -q, --jq <expression>    Filter JSON output using a jq expression
+      --template <string>  Format JSON output using a Go template
+
+IMPORT FLAGS
+  <file>                   Plan file (.yaml, .yml, .json, .md or .markdown)
+  -p, --parent <number>    Existing issue to attach the root issues to (overrides the file)
+  -R, --repo <owner/repo>  Repository (defaults to current)
+
+SPLIT FLAGS
+  [<issue>]                Parent issue number or URL (interactive if omitted)
+      --dry-run            Show the planned sub-issues and rewritten body only
+  -R, --repo <owner/repo>  Repository (defaults to current)
+
+CLOSE FLAGS
+  [<issue>]                Issue number or URL (interactive if omitted)
+  -r, --reason <reason>    Reason for closing: {completed|not_planned} (default: completed)
+      --cascade            Close open sub-issues first, bottom-up, with the same reason
+      --force              Close only the issue, leaving its sub-issues open
+  -R, --repo <owner/repo>  Repository (defaults to current)
+
+REOPEN FLAGS
+  [<issue>]                Issue number or URL (interactive if omitted)
+      --cascade            Reopen closed sub-issues too, top-down
+  -R, --repo <owner/repo>  Repository (defaults to current)
+
+SYNC FLAGS
+  [<issue>]                Check only this parent (defaults to every open parent)
+      --dry-run            Report the parents that would be closed without closing them
+  -R, --repo <owner/repo>  Repository (defaults to current)
+
+JSON FIELDS
+  create                   id, number, url
+  list                     id, number, title, state, type, url
+  repos                    name, fullName, hasIssues, archived, private
+  status                   number, title, url, total, completed, percentCompleted,
+                           open, closed, openByAssignee
 
 ENVIRONMENT VARIABLES
   GH_DEBUG                 Set to any value to enable debug logging (logfmt to stderr)
+  GH_SUBISSUE_MAX_ATTEMPTS Times to send a request that fails with a network error,
+                           server error or rate limit (default 3; 1 disables retries)
+  GH_EDITOR, VISUAL, EDITOR  Editor for the issue body in interactive mode (first set wins)
 
 EXAMPLES
   gh subissue create --title "New task"                           # Interactive parent selection
   gh subissue create --parent 42 --title "Implement feature"
   gh subissue create -p 42 -t "Fix bug" -l bug -a username
   gh subissue create -p 42 -t "Task" --project "Roadmap"          # Add to specific project
+  gh subissue create -p org/planning#42 -t "Task" -R org/service  # Parent in another repository
+  gh subissue create -p 42 -T "Bug report"                        # Start from an issue template
+  gh subissue create -p 42 -t "Task" -m "Sprint 3"                # Milestone by title
+  gh subissue create -p 42 -t "Task" -a @me -a @parent            # Assign yourself and the parent's assignees
+  gh subissue create -p 42 -t "Task" -P "Roadmap" --atomic        # No orphan issue if a step fails
   gh subissue list --parent 42                                    # List sub-issues
   gh subissue list                                                # Interactive parent selection
+  gh subissue list -p 42 --json number,state --jq '.[].number'    # Script against sub-issues
+  gh subissue edit 43 --add-label bug --remove-label triage       # Relabel an issue
+  gh subissue edit 43 --state closed --reason not_planned         # Close as not planned
+  gh subissue edit 43 --parent 50                                 # Move #43 to another epic
+  gh subissue edit 43 --type Bug                                  # Change the issue type
   gh subissue edit 43 --project "Roadmap"                         # Add issue to project
+  gh subissue edit 43 -P "Roadmap" --field "Status=In progress"   # Set a project field
+  gh subissue edit 43 --project my-org/7                          # Add to an org project by number
+  gh subissue edit 43 --remove-project "Roadmap"                  # Remove issue from a project
+  gh subissue add 43 44 --parent 42                               # Link existing issues to a parent
+  gh subissue remove 43 --parent 42 --yes                         # Unlink a sub-issue
+  gh subissue move 45 --parent 42 --top                           # Make #45 the highest priority
+  gh subissue tree 42 --state open                                # Show open work below #42
+  gh subissue parent 43 --ancestors                               # Show the epic chain above #43
+  gh subissue status 42 --json percentCompleted,openByAssignee    # Progress for a standup bot
+  gh subissue import plan.md --parent 42                          # Create an outline below #42
+  gh subissue split 42 --dry-run                                  # Preview converting #42's checklist
+  gh subissue close 42 --cascade --reason not_planned             # Drop an epic and all its work
+  gh subissue reopen 42 --cascade                                 # Reopen an epic and its sub-issues
+  gh subissue sync --dry-run                                      # Find epics whose work is all done
   gh subissue repos                                               # List your repos with sub-issues status
   gh subissue repos my-org                                        # List org repos with sub-issues status
   gh subissue repos --enabled                                     # Only repos where sub-issues are enabled
@@ -457,3 +906,13 @@ var _ cmd.APIClient = (*internalapi.Client)(nil)
 var _ cmd.ListAPIClient = (*internalapi.Client)(nil)
 var _ cmd.EditAPIClient = (*internalapi.Client)(nil)
 var _ cmd.ReposAPIClient = (*internalapi.Client)(nil)
+var _ cmd.AddAPIClient = (*internalapi.Client)(nil)
+var _ cmd.RemoveAPIClient = (*internalapi.Client)(nil)
+var _ cmd.MoveAPIClient = (*internalapi.Client)(nil)
+var _ cmd.TreeAPIClient = (*internalapi.Client)(nil)
+var _ cmd.ParentAPIClient = (*internalapi.Client)(nil)
+var _ cmd.StatusAPIClient = (*internalapi.Client)(nil)
+var _ cmd.ImportAPIClient = (*internalapi.Client)(nil)
+var _ cmd.SplitAPIClient = (*internalapi.Client)(nil)
+var _ cmd.CloseAPIClient = (*internalapi.Client)(nil)
+var _ cmd.SyncAPIClient = (*internalapi.Client)(nil)